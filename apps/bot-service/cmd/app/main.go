@@ -11,6 +11,7 @@ import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/env"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/server"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/whatsapp"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/worker"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
 	"github.com/jmoiron/sqlx"
 )
@@ -33,6 +34,14 @@ func main() {
 		go startWhatsAppBot(ctx, psqlDB, &wg)
 	}
 
+	wg.Add(1)
+	go startImportWorker(ctx, psqlDB, &wg)
+
+	if env.AppEnv.TopicExtractionEnabled {
+		wg.Add(1)
+		go startTopicExtractionWorker(ctx, psqlDB, &wg)
+	}
+
 	go server.Start(env.AppEnv.AppPort)
 
 	<-ctx.Done()
@@ -65,3 +74,17 @@ func startWhatsAppBot(ctx context.Context, db *sqlx.DB, wg *sync.WaitGroup) {
 	botService.Stop()
 	log.Info(log.CustomLogInfo{}, "WhatsApp service stopped")
 }
+
+func startImportWorker(ctx context.Context, db *sqlx.DB, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	worker.RunImportJobs(ctx, db)
+	log.Info(log.CustomLogInfo{}, "Import worker stopped")
+}
+
+func startTopicExtractionWorker(ctx context.Context, db *sqlx.DB, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	worker.RunTopicExtraction(ctx, db, env.AppEnv.TopicExtractionInterval)
+	log.Info(log.CustomLogInfo{}, "Topic extraction worker stopped")
+}
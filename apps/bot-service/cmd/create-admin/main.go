@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	auditrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/repository"
+	auditservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service"
+	authrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/auth/repository"
+	authservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/auth/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/database"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/env"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/bcrypt"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/jwt"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+)
+
+// create-admin creates a dashboard admin account. The password is read from
+// the ADMIN_PASSWORD environment variable so that it doesn't end up in the
+// shell history.
+func main() {
+	email := flag.String("email", "", "admin email")
+	name := flag.String("name", "", "admin name")
+	role := flag.String("role", entity.AdminRoleSuperadmin, "admin role: superadmin, hc_admin or viewer")
+	flag.Parse()
+
+	psqlDB := database.NewPgsqlConn()
+	defer psqlDB.Close()
+
+	auditRepo := auditrepository.NewAuditRepository(psqlDB)
+	auditService := auditservice.NewAuditService(auditRepo, validator.Validator, uuid.UUID)
+
+	authRepo := authrepository.NewAuthRepository(psqlDB)
+	authService := authservice.NewAuthService(authRepo, validator.Validator, uuid.UUID, jwt.NewJwt(env.AppEnv.JwtSecretKey, env.AppEnv.JwtExpTime), bcrypt.Bcrypt, env.AppEnv.JwtRefreshExpTime, auditService)
+
+	actor := entity.AuditActor{Type: entity.AuditActorSystem}
+	res, err := authService.CreateAdmin(context.Background(), actor, &dto.CreateAdminRequest{
+		Email:    *email,
+		Name:     *name,
+		Password: os.Getenv("ADMIN_PASSWORD"),
+		Role:     *role,
+	})
+	if err != nil {
+		log.Fatal(log.CustomLogInfo{
+			"error": err.Error(),
+		}, "[CreateAdmin] failed to create admin")
+	}
+
+	log.Info(log.CustomLogInfo{
+		"id":    res.ID,
+		"email": *email,
+		"role":  *role,
+	}, "[CreateAdmin] admin created")
+}
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_bot_sessions_last_message_at;
+DROP INDEX IF EXISTS idx_bot_sessions_user_id;
+
+DROP TABLE IF EXISTS bot_sessions;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS bot_sessions (
+    phone_number VARCHAR(20) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    chat_jid VARCHAR(255) NOT NULL,
+    conversation_id VARCHAR(255) NOT NULL DEFAULT '',
+    last_message_at TIMESTAMP NOT NULL,
+    waiting_for_rating BOOLEAN NOT NULL DEFAULT FALSE,
+    waiting_for_comment BOOLEAN NOT NULL DEFAULT FALSE,
+    rating INT NOT NULL DEFAULT 0,
+    feedback_prompt_sent BOOLEAN NOT NULL DEFAULT FALSE,
+    feedback_prompt_sent_at TIMESTAMP,
+    is_auto_prompt BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_bot_session_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_bot_sessions_user_id ON bot_sessions(user_id);
+CREATE INDEX IF NOT EXISTS idx_bot_sessions_last_message_at ON bot_sessions(last_message_at);
@@ -0,0 +1,13 @@
+ALTER TABLE bot_sessions
+    DROP CONSTRAINT IF EXISTS fk_bot_session_transcript,
+    DROP COLUMN IF EXISTS transcript_id;
+
+DROP INDEX IF EXISTS idx_messages_conversation_id;
+
+DROP TABLE IF EXISTS messages;
+
+DROP INDEX IF EXISTS idx_conversations_dify_conversation_id;
+DROP INDEX IF EXISTS idx_conversations_started_at;
+DROP INDEX IF EXISTS idx_conversations_user_id;
+
+DROP TABLE IF EXISTS conversations;
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS conversations (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL,
+    dify_conversation_id VARCHAR(255),
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ended_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_conversation_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversations_user_id ON conversations(user_id);
+CREATE INDEX IF NOT EXISTS idx_conversations_started_at ON conversations(started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_conversations_dify_conversation_id ON conversations(dify_conversation_id) WHERE dify_conversation_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS messages (
+    id VARCHAR(36) PRIMARY KEY,
+    conversation_id VARCHAR(36) NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    content TEXT NOT NULL,
+    whatsapp_message_id VARCHAR(255),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_message_conversation FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE CASCADE,
+    CONSTRAINT chk_message_role CHECK (role IN ('user', 'assistant', 'system'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_id ON messages(conversation_id, created_at);
+
+-- Link bot sessions to the conversation that records their transcript
+ALTER TABLE bot_sessions
+    ADD COLUMN transcript_id VARCHAR(36),
+    ADD CONSTRAINT fk_bot_session_transcript FOREIGN KEY (transcript_id) REFERENCES conversations(id) ON DELETE SET NULL;
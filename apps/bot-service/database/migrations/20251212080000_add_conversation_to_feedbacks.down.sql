@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_feedbacks_conversation_id;
+
+ALTER TABLE feedbacks
+    DROP CONSTRAINT IF EXISTS fk_feedback_conversation,
+    DROP COLUMN IF EXISTS dify_conversation_id,
+    DROP COLUMN IF EXISTS conversation_id;
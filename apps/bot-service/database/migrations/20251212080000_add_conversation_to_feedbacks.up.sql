@@ -0,0 +1,6 @@
+ALTER TABLE feedbacks
+    ADD COLUMN conversation_id VARCHAR(36),
+    ADD COLUMN dify_conversation_id VARCHAR(255),
+    ADD CONSTRAINT fk_feedback_conversation FOREIGN KEY (conversation_id) REFERENCES conversations(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_feedbacks_conversation_id ON feedbacks(conversation_id) WHERE conversation_id IS NOT NULL;
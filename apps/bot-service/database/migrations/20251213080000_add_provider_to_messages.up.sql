@@ -0,0 +1,2 @@
+-- AI backend that produced an assistant answer (e.g. dify, gemini)
+ALTER TABLE messages ADD COLUMN provider VARCHAR(50);
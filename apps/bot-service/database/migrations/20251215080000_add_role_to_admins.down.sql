@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_admins_role;
+
+ALTER TABLE admins
+    DROP CONSTRAINT IF EXISTS chk_admin_role,
+    DROP COLUMN IF EXISTS role;
@@ -0,0 +1,8 @@
+-- Existing admins keep full access; new admins default to read-only
+ALTER TABLE admins
+    ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'superadmin',
+    ADD CONSTRAINT chk_admin_role CHECK (role IN ('superadmin', 'hc_admin', 'viewer'));
+
+ALTER TABLE admins ALTER COLUMN role SET DEFAULT 'viewer';
+
+CREATE INDEX IF NOT EXISTS idx_admins_role ON admins(role);
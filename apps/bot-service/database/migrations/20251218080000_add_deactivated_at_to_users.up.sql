@@ -0,0 +1,5 @@
+-- Users missing from an HR roster import can be deactivated instead of deleted,
+-- which keeps their feedback and conversations attached
+ALTER TABLE users ADD COLUMN deactivated_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_users_deactivated_at ON users(deactivated_at) WHERE deactivated_at IS NOT NULL;
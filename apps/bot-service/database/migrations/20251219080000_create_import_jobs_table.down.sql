@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_import_jobs_status_created_at;
+
+DROP TABLE IF EXISTS import_jobs;
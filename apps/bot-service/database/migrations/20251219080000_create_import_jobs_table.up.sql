@@ -0,0 +1,28 @@
+-- The uploaded file is kept until the job finishes, so queued jobs survive a
+-- restart
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id VARCHAR(36) PRIMARY KEY,
+    type VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    file_name VARCHAR(255) NOT NULL,
+    file_format VARCHAR(10) NOT NULL,
+    file_data BYTEA,
+    options JSONB NOT NULL DEFAULT '{}',
+    actor_type VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(36),
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    processed_rows INTEGER NOT NULL DEFAULT 0,
+    result JSONB,
+    error_code VARCHAR(100),
+    error_message TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_import_job_status CHECK (status IN ('pending', 'running', 'completed', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status_created_at ON import_jobs(status, created_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_feedbacks_comment_search;
+
+ALTER TABLE feedbacks DROP COLUMN IF EXISTS comment_search;
@@ -0,0 +1,6 @@
+-- Search over feedback comments. Most comments are in Indonesian, so words are
+-- stemmed with the indonesian config ("cutinya" matches "cuti").
+ALTER TABLE feedbacks
+    ADD COLUMN comment_search TSVECTOR GENERATED ALWAYS AS (to_tsvector('indonesian', COALESCE(comment, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_feedbacks_comment_search ON feedbacks USING GIN (comment_search);
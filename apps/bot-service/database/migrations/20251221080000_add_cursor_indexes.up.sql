@@ -0,0 +1,3 @@
+-- Keyset pagination walks users and feedbacks in (created_at, id) order
+CREATE INDEX IF NOT EXISTS idx_users_created_at_id ON users(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_feedbacks_created_at_id ON feedbacks(created_at DESC, id DESC);
@@ -0,0 +1,3 @@
+ALTER TABLE feedbacks
+    DROP CONSTRAINT IF EXISTS chk_feedback_source,
+    DROP COLUMN IF EXISTS source;
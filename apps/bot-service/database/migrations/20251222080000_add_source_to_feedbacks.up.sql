@@ -0,0 +1,12 @@
+-- Whether the user gave the rating or the bot filled one in after the user
+-- stopped responding
+ALTER TABLE feedbacks
+    ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'user',
+    ADD CONSTRAINT chk_feedback_source CHECK (source IN ('user', 'auto'));
+
+-- Auto-submitted feedback left an auto_feedback message in its transcript
+UPDATE feedbacks
+SET source = 'auto'
+WHERE conversation_id IN (
+    SELECT conversation_id FROM messages WHERE kind = 'auto_feedback'
+);
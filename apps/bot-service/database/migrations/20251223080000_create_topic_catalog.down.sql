@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_topics_canonical_topic_id_created_at;
+ALTER TABLE topics DROP COLUMN IF EXISTS canonical_topic_id;
+DROP TABLE IF EXISTS topic_aliases;
+DROP TABLE IF EXISTS canonical_topics;
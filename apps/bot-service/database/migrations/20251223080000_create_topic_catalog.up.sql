@@ -0,0 +1,51 @@
+-- Topics as the dashboard shows them. Incoming titles are attributed to one
+-- through topic_aliases.
+CREATE TABLE IF NOT EXISTS canonical_topics (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- An alias is a title lowercased with its whitespace collapsed. Every
+-- canonical topic has its own name as an alias.
+CREATE TABLE IF NOT EXISTS topic_aliases (
+    alias VARCHAR(255) PRIMARY KEY,
+    canonical_topic_id VARCHAR(36) NOT NULL REFERENCES canonical_topics(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_topic_aliases_canonical_topic_id ON topic_aliases(canonical_topic_id);
+
+-- topics.title keeps the title as it came in
+ALTER TABLE topics ADD COLUMN canonical_topic_id VARCHAR(36) REFERENCES canonical_topics(id);
+
+-- One canonical topic per existing alias, named after its most mentioned
+-- spelling
+WITH spellings AS (
+    SELECT
+        LOWER(BTRIM(regexp_replace(title, '\s+', ' ', 'g'))) AS alias,
+        BTRIM(regexp_replace(title, '\s+', ' ', 'g')) AS name,
+        SUM(count) AS mentions
+    FROM topics
+    GROUP BY 1, 2
+)
+INSERT INTO canonical_topics (id, name)
+SELECT gen_random_uuid()::text, name
+FROM (
+    SELECT DISTINCT ON (alias) alias, name
+    FROM spellings
+    ORDER BY alias, mentions DESC, name
+) named;
+
+INSERT INTO topic_aliases (alias, canonical_topic_id)
+SELECT LOWER(name), id FROM canonical_topics;
+
+UPDATE topics t
+SET canonical_topic_id = a.canonical_topic_id
+FROM topic_aliases a
+WHERE a.alias = LOWER(BTRIM(regexp_replace(t.title, '\s+', ' ', 'g')));
+
+ALTER TABLE topics ALTER COLUMN canonical_topic_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_topics_canonical_topic_id_created_at ON topics(canonical_topic_id, created_at);
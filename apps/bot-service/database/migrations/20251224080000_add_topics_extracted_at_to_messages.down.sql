@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_messages_topics_pending;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS topics_extracted_at;
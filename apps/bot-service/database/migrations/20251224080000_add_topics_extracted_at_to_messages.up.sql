@@ -0,0 +1,11 @@
+-- When the topic extraction job last looked at a user message. Only new
+-- questions are classified, so everything sent before this is marked as seen.
+ALTER TABLE messages
+    ADD COLUMN topics_extracted_at TIMESTAMP;
+
+UPDATE messages
+SET topics_extracted_at = created_at
+WHERE role = 'user';
+
+CREATE INDEX IF NOT EXISTS idx_messages_topics_pending ON messages(created_at, id)
+    WHERE topics_extracted_at IS NULL AND role = 'user' AND kind = 'text';
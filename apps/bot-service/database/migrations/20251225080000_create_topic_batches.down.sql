@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_topics_topic_batch_id;
+
+ALTER TABLE topics DROP COLUMN IF EXISTS topic_batch_id;
+
+DROP TABLE IF EXISTS topic_batches;
@@ -0,0 +1,23 @@
+-- Every call that records topics is a batch. A source sends each batch_id
+-- once; a retry gets the stored batch back instead of counting again.
+CREATE TABLE IF NOT EXISTS topic_batches (
+    id VARCHAR(36) PRIMARY KEY,
+    batch_id VARCHAR(100) NOT NULL,
+    source VARCHAR(50) NOT NULL,
+    -- Fingerprint of the topics sent, to reject a reused batch_id
+    payload_hash VARCHAR(64) NOT NULL,
+    topic_count INT NOT NULL DEFAULT 0,
+    mention_count INT NOT NULL DEFAULT 0,
+    actor_type VARCHAR(20) NOT NULL,
+    actor_id VARCHAR(36),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rolled_back_at TIMESTAMP,
+    CONSTRAINT uq_topic_batches_source_batch_id UNIQUE (source, batch_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_topic_batches_created_at ON topic_batches(created_at DESC, id DESC);
+
+-- Rows recorded before batches existed have none
+ALTER TABLE topics ADD COLUMN topic_batch_id VARCHAR(36) REFERENCES topic_batches(id);
+
+CREATE INDEX IF NOT EXISTS idx_topics_topic_batch_id ON topics(topic_batch_id);
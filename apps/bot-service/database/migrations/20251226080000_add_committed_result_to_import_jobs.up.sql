@@ -0,0 +1,5 @@
+-- The users an import job wrote, saved in the same transaction as the users.
+-- A job that is re-run after a crash finds it and reports the earlier write
+-- instead of importing the file again.
+ALTER TABLE import_jobs
+    ADD COLUMN committed_result JSONB;
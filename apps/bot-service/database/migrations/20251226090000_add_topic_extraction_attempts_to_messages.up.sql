@@ -0,0 +1,4 @@
+-- How many times topic extraction failed to classify a user message. A message
+-- that keeps failing is marked as seen so it can't hold up the ones after it.
+ALTER TABLE messages
+    ADD COLUMN topic_extraction_attempts INT NOT NULL DEFAULT 0;
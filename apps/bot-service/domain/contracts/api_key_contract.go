@@ -0,0 +1,27 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -destination=../../internal/app/apikey/repository/mock/mock_api_key_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts APIKeyRepository
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *entity.APIKey) error
+	FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	List(ctx context.Context, filter *entity.GetAPIKeysFilter) ([]entity.APIKey, int64, error)
+	Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	UpdateLastUsedAt(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error
+}
+
+type APIKeyService interface {
+	Create(ctx context.Context, actor entity.AuditActor, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error)
+	List(ctx context.Context, query *dto.GetAPIKeysQuery) (*dto.GetAPIKeysResponse, error)
+	Revoke(ctx context.Context, actor entity.AuditActor, param *dto.RevokeAPIKeyParam) error
+	Authenticate(ctx context.Context, key string, scope string) (*entity.APIKey, error)
+}
@@ -0,0 +1,22 @@
+package contracts
+
+import (
+	"context"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+)
+
+//go:generate mockgen -destination=../../internal/app/audit/repository/mock/mock_audit_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts AuditRepository
+//go:generate mockgen -destination=../../internal/app/audit/service/mock/mock_audit_service.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts AuditService
+
+type AuditRepository interface {
+	Create(ctx context.Context, event *entity.AuditEvent) error
+	List(ctx context.Context, filter *entity.GetAuditEventsFilter) ([]entity.AuditEvent, int64, error)
+}
+
+// AuditService is the hook other services call after a change is made.
+type AuditService interface {
+	Record(ctx context.Context, req *dto.RecordAuditEventRequest)
+	List(ctx context.Context, query *dto.GetAuditEventsQuery) (*dto.GetAuditEventsResponse, error)
+}
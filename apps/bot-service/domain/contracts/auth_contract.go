@@ -0,0 +1,38 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -destination=../../internal/app/auth/repository/mock/mock_auth_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts AuthRepository
+
+type AuthRepository interface {
+	CreateAdmin(ctx context.Context, admin *entity.Admin) error
+	FindAdminByID(ctx context.Context, id uuid.UUID) (*entity.Admin, error)
+	FindAdminByEmail(ctx context.Context, email string) (*entity.Admin, error)
+	ListAdmins(ctx context.Context, filter *entity.GetAdminsFilter) ([]entity.Admin, int64, error)
+	UpdateAdmin(ctx context.Context, admin *entity.Admin) error
+	DeleteAdmin(ctx context.Context, id uuid.UUID) error
+	UpdateLastLoginAt(ctx context.Context, id uuid.UUID, lastLoginAt time.Time) error
+	CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error
+	FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	RevokeRefreshToken(ctx context.Context, id uuid.UUID, revokedAt time.Time) error
+	RevokeAdminRefreshTokens(ctx context.Context, adminID uuid.UUID, revokedAt time.Time) error
+}
+
+type AuthService interface {
+	Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error)
+	Refresh(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.RefreshTokenResponse, error)
+	GetMe(ctx context.Context, adminID string) (*dto.GetMeResponse, error)
+	Authorize(ctx context.Context, adminID string, roles []string) (*entity.Admin, error)
+	CreateAdmin(ctx context.Context, actor entity.AuditActor, req *dto.CreateAdminRequest) (*dto.CreateAdminResponse, error)
+	GetAdminByID(ctx context.Context, param *dto.GetAdminByIDParam) (*dto.GetAdminByIDResponse, error)
+	ListAdmins(ctx context.Context, query *dto.GetAdminsQuery) (*dto.GetAdminsResponse, error)
+	UpdateAdmin(ctx context.Context, actor entity.AuditActor, param *dto.UpdateAdminParam, req *dto.UpdateAdminRequest) error
+	DeleteAdmin(ctx context.Context, actor entity.AuditActor, param *dto.DeleteAdminParam) error
+}
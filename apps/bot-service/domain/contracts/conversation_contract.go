@@ -0,0 +1,35 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -destination=../../internal/app/conversation/repository/mock/mock_conversation_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts ConversationRepository
+//go:generate mockgen -destination=../../internal/app/conversation/service/mock/mock_conversation_service.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts ConversationService
+
+type ConversationRepository interface {
+	Create(ctx context.Context, conversation *entity.Conversation) error
+	UpdateDifyConversationID(ctx context.Context, id uuid.UUID, difyConversationID string) error
+	End(ctx context.Context, id uuid.UUID, endedAt time.Time) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Conversation, error)
+	List(ctx context.Context, filter *entity.GetConversationsFilter) ([]entity.Conversation, int64, error)
+	CreateMessage(ctx context.Context, message *entity.Message) error
+	ListMessages(ctx context.Context, conversationID uuid.UUID) ([]entity.Message, error)
+	ListPendingQuestions(ctx context.Context, sentBefore time.Time, limit int) ([]entity.PendingQuestion, error)
+	MarkTopicsExtracted(ctx context.Context, ids []uuid.UUID, extractedAt time.Time) error
+	RecordTopicExtractionFailure(ctx context.Context, ids []uuid.UUID) error
+}
+
+type ConversationService interface {
+	Start(ctx context.Context, req *dto.StartConversationRequest) (*dto.StartConversationResponse, error)
+	SetDifyConversationID(ctx context.Context, req *dto.SetDifyConversationIDRequest) error
+	End(ctx context.Context, req *dto.EndConversationRequest) error
+	RecordMessage(ctx context.Context, req *dto.RecordMessageRequest) error
+	GetByID(ctx context.Context, param *dto.GetConversationByIDParam) (*dto.GetConversationByIDResponse, error)
+	List(ctx context.Context, query *dto.GetConversationsQuery) (*dto.GetConversationsResponse, error)
+}
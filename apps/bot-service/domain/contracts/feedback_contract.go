@@ -14,14 +14,20 @@ type FeedbackRepository interface {
 	Create(ctx context.Context, feedback *entity.Feedback) error
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Feedback, error)
 	List(ctx context.Context, filter *entity.GetFeedbacksFilter) ([]entity.Feedback, int64, error)
-	GetMetrics(ctx context.Context) (float64, int, error)
-	GetSatisfactionTrend(ctx context.Context) ([]entity.SatisfactionTrendRow, error)
+	ListByCursor(ctx context.Context, filter *entity.GetFeedbacksFilter) ([]entity.Feedback, bool, error)
+	Each(ctx context.Context, filter *entity.GetFeedbacksFilter, fn func(feedback *entity.Feedback) error) error
+	GetMetrics(ctx context.Context, filter *entity.FeedbackMetricsFilter) (*entity.FeedbackMetrics, *entity.FeedbackMetrics, error)
+	GetBreakdown(ctx context.Context, filter *entity.FeedbackBreakdownFilter) ([]entity.FeedbackBreakdownRow, error)
+	GetSatisfactionTrend(ctx context.Context, filter *entity.SatisfactionTrendFilter) ([]entity.SatisfactionTrendRow, error)
 }
 
 type FeedbackService interface {
 	Create(ctx context.Context, req *dto.CreateFeedbackRequest) (*dto.CreateFeedbackResponse, error)
 	GetByID(ctx context.Context, param *dto.GetFeedbackByIDParam) (*dto.GetFeedbackByIDResponse, error)
+	GetConversation(ctx context.Context, param *dto.GetFeedbackConversationParam) (*dto.GetConversationByIDResponse, error)
 	List(ctx context.Context, query *dto.GetFeedbacksQuery) (*dto.GetFeedbacksResponse, error)
-	GetMetrics(ctx context.Context) (*dto.GetFeedbackMetricsResponse, error)
-	GetSatisfactionTrend(ctx context.Context) (*dto.GetSatisfactionTrendResponse, error)
+	Export(ctx context.Context, query *dto.ExportFeedbacksQuery) (*dto.ExportFile, error)
+	GetMetrics(ctx context.Context, query *dto.GetFeedbackMetricsQuery) (*dto.GetFeedbackMetricsResponse, error)
+	GetBreakdown(ctx context.Context, param *dto.GetFeedbackBreakdownParam, query *dto.GetFeedbackBreakdownQuery) (*dto.GetFeedbackBreakdownResponse, error)
+	GetSatisfactionTrend(ctx context.Context, query *dto.GetSatisfactionTrendQuery) (*dto.GetSatisfactionTrendResponse, error)
 }
@@ -0,0 +1,33 @@
+package contracts
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/google/uuid"
+)
+
+//go:generate mockgen -destination=../../internal/app/importjob/repository/mock/mock_import_job_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts ImportJobRepository
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *entity.ImportJob) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportJob, error)
+	// ClaimNext marks the oldest pending job as running and returns it, or
+	// returns nil when no job is pending.
+	ClaimNext(ctx context.Context, now time.Time) (*entity.ImportJob, error)
+	UpdateProgress(ctx context.Context, id uuid.UUID, totalRows int, processedRows int, now time.Time) error
+	Complete(ctx context.Context, id uuid.UUID, result json.RawMessage, now time.Time) error
+	Fail(ctx context.Context, id uuid.UUID, errorCode string, errorMessage string, now time.Time) error
+	// RequeueStale puts running jobs that haven't made progress since
+	// staleBefore back in the queue, and fails the ones that already ran
+	// maxAttempts times.
+	RequeueStale(ctx context.Context, staleBefore time.Time, maxAttempts int, now time.Time) error
+}
+
+type ImportJobService interface {
+	GetByID(ctx context.Context, param *dto.GetImportJobByIDParam) (*dto.GetImportJobByIDResponse, error)
+	Run(ctx context.Context)
+}
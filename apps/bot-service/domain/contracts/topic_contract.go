@@ -2,21 +2,45 @@ package contracts
 
 import (
 	"context"
+	"time"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/google/uuid"
 )
 
 //go:generate mockgen -destination=../../internal/app/topic/repository/mock/mock_topic_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts TopicRepository
 
 type TopicRepository interface {
-	BulkCreate(ctx context.Context, topics []entity.Topic) error
-	GetHotTopics(ctx context.Context) ([]entity.Topic, error)
-	GetTopicsCount(ctx context.Context) (int, error)
+	BulkCreate(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error)
+	GetHotTopics(ctx context.Context, filter *entity.HotTopicsFilter) ([]entity.HotTopic, error)
+	GetTopicsCount(ctx context.Context, filter *entity.TopicsCountFilter) (int, error)
+	GetTopicSeries(ctx context.Context, filter *entity.TopicSeriesFilter) ([]entity.TopicSeriesRow, error)
+	FindCanonicalTopicByID(ctx context.Context, id uuid.UUID) (*entity.CanonicalTopic, error)
+	FindCanonicalTopicByAlias(ctx context.Context, alias string) (*entity.CanonicalTopic, error)
+	ListCanonicalTopics(ctx context.Context, filter *entity.GetCanonicalTopicsFilter) ([]entity.CanonicalTopic, int64, error)
+	ListCanonicalTopicNames(ctx context.Context, limit int) ([]string, error)
+	GetTopicAliases(ctx context.Context, canonicalTopicIDs []uuid.UUID) ([]entity.TopicAlias, error)
+	RenameCanonicalTopic(ctx context.Context, topic *entity.CanonicalTopic, alias string) error
+	MergeCanonicalTopics(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, now time.Time) error
+	FindTopicBatchByID(ctx context.Context, id uuid.UUID) (*entity.TopicBatch, error)
+	ListTopicBatches(ctx context.Context, filter *entity.GetTopicBatchesFilter) ([]entity.TopicBatch, int64, error)
+	RollbackTopicBatch(ctx context.Context, id uuid.UUID, now time.Time) error
 }
 
 type TopicService interface {
-	BulkCreate(ctx context.Context, req *dto.BulkCreateTopicsRequest) error
-	GetHotTopics(ctx context.Context) (*dto.GetHotTopicsResponse, error)
-	GetTopicsCount(ctx context.Context) (*dto.GetTopicsCountResponse, error)
+	BulkCreate(ctx context.Context, actor entity.AuditActor, req *dto.BulkCreateTopicsRequest) (*dto.BulkCreateTopicsResponse, error)
+	GetHotTopics(ctx context.Context, query *dto.GetHotTopicsQuery) (*dto.GetHotTopicsResponse, error)
+	GetTopicsCount(ctx context.Context, query *dto.GetTopicsCountQuery) (*dto.GetTopicsCountResponse, error)
+	GetTopicSeries(ctx context.Context, query *dto.GetTopicSeriesQuery) (*dto.GetTopicSeriesResponse, error)
+	ListCanonicalTopics(ctx context.Context, query *dto.GetCanonicalTopicsQuery) (*dto.GetCanonicalTopicsResponse, error)
+	RenameCanonicalTopic(ctx context.Context, actor entity.AuditActor, param *dto.CanonicalTopicParam, req *dto.RenameCanonicalTopicRequest) (*dto.CanonicalTopicResponse, error)
+	MergeCanonicalTopics(ctx context.Context, actor entity.AuditActor, param *dto.CanonicalTopicParam, req *dto.MergeCanonicalTopicsRequest) (*dto.CanonicalTopicResponse, error)
+	ListTopicBatches(ctx context.Context, query *dto.GetTopicBatchesQuery) (*dto.GetTopicBatchesResponse, error)
+	RollbackTopicBatch(ctx context.Context, actor entity.AuditActor, param *dto.TopicBatchParam) (*dto.TopicBatchResponse, error)
+}
+
+type TopicExtractionService interface {
+	Run(ctx context.Context, interval time.Duration)
+	ExtractNext(ctx context.Context) (bool, error)
 }
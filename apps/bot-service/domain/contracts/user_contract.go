@@ -9,27 +9,34 @@ import (
 )
 
 //go:generate mockgen -destination=../../internal/app/user/repository/mock/mock_user_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts UserRepository
+//go:generate mockgen -destination=../../internal/app/user/service/mock/mock_user_service.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts UserService
 
 type UserRepository interface {
 	Create(ctx context.Context, user *entity.User) error
-	BulkCreate(ctx context.Context, users []entity.User) error
+	BulkCreate(ctx context.Context, users []entity.User, importJobID uuid.UUID) error
+	Upsert(ctx context.Context, users []entity.User, opts entity.UpsertUsersOptions) (*entity.UpsertUsersResult, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
 	FindByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error)
 	List(ctx context.Context, filter *entity.GetUsersFilter) ([]entity.User, int64, error)
+	ListByCursor(ctx context.Context, filter *entity.GetUsersFilter) ([]entity.User, bool, error)
+	Each(ctx context.Context, filter *entity.GetUsersFilter, fn func(user *entity.User) error) error
 	Update(ctx context.Context, user *entity.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetAllPhoneNumbers(ctx context.Context) ([]string, error)
+	FindExistingPhoneNumbers(ctx context.Context, phoneNumbers []string) ([]string, error)
 	GetTotalUsers(ctx context.Context) (int, error)
 }
 
 type UserService interface {
-	Create(ctx context.Context, req *dto.CreateUserRequest) (*dto.CreateUserResponse, error)
+	Create(ctx context.Context, actor entity.AuditActor, req *dto.CreateUserRequest) (*dto.CreateUserResponse, error)
 	GetByID(ctx context.Context, param *dto.GetUserByIDParam) (*dto.GetUserByIDResponse, error)
 	GetByPhoneNumber(ctx context.Context, param *dto.GetUserByPhoneNumberParam) (*dto.GetUserByPhoneNumberResponse, error)
 	List(ctx context.Context, query *dto.GetUsersQuery) (*dto.GetUsersResponse, error)
-	Update(ctx context.Context, param *dto.UpdateUserParam, req *dto.UpdateUserRequest) error
-	Delete(ctx context.Context, param *dto.DeleteUserParam) error
+	Update(ctx context.Context, actor entity.AuditActor, param *dto.UpdateUserParam, req *dto.UpdateUserRequest) error
+	Delete(ctx context.Context, actor entity.AuditActor, param *dto.DeleteUserParam) error
 	GetAllPhoneNumbers(ctx context.Context) (*dto.GetAllPhoneNumbersResponse, error)
 	GetMetrics(ctx context.Context) (*dto.GetUserMetricsResponse, error)
-	ImportFromCSV(ctx context.Context, req *dto.ImportUsersFromCSVRequest) error
+	Import(ctx context.Context, actor entity.AuditActor, req *dto.ImportUsersRequest) (*dto.CreateImportJobResponse, error)
+	Export(ctx context.Context, query *dto.ExportUsersQuery) (*dto.ExportFile, error)
+	RunImportJob(ctx context.Context, job *entity.ImportJob, onProgress func(totalRows int, processedRows int)) (*dto.ImportUsersResponse, error)
 }
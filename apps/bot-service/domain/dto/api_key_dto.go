@@ -0,0 +1,78 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+)
+
+type APIKeyResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Prefix     string   `json:"prefix"`
+	Scopes     []string `json:"scopes"`
+	CreatedBy  *string  `json:"createdBy"`
+	LastUsedAt *string  `json:"lastUsedAt"`
+	ExpiresAt  *string  `json:"expiresAt"`
+	RevokedAt  *string  `json:"revokedAt"`
+	CreatedAt  string   `json:"createdAt"`
+}
+
+func ToAPIKeyResponse(apiKey *entity.APIKey) APIKeyResponse {
+	var createdBy *string
+	if apiKey.CreatedBy != nil {
+		id := apiKey.CreatedBy.String()
+		createdBy = &id
+	}
+
+	return APIKeyResponse{
+		ID:         apiKey.ID.String(),
+		Name:       apiKey.Name,
+		Prefix:     apiKey.Prefix,
+		Scopes:     strings.Fields(apiKey.Scopes),
+		CreatedBy:  createdBy,
+		LastUsedAt: formatOptionalTime(apiKey.LastUsedAt),
+		ExpiresAt:  formatOptionalTime(apiKey.ExpiresAt),
+		RevokedAt:  formatOptionalTime(apiKey.RevokedAt),
+		CreatedAt:  apiKey.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name" validate:"required,min=1,max=255"`
+	Scopes    []string `json:"scopes" validate:"required,min=1,unique,dive,oneof=topics:write feedbacks:write"`
+	ExpiresAt *string  `json:"expiresAt,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+}
+
+// CreateAPIKeyResponse is the only time the key itself is returned.
+type CreateAPIKeyResponse struct {
+	APIKey APIKeyResponse `json:"apiKey"`
+	Key    string         `json:"key"`
+}
+
+type GetAPIKeysQuery struct {
+	Page           int  `query:"page" validate:"omitempty,min=1"`
+	Limit          int  `query:"limit" validate:"omitempty,min=1,max=100"`
+	IncludeRevoked bool `query:"includeRevoked"`
+}
+
+type GetAPIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"apiKeys"`
+	Meta    struct {
+		Pagination PaginationResponse `json:"pagination"`
+	} `json:"meta"`
+}
+
+type RevokeAPIKeyParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
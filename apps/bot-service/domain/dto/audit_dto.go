@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+)
+
+type AuditEventResponse struct {
+	ID         string          `json:"id"`
+	ActorType  string          `json:"actorType"`
+	ActorID    *string         `json:"actorId"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	TargetID   *string         `json:"targetId"`
+	Changes    json.RawMessage `json:"changes"`
+	Metadata   json.RawMessage `json:"metadata"`
+	IPAddress  *string         `json:"ipAddress"`
+	UserAgent  *string         `json:"userAgent"`
+	CreatedAt  string          `json:"createdAt"`
+}
+
+func ToAuditEventResponse(event *entity.AuditEvent) AuditEventResponse {
+	return AuditEventResponse{
+		ID:         event.ID.String(),
+		ActorType:  event.ActorType,
+		ActorID:    event.ActorID,
+		Action:     event.Action,
+		TargetType: event.TargetType,
+		TargetID:   event.TargetID,
+		Changes:    event.Changes,
+		Metadata:   event.Metadata,
+		IPAddress:  event.IPAddress,
+		UserAgent:  event.UserAgent,
+		CreatedAt:  event.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// RecordAuditEventRequest describes a change. Before and After are snapshots
+// of the target, such as a UserResponse, and are nil when the target was
+// created or deleted.
+type RecordAuditEventRequest struct {
+	Actor      entity.AuditActor
+	Action     string
+	TargetType string
+	TargetID   *string
+	Before     any
+	After      any
+	Metadata   map[string]any
+}
+
+// GetAuditEventsQuery filters audit events. From and To are inclusive dates
+// in Timezone, which defaults to Asia/Jakarta.
+type GetAuditEventsQuery struct {
+	Page       int     `query:"page" validate:"omitempty,min=1"`
+	Limit      int     `query:"limit" validate:"omitempty,min=1,max=100"`
+	ActorType  *string `query:"actorType" validate:"omitempty,oneof=admin api_key system"`
+	ActorID    *string `query:"actorId" validate:"omitempty,uuid"`
+	Action     *string `query:"action" validate:"omitempty,max=50"`
+	TargetType *string `query:"targetType" validate:"omitempty,max=50"`
+	TargetID   *string `query:"targetId" validate:"omitempty,uuid"`
+	From       *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To         *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Timezone   string  `query:"tz" validate:"omitempty,timezone"`
+	Search     string  `query:"search" validate:"omitempty,max=255"`
+}
+
+type GetAuditEventsResponse struct {
+	AuditEvents []AuditEventResponse `json:"auditEvents"`
+	Meta        struct {
+		Pagination PaginationResponse `json:"pagination"`
+	} `json:"meta"`
+}
@@ -0,0 +1,111 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+)
+
+type AdminResponse struct {
+	ID          string  `json:"id"`
+	Email       string  `json:"email"`
+	Name        string  `json:"name"`
+	Role        string  `json:"role"`
+	LastLoginAt *string `json:"lastLoginAt"`
+	CreatedAt   string  `json:"createdAt"`
+	UpdatedAt   string  `json:"updatedAt"`
+}
+
+func ToAdminResponse(admin *entity.Admin) AdminResponse {
+	var lastLoginAt *string
+	if admin.LastLoginAt != nil {
+		formatted := admin.LastLoginAt.Format(time.RFC3339)
+		lastLoginAt = &formatted
+	}
+
+	return AdminResponse{
+		ID:          admin.ID.String(),
+		Email:       admin.Email,
+		Name:        admin.Name,
+		Role:        admin.Role,
+		LastLoginAt: lastLoginAt,
+		CreatedAt:   admin.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   admin.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type AuthTokensResponse struct {
+	AccessToken           string `json:"accessToken"`
+	RefreshToken          string `json:"refreshToken"`
+	RefreshTokenExpiresAt string `json:"refreshTokenExpiresAt"`
+	TokenType             string `json:"tokenType"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required,max=72"`
+}
+
+type LoginResponse struct {
+	AuthTokensResponse
+	Admin AdminResponse `json:"admin"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+type RefreshTokenResponse struct {
+	AuthTokensResponse
+}
+
+type GetMeResponse struct {
+	Admin AdminResponse `json:"admin"`
+}
+
+type CreateAdminRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Name     string `json:"name" validate:"required,min=1,max=255"`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+	Role     string `json:"role" validate:"required,oneof=superadmin hc_admin viewer"`
+}
+
+type CreateAdminResponse struct {
+	ID string `json:"id"`
+}
+
+type GetAdminsQuery struct {
+	Page   int     `query:"page" validate:"omitempty,min=1"`
+	Limit  int     `query:"limit" validate:"omitempty,min=1,max=100"`
+	Role   *string `query:"role" validate:"omitempty,oneof=superadmin hc_admin viewer"`
+	Search string  `query:"search" validate:"omitempty,max=255"`
+}
+
+type GetAdminsResponse struct {
+	Admins []AdminResponse `json:"admins"`
+	Meta   struct {
+		Pagination PaginationResponse `json:"pagination"`
+	} `json:"meta"`
+}
+
+type GetAdminByIDParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type GetAdminByIDResponse struct {
+	Admin AdminResponse `json:"admin"`
+}
+
+type UpdateAdminParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type UpdateAdminRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	Role     *string `json:"role,omitempty" validate:"omitempty,oneof=superadmin hc_admin viewer"`
+	Password *string `json:"password,omitempty" validate:"omitempty,min=8,max=72"`
+}
+
+type DeleteAdminParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
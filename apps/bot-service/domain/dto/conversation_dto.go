@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+)
+
+type ConversationResponse struct {
+	ID                 string       `json:"id"`
+	User               UserResponse `json:"user"`
+	DifyConversationID *string      `json:"difyConversationId,omitempty"`
+	StartedAt          string       `json:"startedAt"`
+	EndedAt            *string      `json:"endedAt,omitempty"`
+	MessageCount       int          `json:"messageCount"`
+	CreatedAt          string       `json:"createdAt"`
+}
+
+func ToConversationResponse(conversation *entity.Conversation) ConversationResponse {
+	if conversation == nil {
+		return ConversationResponse{}
+	}
+
+	var endedAt *string
+	if conversation.EndedAt != nil {
+		formatted := conversation.EndedAt.Format(time.RFC3339)
+		endedAt = &formatted
+	}
+
+	return ConversationResponse{
+		ID:                 conversation.ID.String(),
+		User:               ToUserResponse(&conversation.User),
+		DifyConversationID: conversation.DifyConversationID,
+		StartedAt:          conversation.StartedAt.Format(time.RFC3339),
+		EndedAt:            endedAt,
+		MessageCount:       conversation.MessageCount,
+		CreatedAt:          conversation.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type MessageResponse struct {
+	ID        string  `json:"id"`
+	Role      string  `json:"role"`
+	Kind      string  `json:"kind"`
+	Content   string  `json:"content"`
+	Provider  *string `json:"provider"`
+	CreatedAt string  `json:"createdAt"`
+}
+
+func ToMessageResponse(message *entity.Message) MessageResponse {
+	return MessageResponse{
+		ID:        message.ID.String(),
+		Role:      message.Role,
+		Kind:      message.Kind,
+		Content:   message.Content,
+		Provider:  message.Provider,
+		CreatedAt: message.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+type StartConversationRequest struct {
+	UserID string `json:"userId" validate:"required,uuid"`
+}
+
+type StartConversationResponse struct {
+	ID string `json:"id"`
+}
+
+type SetDifyConversationIDRequest struct {
+	ID                 string `json:"id" validate:"required,uuid"`
+	DifyConversationID string `json:"difyConversationId" validate:"required,max=255"`
+}
+
+type EndConversationRequest struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+type RecordMessageRequest struct {
+	ConversationID    string  `json:"conversationId" validate:"required,uuid"`
+	Role              string  `json:"role" validate:"required,oneof=user assistant system"`
+	Kind              string  `json:"kind" validate:"required,max=50"`
+	Content           string  `json:"content" validate:"required"`
+	WhatsAppMessageID *string `json:"whatsappMessageId,omitempty" validate:"omitempty,max=255"`
+	Provider          *string `json:"provider,omitempty" validate:"omitempty,max=50"`
+}
+
+// GetConversationsQuery filters conversations by when they started. From and
+// To are inclusive dates in Timezone, which defaults to Asia/Jakarta.
+type GetConversationsQuery struct {
+	Page     int     `query:"page" validate:"omitempty,min=1"`
+	Limit    int     `query:"limit" validate:"omitempty,min=1,max=100"`
+	UserID   *string `query:"userId" validate:"omitempty,uuid"`
+	From     *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To       *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Timezone string  `query:"tz" validate:"omitempty,timezone"`
+	Search   string  `query:"search" validate:"omitempty,max=255"`
+}
+
+type GetConversationsResponse struct {
+	Conversations []ConversationResponse `json:"conversations"`
+	Meta          struct {
+		Pagination PaginationResponse `json:"pagination"`
+	} `json:"meta"`
+}
+
+type GetConversationByIDParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type GetConversationByIDResponse struct {
+	Conversation ConversationResponse `json:"conversation"`
+	Messages     []MessageResponse    `json:"messages"`
+}
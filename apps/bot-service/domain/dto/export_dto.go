@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"context"
+	"io"
+)
+
+// ExportFile is a file download that's checked up front and streamed to the
+// client afterwards, once the response headers have gone out.
+type ExportFile struct {
+	FileName    string
+	ContentType string
+	// Write writes the whole file to w
+	Write func(ctx context.Context, w io.Writer) error
+}
@@ -7,37 +7,56 @@ import (
 )
 
 type FeedbackResponse struct {
-	ID        string       `json:"id"`
-	User      UserResponse `json:"user"`
-	Rating    int          `json:"rating"`
-	Comment   *string      `json:"comment,omitempty"`
-	CreatedAt string       `json:"createdAt"`
+	ID                 string       `json:"id"`
+	User               UserResponse `json:"user"`
+	ConversationID     *string      `json:"conversationId,omitempty"`
+	DifyConversationID *string      `json:"difyConversationId,omitempty"`
+	Rating             int          `json:"rating"`
+	Comment            *string      `json:"comment,omitempty"`
+	Source             string       `json:"source"`
+	CreatedAt          string       `json:"createdAt"`
 }
 
 func ToFeedbackResponse(feedback *entity.Feedback) FeedbackResponse {
 	if feedback == nil {
 		return FeedbackResponse{}
 	}
-	
+
+	var conversationID *string
+	if feedback.ConversationID != nil {
+		formatted := feedback.ConversationID.String()
+		conversationID = &formatted
+	}
+
 	return FeedbackResponse{
-		ID:        feedback.ID.String(),
-		User:      ToUserResponse(&feedback.User),
-		Rating:    feedback.Rating,
-		Comment:   feedback.Comment,
-		CreatedAt: feedback.CreatedAt.Format(time.RFC3339),
+		ID:                 feedback.ID.String(),
+		User:               ToUserResponse(&feedback.User),
+		ConversationID:     conversationID,
+		DifyConversationID: feedback.DifyConversationID,
+		Rating:             feedback.Rating,
+		Comment:            feedback.Comment,
+		Source:             feedback.Source,
+		CreatedAt:          feedback.CreatedAt.Format(time.RFC3339),
 	}
 }
 
 type CreateFeedbackRequest struct {
-	UserID  string  `json:"userId" validate:"required,uuid"`
-	Rating  int     `json:"rating" validate:"required,min=1,max=5"`
-	Comment *string `json:"comment,omitempty" validate:"omitempty,max=1000"`
+	UserID             string  `json:"userId" validate:"required,uuid"`
+	ConversationID     *string `json:"conversationId,omitempty" validate:"omitempty,uuid"`
+	DifyConversationID *string `json:"difyConversationId,omitempty" validate:"omitempty,max=255"`
+	Rating             int     `json:"rating" validate:"required,min=1,max=5"`
+	Comment            *string `json:"comment,omitempty" validate:"omitempty,max=1000"`
+	// Defaults to user
+	Source string `json:"source,omitempty" validate:"omitempty,oneof=user auto"`
 }
 
 type CreateFeedbackResponse struct {
 	ID string `json:"id"`
 }
 
+// GetFeedbacksQuery pages by Page, or by Cursor when it's set. Cursor pages
+// skip the total count and can go past the 10000th feedback, but need the
+// feedbacks sorted by date.
 type GetFeedbacksQuery struct {
 	Page      int     `query:"page" validate:"omitempty,min=1"`
 	Limit     int     `query:"limit" validate:"omitempty,min=1,max=100"`
@@ -45,12 +64,40 @@ type GetFeedbacksQuery struct {
 	Ratings   []int   `query:"ratings" validate:"omitempty,dive,min=1,max=5"`
 	MinRating *int    `query:"minRating" validate:"omitempty,min=1,max=5"`
 	MaxRating *int    `query:"maxRating" validate:"omitempty,min=1,max=5"`
+	// CreatedFrom and CreatedTo are inclusive dates in Timezone, which defaults
+	// to Asia/Jakarta
+	CreatedFrom *string `query:"createdFrom" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo   *string `query:"createdTo" validate:"omitempty,datetime=2006-01-02"`
+	Timezone    string  `query:"tz" validate:"omitempty,timezone"`
+	Search      string  `query:"search" validate:"omitempty,max=255"`
+	HasComment  *bool   `query:"hasComment"`
+	SortBy      string  `query:"sortBy" validate:"omitempty,oneof=createdAt rating"`
+	SortOrder   string  `query:"sortOrder" validate:"omitempty,oneof=asc desc"`
+	Cursor      string  `query:"cursor" validate:"omitempty,max=255"`
+}
+
+// ExportFeedbacksQuery filters a feedback export. From and To are inclusive
+// dates in Timezone, which defaults to Asia/Jakarta, and Format defaults to
+// csv.
+type ExportFeedbacksQuery struct {
+	Format    string  `query:"format" validate:"omitempty,oneof=csv xlsx"`
+	UserID    *string `query:"userId" validate:"omitempty,uuid"`
+	JobTitle  *string `query:"jobTitle" validate:"omitempty,max=255"`
+	Ratings   []int   `query:"ratings" validate:"omitempty,dive,min=1,max=5"`
+	MinRating *int    `query:"minRating" validate:"omitempty,min=1,max=5"`
+	MaxRating *int    `query:"maxRating" validate:"omitempty,min=1,max=5"`
+	From      *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To        *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Timezone  string  `query:"tz" validate:"omitempty,timezone"`
 }
 
 type GetFeedbacksResponse struct {
 	Feedbacks []FeedbackResponse `json:"feedbacks"`
 	Meta      struct {
-		Pagination PaginationResponse `json:"pagination"`
+		// Left out for cursor pages
+		Pagination *PaginationResponse `json:"pagination,omitempty"`
+		// Null on the last page, and when sorting by rating
+		NextCursor *string `json:"nextCursor"`
 	} `json:"meta"`
 }
 
@@ -62,16 +109,157 @@ type GetFeedbackByIDResponse struct {
 	Feedback FeedbackResponse `json:"feedback"`
 }
 
-type GetFeedbackMetricsResponse struct {
+type GetFeedbackConversationParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+// GetFeedbackMetricsQuery covers all feedback when From and To are both
+// empty. Otherwise it covers the inclusive dates in Timezone, defaulting to the
+// 30 days up to today in Asia/Jakarta, and compares them with the period of
+// the same length just before.
+type GetFeedbackMetricsQuery struct {
+	From     *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To       *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Timezone string  `query:"tz" validate:"omitempty,timezone"`
+}
+
+type RatingCount struct {
+	Rating int `json:"rating"`
+	Count  int `json:"count"`
+}
+
+// FeedbackPeriodMetrics rates are percentages of TotalFeedbacks, and 0 when
+// there is no feedback. Promoters rated 5, passives 4 and detractors 1 to 3;
+// NetScore is the share of promoters minus the share of detractors.
+type FeedbackPeriodMetrics struct {
+	// Inclusive dates, omitted when covering all feedback
+	From               *string       `json:"from,omitempty"`
+	To                 *string       `json:"to,omitempty"`
+	SatisfactionScore  float64       `json:"satisfactionScore"`
+	TotalFeedbacks     int           `json:"totalFeedbacks"`
+	RatingDistribution []RatingCount `json:"ratingDistribution"`
+	Promoters          int           `json:"promoters"`
+	Passives           int           `json:"passives"`
+	Detractors         int           `json:"detractors"`
+	NetScore           float64       `json:"netScore"`
+	AutoSubmitted      int           `json:"autoSubmitted"`
+	UserGiven          int           `json:"userGiven"`
+	AutoSubmittedRate  float64       `json:"autoSubmittedRate"`
+	CommentRate        float64       `json:"commentRate"`
+}
+
+func ToFeedbackPeriodMetrics(metrics *entity.FeedbackMetrics) FeedbackPeriodMetrics {
+	res := FeedbackPeriodMetrics{
+		TotalFeedbacks: metrics.TotalFeedbacks,
+		RatingDistribution: []RatingCount{
+			{Rating: 1, Count: metrics.Rating1},
+			{Rating: 2, Count: metrics.Rating2},
+			{Rating: 3, Count: metrics.Rating3},
+			{Rating: 4, Count: metrics.Rating4},
+			{Rating: 5, Count: metrics.Rating5},
+		},
+		Promoters:     metrics.Rating5,
+		Passives:      metrics.Rating4,
+		Detractors:    metrics.Rating1 + metrics.Rating2 + metrics.Rating3,
+		AutoSubmitted: metrics.AutoFeedbacks,
+		UserGiven:     metrics.TotalFeedbacks - metrics.AutoFeedbacks,
+	}
+
+	if metrics.TotalFeedbacks > 0 {
+		total := float64(metrics.TotalFeedbacks)
+		res.SatisfactionScore = float64(metrics.RatingSum) * 100 / (total * 5)
+		res.NetScore = float64(res.Promoters-res.Detractors) * 100 / total
+		res.AutoSubmittedRate = float64(metrics.AutoFeedbacks) * 100 / total
+		res.CommentRate = float64(metrics.CommentedFeedbacks) * 100 / total
+	}
+
+	return res
+}
+
+// FeedbackMetricsDelta is the current period minus the previous one
+type FeedbackMetricsDelta struct {
 	SatisfactionScore float64 `json:"satisfactionScore"`
 	TotalFeedbacks    int     `json:"totalFeedbacks"`
+	NetScore          float64 `json:"netScore"`
+	AutoSubmittedRate float64 `json:"autoSubmittedRate"`
+	CommentRate       float64 `json:"commentRate"`
+}
+
+// GetFeedbackMetricsResponse keeps the current period's metrics at the top
+// level. Timezone, Previous and Delta are only set for a date range.
+type GetFeedbackMetricsResponse struct {
+	FeedbackPeriodMetrics
+	Timezone string                 `json:"timezone,omitempty"`
+	Previous *FeedbackPeriodMetrics `json:"previous"`
+	Delta    *FeedbackMetricsDelta  `json:"delta"`
+}
+
+type GetFeedbackBreakdownParam struct {
+	Dimension string `param:"dimension" validate:"required,oneof=job-title gender age-band"`
+}
+
+// GetFeedbackBreakdownQuery covers all feedback when From and To are both
+// empty, like GetFeedbackMetricsQuery
+type GetFeedbackBreakdownQuery struct {
+	From     *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To       *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Timezone string  `query:"tz" validate:"omitempty,timezone"`
+}
+
+type FeedbackBreakdownGroup struct {
+	// Nil for users without a job title, gender or date of birth
+	Value         *string `json:"value"`
+	AvgRating     float64 `json:"avgRating"`
+	FeedbackCount int     `json:"feedbackCount"`
+	// Percentage of the group's feedback with a comment
+	CommentRate float64 `json:"commentRate"`
+}
+
+func ToFeedbackBreakdownGroup(row *entity.FeedbackBreakdownRow) FeedbackBreakdownGroup {
+	res := FeedbackBreakdownGroup{
+		Value:         row.Value,
+		AvgRating:     row.AvgRating,
+		FeedbackCount: row.FeedbackCount,
+	}
+
+	if row.FeedbackCount > 0 {
+		res.CommentRate = float64(row.CommentedCount) * 100 / float64(row.FeedbackCount)
+	}
+
+	return res
+}
+
+// GetFeedbackBreakdownResponse leaves out groups with feedback from fewer
+// than MinGroupSize users and only says how many there were
+type GetFeedbackBreakdownResponse struct {
+	Dimension        string                   `json:"dimension"`
+	From             *string                  `json:"from,omitempty"`
+	To               *string                  `json:"to,omitempty"`
+	Timezone         string                   `json:"timezone,omitempty"`
+	MinGroupSize     int                      `json:"minGroupSize"`
+	Groups           []FeedbackBreakdownGroup `json:"groups"`
+	SuppressedGroups int                      `json:"suppressedGroups"`
+}
+
+// GetSatisfactionTrendQuery defaults to the buckets up to today in Asia/Jakarta:
+// the last 30 days, 12 weeks or 12 months. From and To are inclusive dates.
+type GetSatisfactionTrendQuery struct {
+	From        *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To          *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Granularity string  `query:"granularity" validate:"omitempty,oneof=day week month"`
+	Timezone    string  `query:"tz" validate:"omitempty,timezone"`
 }
 
 type SatisfactionTrendData struct {
+	// Start of the bucket. Weeks start on Monday.
 	Date            string  `json:"date"`
 	AvgSatisfaction float64 `json:"avgSatisfaction"`
+	// Zero when there was no feedback, which also leaves AvgSatisfaction at 0
+	Count int `json:"count"`
 }
 
 type GetSatisfactionTrendResponse struct {
-	Trend []SatisfactionTrendData `json:"trend"`
+	Granularity string                  `json:"granularity"`
+	Timezone    string                  `json:"timezone"`
+	Trend       []SatisfactionTrendData `json:"trend"`
 }
@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+)
+
+type ImportJobErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ImportJobResponse struct {
+	ID            string                  `json:"id"`
+	Type          string                  `json:"type"`
+	Status        string                  `json:"status"`
+	FileName      string                  `json:"fileName"`
+	TotalRows     int                     `json:"totalRows"`
+	ProcessedRows int                     `json:"processedRows"`
+	Result        json.RawMessage         `json:"result"` // An ImportUsersResponse, once completed
+	Error         *ImportJobErrorResponse `json:"error"`  // Why the job failed
+	CreatedAt     string                  `json:"createdAt"`
+	StartedAt     *string                 `json:"startedAt"`
+	FinishedAt    *string                 `json:"finishedAt"`
+}
+
+func ToImportJobResponse(job *entity.ImportJob) ImportJobResponse {
+	var jobErr *ImportJobErrorResponse
+	if job.ErrorCode != nil {
+		jobErr = &ImportJobErrorResponse{
+			Code: *job.ErrorCode,
+		}
+		if job.ErrorMessage != nil {
+			jobErr.Message = *job.ErrorMessage
+		}
+	}
+
+	return ImportJobResponse{
+		ID:            job.ID.String(),
+		Type:          job.Type,
+		Status:        job.Status,
+		FileName:      job.FileName,
+		TotalRows:     job.TotalRows,
+		ProcessedRows: job.ProcessedRows,
+		Result:        job.Result,
+		Error:         jobErr,
+		CreatedAt:     job.CreatedAt.Format(time.RFC3339),
+		StartedAt:     formatOptionalTime(job.StartedAt),
+		FinishedAt:    formatOptionalTime(job.FinishedAt),
+	}
+}
+
+type CreateImportJobResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type GetImportJobByIDParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type GetImportJobByIDResponse struct {
+	ImportJob ImportJobResponse `json:"importJob"`
+}
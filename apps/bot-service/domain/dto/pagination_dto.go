@@ -1,5 +1,15 @@
 package dto
 
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/google/uuid"
+)
+
 type PaginationResponse struct {
 	TotalData int64 `json:"total_data"`
 	TotalPage int   `json:"total_page"`
@@ -29,3 +39,35 @@ func NewPaginationResponse(totalData int64, page, limit int) PaginationResponse
 		Limit:     limit,
 	}
 }
+
+// EncodeCursor returns an opaque cursor that pages on from the row with
+// createdAt and id
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	raw := createdAt.UTC().Format(time.RFC3339Nano) + "|" + id.String()
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func DecodeCursor(cursor string) (*entity.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAtValue, idValue, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errors.New("cursor has no separator")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtValue)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(idValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Cursor{CreatedAt: createdAt, ID: id}, nil
+}
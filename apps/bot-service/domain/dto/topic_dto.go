@@ -11,30 +11,208 @@ type TopicRequest struct {
 	Count int    `json:"count" validate:"required,min=1"`
 }
 
+// BulkCreateTopicsRequest is recorded once per API key and BatchID. Sending
+// the same batch again returns the stored one instead of counting twice.
+// Without a BatchID every call is a new batch, as before batches existed, so
+// callers can send one once they retry.
 type BulkCreateTopicsRequest struct {
-	Topics []TopicRequest `json:"topics" validate:"required,min=1,max=100,dive"`
+	BatchID string         `json:"batchId" validate:"omitempty,max=100"`
+	Topics  []TopicRequest `json:"topics" validate:"required,min=1,max=100,dive"`
+}
+
+type BulkCreateTopicsResponse struct {
+	Batch TopicBatchResponse `json:"batch"`
+	// Replayed is true when the batch had already been recorded
+	Replayed bool `json:"replayed"`
+}
+
+type TopicBatchParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type GetTopicBatchesQuery struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Source string `query:"source" validate:"omitempty,max=50"`
+}
+
+type TopicBatchResponse struct {
+	ID      string `json:"id"`
+	BatchID string `json:"batchId"`
+	Source  string `json:"source"`
+	// Canonical topics the batch counted, and the sum of its counts
+	TopicCount   int     `json:"topicCount"`
+	MentionCount int     `json:"mentionCount"`
+	ActorType    string  `json:"actorType"`
+	ActorID      *string `json:"actorId"`
+	CreatedAt    string  `json:"createdAt"`
+	RolledBackAt *string `json:"rolledBackAt"`
+}
+
+type GetTopicBatchesResponse struct {
+	Batches []TopicBatchResponse `json:"batches"`
+	Meta    struct {
+		Pagination PaginationResponse `json:"pagination"`
+	} `json:"meta"`
+}
+
+func ToTopicBatchResponse(batch *entity.TopicBatch) TopicBatchResponse {
+	return TopicBatchResponse{
+		ID:           batch.ID.String(),
+		BatchID:      batch.BatchID,
+		Source:       batch.Source,
+		TopicCount:   batch.TopicCount,
+		MentionCount: batch.MentionCount,
+		ActorType:    batch.ActorType,
+		ActorID:      batch.ActorID,
+		CreatedAt:    batch.CreatedAt.Format(time.RFC3339),
+		RolledBackAt: formatOptionalTime(batch.RolledBackAt),
+	}
 }
 
 type TopicResponse struct {
-	ID        int    `json:"id"`
+	ID int `json:"id"`
+	// TopicID is the canonical topic the title was attributed to
+	TopicID   string `json:"topicId"`
 	Title     string `json:"title"`
 	Count     int    `json:"count"`
 	CreatedAt string `json:"createdAt"`
 }
 
+// GetHotTopicsQuery covers the Window days up to To, or From to To when From
+// is set. To defaults to today in Asia/Jakarta, Window to 30 and Limit to 5.
+type GetHotTopicsQuery struct {
+	Window   int     `query:"window" validate:"omitempty,oneof=7 30 90,excluded_with=From"`
+	From     *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To       *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Timezone string  `query:"tz" validate:"omitempty,timezone"`
+	Limit    int     `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+type HotTopicResponse struct {
+	TopicResponse
+	// Mentions in the window of the same length just before
+	PreviousCount int    `json:"previousCount"`
+	Trend         string `json:"trend"`
+}
+
 type GetHotTopicsResponse struct {
-	Topics []TopicResponse `json:"topics"`
+	// Inclusive dates of the window and the previous one
+	From         string             `json:"from"`
+	To           string             `json:"to"`
+	PreviousFrom string             `json:"previousFrom"`
+	PreviousTo   string             `json:"previousTo"`
+	Timezone     string             `json:"timezone"`
+	Topics       []HotTopicResponse `json:"topics"`
+}
+
+// GetTopicsCountQuery counts over all time when none of Window, From and To
+// are set, and otherwise like GetHotTopicsQuery
+type GetTopicsCountQuery struct {
+	Window   int     `query:"window" validate:"omitempty,oneof=7 30 90,excluded_with=From"`
+	From     *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To       *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Timezone string  `query:"tz" validate:"omitempty,timezone"`
 }
 
 type GetTopicsCountResponse struct {
 	TotalTopics int `json:"totalTopics"`
 }
 
+// GetTopicSeriesQuery looks the topic up by any of its aliases, and defaults
+// to the buckets up to today in Asia/Jakarta like GetSatisfactionTrendQuery
+type GetTopicSeriesQuery struct {
+	Title       string  `query:"title" validate:"required,max=255"`
+	From        *string `query:"from" validate:"omitempty,datetime=2006-01-02"`
+	To          *string `query:"to" validate:"omitempty,datetime=2006-01-02"`
+	Granularity string  `query:"granularity" validate:"omitempty,oneof=day week month"`
+	Timezone    string  `query:"tz" validate:"omitempty,timezone"`
+}
+
+type TopicSeriesData struct {
+	// Start of the bucket. Weeks start on Monday.
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+type GetTopicSeriesResponse struct {
+	TopicID string `json:"topicId"`
+	// The canonical topic's name
+	Title       string            `json:"title"`
+	Granularity string            `json:"granularity"`
+	Timezone    string            `json:"timezone"`
+	Series      []TopicSeriesData `json:"series"`
+}
+
+type CanonicalTopicParam struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+type GetCanonicalTopicsQuery struct {
+	Page   int    `query:"page" validate:"omitempty,min=1"`
+	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Search string `query:"search" validate:"omitempty,max=255"`
+}
+
+type RenameCanonicalTopicRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=255"`
+}
+
+// MergeCanonicalTopicsRequest lists the topics to fold into the one in the path
+type MergeCanonicalTopicsRequest struct {
+	SourceIDs []string `json:"sourceIds" validate:"required,min=1,max=50,unique,dive,uuid"`
+}
+
+type CanonicalTopicResponse struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+type GetCanonicalTopicsResponse struct {
+	Topics []CanonicalTopicResponse `json:"topics"`
+	Meta   struct {
+		Pagination PaginationResponse `json:"pagination"`
+	} `json:"meta"`
+}
+
+func ToCanonicalTopicResponse(topic *entity.CanonicalTopic, aliases []string) CanonicalTopicResponse {
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	return CanonicalTopicResponse{
+		ID:        topic.ID.String(),
+		Name:      topic.Name,
+		Aliases:   aliases,
+		CreatedAt: topic.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: topic.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
 func ToTopicResponse(topic *entity.Topic) TopicResponse {
 	return TopicResponse{
 		ID:        topic.ID,
+		TopicID:   topic.CanonicalTopicID.String(),
 		Title:     topic.Title,
 		Count:     topic.Count,
 		CreatedAt: topic.CreatedAt.Format(time.RFC3339),
 	}
 }
+
+func ToHotTopicResponse(topic *entity.HotTopic) HotTopicResponse {
+	trend := entity.TopicTrendSteady
+	if topic.Count > topic.PreviousCount {
+		trend = entity.TopicTrendRising
+	} else if topic.Count < topic.PreviousCount {
+		trend = entity.TopicTrendFalling
+	}
+
+	return HotTopicResponse{
+		TopicResponse: ToTopicResponse(&topic.Topic),
+		PreviousCount: topic.PreviousCount,
+		Trend:         trend,
+	}
+}
@@ -8,14 +8,15 @@ import (
 )
 
 type UserResponse struct {
-	ID          string  `json:"id"`
-	PhoneNumber string  `json:"phoneNumber"`
-	Name        string  `json:"name"`
-	JobTitle    *string `json:"jobTitle,omitempty"`
-	Gender      *string `json:"gender,omitempty"`
-	DateOfBirth *string `json:"dateOfBirth,omitempty"`
-	CreatedAt   string  `json:"createdAt"`
-	UpdatedAt   string  `json:"updatedAt"`
+	ID            string  `json:"id"`
+	PhoneNumber   string  `json:"phoneNumber"`
+	Name          string  `json:"name"`
+	JobTitle      *string `json:"jobTitle,omitempty"`
+	Gender        *string `json:"gender,omitempty"`
+	DateOfBirth   *string `json:"dateOfBirth,omitempty"`
+	DeactivatedAt *string `json:"deactivatedAt,omitempty"`
+	CreatedAt     string  `json:"createdAt"`
+	UpdatedAt     string  `json:"updatedAt"`
 }
 
 func ToUserResponse(user *entity.User) UserResponse {
@@ -25,15 +26,22 @@ func ToUserResponse(user *entity.User) UserResponse {
 		dateOfBirth = &formatted
 	}
 
+	var deactivatedAt *string
+	if user.DeactivatedAt != nil {
+		formatted := user.DeactivatedAt.Format(time.RFC3339)
+		deactivatedAt = &formatted
+	}
+
 	return UserResponse{
-		ID:          user.ID.String(),
-		PhoneNumber: user.PhoneNumber,
-		Name:        user.Name,
-		JobTitle:    user.JobTitle,
-		Gender:      user.Gender,
-		DateOfBirth: dateOfBirth,
-		CreatedAt:   user.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   user.UpdatedAt.Format(time.RFC3339),
+		ID:            user.ID.String(),
+		PhoneNumber:   user.PhoneNumber,
+		Name:          user.Name,
+		JobTitle:      user.JobTitle,
+		Gender:        user.Gender,
+		DateOfBirth:   dateOfBirth,
+		DeactivatedAt: deactivatedAt,
+		CreatedAt:     user.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     user.UpdatedAt.Format(time.RFC3339),
 	}
 }
 
@@ -65,16 +73,29 @@ type DeleteUserParam struct {
 	ID string `param:"id" validate:"required,uuid"`
 }
 
+// GetUsersQuery pages by Page, or by Cursor when it's set. Cursor pages skip
+// the total count and can go past the 10000th user.
 type GetUsersQuery struct {
 	Page   int    `query:"page" validate:"omitempty,min=1"`
 	Limit  int    `query:"limit" validate:"omitempty,min=1,max=100"`
 	Search string `query:"search" validate:"omitempty,max=255"`
+	Cursor string `query:"cursor" validate:"omitempty,max=255"`
+}
+
+// ExportUsersQuery takes the same filters as GetUsersQuery. Format defaults
+// to csv.
+type ExportUsersQuery struct {
+	Format string `query:"format" validate:"omitempty,oneof=csv xlsx"`
+	Search string `query:"search" validate:"omitempty,max=255"`
 }
 
 type GetUsersResponse struct {
 	Users []UserResponse `json:"users"`
 	Meta  struct {
-		Pagination PaginationResponse `json:"pagination"`
+		// Left out for cursor pages
+		Pagination *PaginationResponse `json:"pagination,omitempty"`
+		// Null on the last page
+		NextCursor *string `json:"nextCursor"`
 	} `json:"meta"`
 }
 
@@ -102,6 +123,44 @@ type GetUserMetricsResponse struct {
 	TotalUsers int `json:"totalUsers"`
 }
 
-type ImportUsersFromCSVRequest struct {
-	File *multipart.FileHeader // manually set and validate
+type ImportUsersRequest struct {
+	File              *multipart.FileHeader // manually set and validate
+	Mode              string                `form:"mode" validate:"omitempty,oneof=create upsert"`            // Defaults to create
+	DryRun            bool                  `form:"dryRun"`                                                   // Only validate, don't import anything
+	AllowPartial      bool                  `form:"allowPartial"`                                             // Import the valid rows even if some rows have errors
+	DeactivateMissing bool                  `form:"deactivateMissing" validate:"excluded_unless=Mode upsert"` // Deactivate users missing from the file
+}
+
+// ImportUsersOptions are the options of an ImportUsersRequest, as kept with
+// the import job
+type ImportUsersOptions struct {
+	Mode              string `json:"mode"`
+	DryRun            bool   `json:"dryRun"`
+	AllowPartial      bool   `json:"allowPartial"`
+	DeactivateMissing bool   `json:"deactivateMissing"`
+}
+
+// ImportRowError is a problem with one cell of an imported file. Row is the
+// line number in the file, counting the header as row 1.
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column"`
+	Value   string `json:"value"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ImportUsersResponse struct {
+	Mode        string           `json:"mode"`
+	DryRun      bool             `json:"dryRun"`
+	Committed   bool             `json:"committed"`
+	TotalRows   int              `json:"totalRows"`
+	ValidRows   int              `json:"validRows"`
+	InvalidRows int              `json:"invalidRows"`
+	Imported    int              `json:"imported"`
+	Created     int              `json:"created"`
+	Updated     int              `json:"updated"`
+	Unchanged   int              `json:"unchanged"`
+	Deactivated int              `json:"deactivated"`
+	Errors      []ImportRowError `json:"errors"`
 }
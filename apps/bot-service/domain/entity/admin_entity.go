@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Admin roles, from most to least privileged
+const (
+	AdminRoleSuperadmin = "superadmin" // Everything, including managing admins
+	AdminRoleHCAdmin    = "hc_admin"   // Manages users, feedback, topics and transcripts
+	AdminRoleViewer     = "viewer"     // Reads analytics only
+)
+
+type Admin struct {
+	ID           uuid.UUID  `db:"id"`
+	Email        string     `db:"email"`
+	Name         string     `db:"name"`
+	PasswordHash string     `db:"password_hash"`
+	Role         string     `db:"role"`
+	LastLoginAt  *time.Time `db:"last_login_at"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+}
+
+type GetAdminsFilter struct {
+	Offset int
+	Limit  int
+	Role   *string
+	Search string
+}
+
+// RefreshToken is a long-lived token that can be exchanged for a new access
+// token. Only the SHA-256 hash of the token is stored.
+type RefreshToken struct {
+	ID        uuid.UUID  `db:"id"`
+	AdminID   uuid.UUID  `db:"admin_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	CreatedAt time.Time  `db:"created_at"`
+}
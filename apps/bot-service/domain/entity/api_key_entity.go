@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key scopes
+const (
+	APIKeyScopeTopicsWrite    = "topics:write"
+	APIKeyScopeFeedbacksWrite = "feedbacks:write"
+)
+
+// APIKey authenticates a machine caller, such as the Dify workflow. Only the
+// SHA-256 hash of the key is stored.
+type APIKey struct {
+	ID         uuid.UUID  `db:"id"`
+	Name       string     `db:"name"`
+	Prefix     string     `db:"prefix"`
+	KeyHash    string     `db:"key_hash"`
+	Scopes     string     `db:"scopes"` // Space-separated
+	CreatedBy  *uuid.UUID `db:"created_by"`
+	LastUsedAt *time.Time `db:"last_used_at"`
+	ExpiresAt  *time.Time `db:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+}
+
+type GetAPIKeysFilter struct {
+	Offset         int
+	Limit          int
+	IncludeRevoked bool
+}
@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kinds of actor that can make a change
+const (
+	AuditActorAdmin  = "admin"
+	AuditActorAPIKey = "api_key"
+	AuditActorSystem = "system"
+)
+
+// Audited actions, as <target type>.<verb>
+const (
+	AuditActionUserCreate  = "user.create"
+	AuditActionUserUpdate  = "user.update"
+	AuditActionUserDelete  = "user.delete"
+	AuditActionUserImport  = "user.import"
+	AuditActionTopicRename = "topic.rename"
+	AuditActionTopicMerge  = "topic.merge"
+
+	AuditActionAdminCreate  = "admin.create"
+	AuditActionAdminUpdate  = "admin.update"
+	AuditActionAdminDelete  = "admin.delete"
+	AuditActionAPIKeyCreate = "api_key.create"
+	AuditActionAPIKeyRevoke = "api_key.revoke"
+
+	AuditActionTopicBatchRollback = "topic_batch.rollback"
+)
+
+// Kinds of record a change can target
+const (
+	AuditTargetUser       = "user"
+	AuditTargetTopic      = "topic"
+	AuditTargetTopicBatch = "topic_batch"
+	AuditTargetAdmin      = "admin"
+	AuditTargetAPIKey     = "api_key"
+)
+
+// AuditActor is who made a change and where the request came from.
+type AuditActor struct {
+	Type      string
+	ID        *string
+	IPAddress string
+	UserAgent string
+}
+
+// AuditEvent records a change made through the API. Changes maps each changed
+// field to its value before and after, e.g. {"name": {"before": "A", "after": "B"}}.
+type AuditEvent struct {
+	ID         uuid.UUID       `db:"id"`
+	ActorType  string          `db:"actor_type"`
+	ActorID    *string         `db:"actor_id"`
+	Action     string          `db:"action"`
+	TargetType string          `db:"target_type"`
+	TargetID   *string         `db:"target_id"`
+	Changes    json.RawMessage `db:"changes"`
+	Metadata   json.RawMessage `db:"metadata"`
+	IPAddress  *string         `db:"ip_address"`
+	UserAgent  *string         `db:"user_agent"`
+	CreatedAt  time.Time       `db:"created_at"`
+}
+
+type GetAuditEventsFilter struct {
+	Offset      int
+	Limit       int
+	ActorType   *string
+	ActorID     *string
+	Action      *string
+	TargetType  *string
+	TargetID    *string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Search      string
+}
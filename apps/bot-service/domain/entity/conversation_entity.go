@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MessageRoleUser      = "user"
+	MessageRoleAssistant = "assistant"
+	MessageRoleSystem    = "system"
+)
+
+const (
+	MessageKindText               = "text"
+	MessageKindAnswer             = "answer"
+	MessageKindWelcome            = "welcome"
+	MessageKindHelp               = "help"
+	MessageKindRatingRequest      = "rating_request"
+	MessageKindRatingConfirmation = "rating_confirmation"
+	MessageKindInvalidRating      = "invalid_rating"
+	MessageKindGoodbye            = "goodbye"
+	MessageKindFeedbackPrompt     = "feedback_prompt"
+	MessageKindAutoFeedback       = "auto_feedback"
+	MessageKindRateLimited        = "rate_limited"
+	MessageKindError              = "error"
+	MessageKindServiceBusy        = "service_busy"
+)
+
+type Conversation struct {
+	ID                 uuid.UUID  `db:"id"`
+	UserID             uuid.UUID  `db:"user_id"`
+	DifyConversationID *string    `db:"dify_conversation_id"`
+	StartedAt          time.Time  `db:"started_at"`
+	EndedAt            *time.Time `db:"ended_at"`
+	MessageCount       int        `db:"message_count"`
+	CreatedAt          time.Time  `db:"created_at"`
+	UpdatedAt          time.Time  `db:"updated_at"`
+
+	User User `db:"user"`
+}
+
+type Message struct {
+	ID                uuid.UUID `db:"id"`
+	ConversationID    uuid.UUID `db:"conversation_id"`
+	Role              string    `db:"role"`
+	Kind              string    `db:"kind"`
+	Content           string    `db:"content"`
+	WhatsAppMessageID *string   `db:"whatsapp_message_id"`
+	Provider          *string   `db:"provider"`
+	CreatedAt         time.Time `db:"created_at"`
+}
+
+// PendingQuestion is a user message topic extraction hasn't looked at yet.
+// Answered is false for ratings, comments and commands, which the bot
+// doesn't answer. Attempts counts the runs that failed to classify it.
+type PendingQuestion struct {
+	ID        uuid.UUID `db:"id"`
+	Content   string    `db:"content"`
+	Answered  bool      `db:"answered"`
+	Attempts  int       `db:"topic_extraction_attempts"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type GetConversationsFilter struct {
+	Offset      int
+	Limit       int
+	UserID      *uuid.UUID
+	StartedFrom *time.Time
+	StartedTo   *time.Time
+	Search      string
+}
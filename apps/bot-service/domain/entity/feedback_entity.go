@@ -7,15 +7,37 @@ import (
 )
 
 type Feedback struct {
-	ID        uuid.UUID `db:"id"`
-	UserID    uuid.UUID `db:"user_id"`
-	Rating    int       `db:"rating"`
-	Comment   *string   `db:"comment"`
-	CreatedAt time.Time `db:"created_at"`
+	ID                 uuid.UUID  `db:"id"`
+	UserID             uuid.UUID  `db:"user_id"`
+	ConversationID     *uuid.UUID `db:"conversation_id"`
+	DifyConversationID *string    `db:"dify_conversation_id"`
+	Rating             int        `db:"rating"`
+	Comment            *string    `db:"comment"`
+	Source             string     `db:"source"`
+	CreatedAt          time.Time  `db:"created_at"`
 
 	User User `db:"user"`
 }
 
+// Who gave a feedback's rating
+const (
+	FeedbackSourceUser = "user"
+	// The bot rated the conversation after the user stopped responding
+	FeedbackSourceAuto = "auto"
+)
+
+// Orders feedbacks can be listed in
+const (
+	FeedbackSortCreatedAt = "createdAt"
+	FeedbackSortRating    = "rating"
+)
+
+// Sort directions
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
 type GetFeedbacksFilter struct {
 	Offset    int
 	Limit     int
@@ -23,9 +45,96 @@ type GetFeedbacksFilter struct {
 	Ratings   []int
 	MinRating *int
 	MaxRating *int
+	// Matched case-insensitively against the user's job title
+	JobTitle *string
+	// CreatedFrom is inclusive and CreatedTo exclusive
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// Full-text search over comments, in web search syntax
+	Search string
+	// Whether the feedback has a non-blank comment
+	HasComment *bool
+	// SortBy defaults to FeedbackSortCreatedAt and SortOrder to SortOrderDesc
+	SortBy    string
+	SortOrder string
+	// After pages on from a cursor instead of Offset, for ListByCursor. It
+	// only applies to FeedbackSortCreatedAt.
+	After *Cursor
+}
+
+// Bucket sizes of the satisfaction trend
+const (
+	TrendGranularityDay   = "day"
+	TrendGranularityWeek  = "week"
+	TrendGranularityMonth = "month"
+)
+
+// FeedbackMetricsFilter covers all feedback when From is nil. Otherwise From
+// and To bound the period and the previous one runs from PreviousFrom to From.
+type FeedbackMetricsFilter struct {
+	From         *time.Time
+	To           *time.Time
+	PreviousFrom *time.Time
+}
+
+type FeedbackMetrics struct {
+	TotalFeedbacks     int `db:"total_feedbacks"`
+	RatingSum          int `db:"rating_sum"`
+	Rating1            int `db:"rating_1"`
+	Rating2            int `db:"rating_2"`
+	Rating3            int `db:"rating_3"`
+	Rating4            int `db:"rating_4"`
+	Rating5            int `db:"rating_5"`
+	AutoFeedbacks      int `db:"auto_feedbacks"`
+	CommentedFeedbacks int `db:"commented_feedbacks"`
+}
+
+// What a feedback breakdown groups by
+const (
+	BreakdownDimensionJobTitle = "job-title"
+	BreakdownDimensionGender   = "gender"
+	BreakdownDimensionAgeBand  = "age-band"
+)
+
+// Age bands by the user's age when they gave the feedback, youngest first
+const (
+	AgeBandUnder25 = "under-25"
+	AgeBand25To34  = "25-34"
+	AgeBand35To44  = "35-44"
+	AgeBand45To54  = "45-54"
+	AgeBand55Plus  = "55-plus"
+)
+
+// FeedbackBreakdownFilter covers all feedback when From is nil
+type FeedbackBreakdownFilter struct {
+	Dimension string
+	From      *time.Time
+	To        *time.Time
+}
+
+// FeedbackBreakdownRow is one group. Value is nil for users without the
+// attribute.
+type FeedbackBreakdownRow struct {
+	Value          *string `db:"value"`
+	AvgRating      float64 `db:"avg_rating"`
+	FeedbackCount  int     `db:"feedback_count"`
+	CommentedCount int     `db:"commented_count"`
+	UserCount      int     `db:"user_count"`
+}
+
+type SatisfactionTrendFilter struct {
+	Granularity string
+	// Timezone is the IANA name buckets follow the calendar of
+	Timezone string
+	// From is the first day and To the day after the last, both at midnight
+	// in Timezone
+	From time.Time
+	To   time.Time
 }
 
 type SatisfactionTrendRow struct {
+	// Start of the bucket, as a wall clock time in the filter's Timezone
 	Date            time.Time `db:"date"`
 	AvgSatisfaction float64   `db:"avg_satisfaction"`
+	Count           int       `db:"feedback_count"`
 }
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// What an import job imports
+const (
+	ImportJobTypeUsers = "users"
+)
+
+const (
+	ImportJobStatusPending   = "pending"
+	ImportJobStatusRunning   = "running"
+	ImportJobStatusCompleted = "completed" // Finished, though the file may have had problems
+	ImportJobStatusFailed    = "failed"
+)
+
+// ImportJob is an uploaded file waiting for, or done with, a background
+// import. FileData is cleared once the job finishes, and Result holds the
+// import report. CommittedResult is saved along with the imported users, so a
+// job cut short after that doesn't import them twice.
+type ImportJob struct {
+	ID              uuid.UUID       `db:"id"`
+	Type            string          `db:"type"`
+	Status          string          `db:"status"`
+	FileName        string          `db:"file_name"`
+	FileFormat      string          `db:"file_format"`
+	FileData        []byte          `db:"file_data"`
+	Options         json.RawMessage `db:"options"`
+	ActorType       string          `db:"actor_type"`
+	ActorID         *string         `db:"actor_id"`
+	IPAddress       *string         `db:"ip_address"`
+	UserAgent       *string         `db:"user_agent"`
+	TotalRows       int             `db:"total_rows"`
+	ProcessedRows   int             `db:"processed_rows"`
+	Result          json.RawMessage `db:"result"`
+	CommittedResult json.RawMessage `db:"committed_result"`
+	ErrorCode       *string         `db:"error_code"`
+	ErrorMessage    *string         `db:"error_message"`
+	Attempts        int             `db:"attempts"`
+	CreatedAt       time.Time       `db:"created_at"`
+	StartedAt       *time.Time      `db:"started_at"`
+	FinishedAt      *time.Time      `db:"finished_at"`
+	UpdatedAt       time.Time       `db:"updated_at"`
+}
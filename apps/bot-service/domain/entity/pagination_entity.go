@@ -0,0 +1,14 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor is the last row of a page in keyset pagination. IDs are UUIDv7, so
+// they break ties between rows created at the same time in creation order.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
@@ -1,10 +1,122 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type Topic struct {
-	ID        int       `db:"id"`
-	Title     string    `db:"title"`
-	Count     int       `db:"count"`
+	ID               int        `db:"id"`
+	CanonicalTopicID uuid.UUID  `db:"canonical_topic_id"`
+	TopicBatchID     *uuid.UUID `db:"topic_batch_id"`
+	Title            string     `db:"title"`
+	Count            int        `db:"count"`
+	CreatedAt        time.Time  `db:"created_at"`
+}
+
+// TopicMention is an incoming title, to be recorded under the canonical topic
+// Alias belongs to
+type TopicMention struct {
+	Title string
+	Alias string
+	Count int
+	// NewCanonicalTopicID is the ID given to the canonical topic created when
+	// Alias doesn't belong to one yet
+	NewCanonicalTopicID uuid.UUID
+}
+
+// Sources the service records topics from itself
+const (
+	TopicSourceExtraction = "topic-extraction"
+)
+
+// TopicBatch is one call that recorded topics. BatchID is unique per Source.
+// MessageIDs are the messages topic extraction classified for the batch,
+// marked as extracted along with it.
+type TopicBatch struct {
+	ID           uuid.UUID   `db:"id"`
+	BatchID      string      `db:"batch_id"`
+	Source       string      `db:"source"`
+	PayloadHash  string      `db:"payload_hash"`
+	TopicCount   int         `db:"topic_count"`
+	MentionCount int         `db:"mention_count"`
+	ActorType    string      `db:"actor_type"`
+	ActorID      *string     `db:"actor_id"`
+	CreatedAt    time.Time   `db:"created_at"`
+	RolledBackAt *time.Time  `db:"rolled_back_at"`
+	MessageIDs   []uuid.UUID `db:"-"`
+}
+
+type GetTopicBatchesFilter struct {
+	Offset int
+	Limit  int
+	Source string
+}
+
+// CanonicalTopic is a topic as the dashboard shows it
+type CanonicalTopic struct {
+	ID        uuid.UUID `db:"id"`
+	Name      string    `db:"name"`
 	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// TopicAlias maps a normalized title to its canonical topic
+type TopicAlias struct {
+	Alias            string    `db:"alias"`
+	CanonicalTopicID uuid.UUID `db:"canonical_topic_id"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+type GetCanonicalTopicsFilter struct {
+	Offset int
+	Limit  int
+	// Search matches names and aliases
+	Search string
+}
+
+// HotTopic is a canonical topic's total over a window. Title is the topic's
+// name, and ID and CreatedAt come from its latest row.
+type HotTopic struct {
+	Topic
+	PreviousCount int `db:"previous_count"`
+}
+
+// How a hot topic's count compares with the previous window
+const (
+	TopicTrendRising  = "rising"
+	TopicTrendFalling = "falling"
+	TopicTrendSteady  = "steady"
+)
+
+// HotTopicsFilter counts topics in [From, To) and compares them with
+// [PreviousFrom, From)
+type HotTopicsFilter struct {
+	From         time.Time
+	To           time.Time
+	PreviousFrom time.Time
+	Limit        int
+}
+
+// TopicsCountFilter covers all topics when From is nil
+type TopicsCountFilter struct {
+	From *time.Time
+	To   *time.Time
+}
+
+type TopicSeriesFilter struct {
+	CanonicalTopicID uuid.UUID
+	Granularity      string
+	// Timezone is the IANA name buckets follow the calendar of
+	Timezone string
+	// From is the first day and To the day after the last, both at midnight
+	// in Timezone
+	From time.Time
+	To   time.Time
+}
+
+type TopicSeriesRow struct {
+	Date  time.Time `db:"date"`
+	Count int       `db:"topic_count"`
 }
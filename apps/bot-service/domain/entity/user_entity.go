@@ -6,19 +6,48 @@ import (
 	"github.com/google/uuid"
 )
 
+// How an import treats phone numbers that already belong to a user
+const (
+	UserImportModeCreate = "create" // Existing phone numbers are errors
+	UserImportModeUpsert = "upsert" // Existing users are updated
+)
+
 type User struct {
-	ID          uuid.UUID  `db:"id"`
-	PhoneNumber string     `db:"phone_number"`
-	Name        string     `db:"name"`
-	JobTitle    *string    `db:"job_title"`
-	Gender      *string    `db:"gender"`
-	DateOfBirth *time.Time `db:"date_of_birth"`
-	CreatedAt   time.Time  `db:"created_at"`
-	UpdatedAt   time.Time  `db:"updated_at"`
+	ID            uuid.UUID  `db:"id"`
+	PhoneNumber   string     `db:"phone_number"`
+	Name          string     `db:"name"`
+	JobTitle      *string    `db:"job_title"`
+	Gender        *string    `db:"gender"`
+	DateOfBirth   *time.Time `db:"date_of_birth"`
+	DeactivatedAt *time.Time `db:"deactivated_at"`
+	CreatedAt     time.Time  `db:"created_at"`
+	UpdatedAt     time.Time  `db:"updated_at"`
 }
 
 type GetUsersFilter struct {
 	Offset int
 	Limit  int
 	Search string
+	// Leave out deactivated users
+	ActiveOnly bool
+	// After pages on from a cursor instead of Offset, for ListByCursor
+	After *Cursor
+}
+
+type UpsertUsersOptions struct {
+	// Deactivate active users whose phone number isn't in PresentPhoneNumbers
+	DeactivateMissing   bool
+	PresentPhoneNumbers []string
+	Now                 time.Time
+	// The import job the result is saved on, as its committed result
+	ImportJobID uuid.UUID
+}
+
+// UpsertUsersResult holds the phone numbers affected by an upsert, or the
+// ones created by a bulk create
+type UpsertUsersResult struct {
+	Created     []string `json:"created"`
+	Updated     []string `json:"updated"`
+	Unchanged   []string `json:"unchanged"`
+	Deactivated []string `json:"deactivated"`
 }
@@ -0,0 +1,23 @@
+package errx
+
+import (
+	"net/http"
+)
+
+var (
+	ErrAPIKeyNotFound = NewError(
+		http.StatusNotFound,
+		"api_key_not_found",
+		"API key not found.",
+	)
+	ErrAPIKeyScope = NewError(
+		http.StatusForbidden,
+		"insufficient_api_key_scope",
+		"The API key doesn't have the scope required for this resource.",
+	)
+	ErrAPIKeyExpiryInPast = NewError(
+		http.StatusBadRequest,
+		"api_key_expiry_in_past",
+		"The API key expiry must be in the future.",
+	)
+)
@@ -0,0 +1,33 @@
+package errx
+
+import (
+	"net/http"
+)
+
+var (
+	ErrAdminNotFound = NewError(
+		http.StatusNotFound,
+		"admin_not_found",
+		"Admin not found.",
+	)
+	ErrAdminEmailExists = NewError(
+		http.StatusConflict,
+		"admin_email_exists",
+		"An admin with this email already exists.",
+	)
+	ErrInvalidCredentials = NewError(
+		http.StatusUnauthorized,
+		"invalid_credentials",
+		"Invalid email or password.",
+	)
+	ErrCannotModifySelf = NewError(
+		http.StatusForbidden,
+		"cannot_modify_self",
+		"You cannot change your own role or delete your own account.",
+	)
+	ErrInvalidRefreshToken = NewError(
+		http.StatusUnauthorized,
+		"invalid_refresh_token",
+		"The refresh token is invalid or has expired. Please log in again.",
+	)
+)
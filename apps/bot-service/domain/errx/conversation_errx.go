@@ -0,0 +1,13 @@
+package errx
+
+import (
+	"net/http"
+)
+
+var (
+	ErrConversationNotFound = NewError(
+		http.StatusNotFound,
+		"conversation_not_found",
+		"Conversation not found.",
+	)
+)
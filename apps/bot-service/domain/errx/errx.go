@@ -147,4 +147,19 @@ var (
 		"invalid_date_format",
 		"Please enter a valid date format.",
 	)
+	ErrInvalidCursor = NewError(
+		http.StatusBadRequest,
+		"invalid_cursor",
+		"The page cursor is invalid. Please start again from the first page.",
+	)
+	ErrInvalidDateRange = NewError(
+		http.StatusBadRequest,
+		"invalid_date_range",
+		"The start date must be on or before the end date, and at most two years before it.",
+	)
+	ErrInvalidTimezone = NewError(
+		http.StatusBadRequest,
+		"invalid_timezone",
+		"Unknown time zone. Use an IANA name such as Asia/Jakarta.",
+	)
 )
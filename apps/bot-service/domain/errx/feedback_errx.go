@@ -15,9 +15,19 @@ var (
 		"feedback_already_exists",
 		"Feedback for this session already exists.",
 	)
+	ErrFeedbackHasNoConversation = NewError(
+		http.StatusNotFound,
+		"feedback_has_no_conversation",
+		"This feedback is not linked to a conversation.",
+	)
 	ErrInvalidRating = NewError(
 		http.StatusBadRequest,
 		"invalid_rating",
 		"Rating must be between 1 and 5.",
 	)
+	ErrCursorRequiresDateSort = NewError(
+		http.StatusBadRequest,
+		"cursor_requires_date_sort",
+		"Cursor pagination is only available when sorting by date.",
+	)
 )
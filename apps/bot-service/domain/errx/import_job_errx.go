@@ -0,0 +1,18 @@
+package errx
+
+import (
+	"net/http"
+)
+
+var (
+	ErrImportJobNotFound = NewError(
+		http.StatusNotFound,
+		"import_job_not_found",
+		"Import job not found.",
+	)
+	ErrImportJobInterrupted = NewError(
+		http.StatusInternalServerError,
+		"import_job_interrupted",
+		"The import was interrupted too many times. Please upload the file again.",
+	)
+)
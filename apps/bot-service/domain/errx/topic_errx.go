@@ -4,4 +4,36 @@ import "net/http"
 
 var (
 	ErrTopicNotFound = NewError(http.StatusNotFound, "TOPIC_NOT_FOUND", "topic not found")
+
+	ErrTopicNameTaken = NewError(
+		http.StatusConflict,
+		"topic_name_taken",
+		"Another topic already has this name or alias. Merge the topics instead.",
+	)
+	ErrTopicNameBlank = NewError(
+		http.StatusBadRequest,
+		"topic_name_blank",
+		"Topic name can't be blank.",
+	)
+	ErrTopicMergeIntoItself = NewError(
+		http.StatusBadRequest,
+		"topic_merge_into_itself",
+		"A topic can't be merged into itself.",
+	)
+
+	ErrTopicBatchNotFound = NewError(
+		http.StatusNotFound,
+		"topic_batch_not_found",
+		"Topic batch not found.",
+	)
+	ErrTopicBatchConflict = NewError(
+		http.StatusConflict,
+		"topic_batch_conflict",
+		"This batch ID was already sent from this source with different topics.",
+	)
+	ErrTopicBatchRolledBack = NewError(
+		http.StatusConflict,
+		"topic_batch_rolled_back",
+		"This batch has already been rolled back.",
+	)
 )
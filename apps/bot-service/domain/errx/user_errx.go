@@ -23,22 +23,22 @@ var (
 	ErrEmptyCSVFile = NewError(
 		http.StatusBadRequest,
 		"empty_csv_file",
-		"CSV file is empty.",
+		"File is empty.",
 	)
 	ErrCSVNoData = NewError(
 		http.StatusBadRequest,
 		"csv_no_data",
-		"CSV file must contain at least a header row and one data row.",
+		"File must contain at least a header row and one data row.",
 	)
 	ErrInvalidCSVStructure = NewError(
 		http.StatusBadRequest,
 		"invalid_csv_structure",
-		"CSV must have exactly 5 columns: phone_number, name, job_title, gender, date_of_birth.",
+		"Header row must have phone_number and name columns, and may have job_title, gender and date_of_birth, each at most once.",
 	)
 	ErrInvalidCSVRow = NewError(
 		http.StatusBadRequest,
 		"invalid_csv_row",
-		"CSV row has incorrect number of columns.",
+		"Row has more cells than the header row.",
 	)
 	ErrMissingPhoneNumber = NewError(
 		http.StatusBadRequest,
@@ -55,9 +55,24 @@ var (
 		"invalid_phone_number",
 		"Invalid phone number format (must be E.164 format).",
 	)
+	ErrInvalidName = NewError(
+		http.StatusBadRequest,
+		"invalid_name",
+		"Name must be at most 255 characters.",
+	)
+	ErrInvalidJobTitle = NewError(
+		http.StatusBadRequest,
+		"invalid_job_title",
+		"Job title must be at most 255 characters.",
+	)
 	ErrInvalidGender = NewError(
 		http.StatusBadRequest,
 		"invalid_gender",
 		"Gender must be either 'male' or 'female'.",
 	)
+	ErrDuplicatePhoneInFile = NewError(
+		http.StatusBadRequest,
+		"duplicate_phone_in_file",
+		"This phone number appears more than once in the file.",
+	)
 )
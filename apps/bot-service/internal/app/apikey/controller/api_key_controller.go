@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (c *APIKeyController) create(ctx *fiber.Ctx) error {
+	var req dto.CreateAPIKeyRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return err
+	}
+
+	res, err := c.apiKeySvc.Create(ctx.Context(), middlewares.GetAuditActor(ctx), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusCreated, res)
+}
+
+func (c *APIKeyController) list(ctx *fiber.Ctx) error {
+	var query dto.GetAPIKeysQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.apiKeySvc.List(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *APIKeyController) revoke(ctx *fiber.Ctx) error {
+	var params dto.RevokeAPIKeyParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	if err := c.apiKeySvc.Revoke(ctx.Context(), middlewares.GetAuditActor(ctx), &params); err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusNoContent, nil)
+}
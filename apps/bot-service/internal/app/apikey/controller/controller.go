@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/apikey/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+type APIKeyController struct {
+	apiKeySvc *service.APIKeyService
+}
+
+func InitAPIKeyController(router fiber.Router, apiKeySvc *service.APIKeyService, middleware *middlewares.Middleware) {
+	controller := &APIKeyController{
+		apiKeySvc: apiKeySvc,
+	}
+
+	apiKeyRouter := router.Group("/api-keys", middleware.RequireAuth(), middleware.RequireRole(entity.AdminRoleSuperadmin))
+
+	apiKeyRouter.Post("/", controller.create)
+	apiKeyRouter.Get("/", controller.list)
+	apiKeyRouter.Post("/:id/revoke", controller.revoke)
+}
@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/pg"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (r *apiKeyRepository) Create(ctx context.Context, apiKey *entity.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, name, prefix, key_hash, scopes, created_by, expires_at, created_at)
+		VALUES (:id, :name, :prefix, :key_hash, :scopes, :created_by, :expires_at, :created_at)
+	`
+
+	_, err := r.db.NamedExecContext(
+		ctx,
+		query,
+		apiKey,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			pgErrors := []pg.PgError{
+				{
+					Code:           pg.ForeignKey,
+					ConstraintName: "fk_api_key_created_by",
+					Err: errx.ErrAdminNotFound.WithDetails(map[string]any{
+						"created_by": apiKey.CreatedBy,
+					}).WithLocation("apiKeyRepository.Create"),
+				},
+			}
+
+			if customPgErr := pg.HandlePgError(pgErr, pgErrors); customPgErr != nil {
+				return customPgErr
+			}
+		}
+
+		return errx.ErrInternalServer.WithLocation("apiKeyRepository.Create").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	query := `
+		SELECT id, name, prefix, key_hash, scopes, created_by, last_used_at, expires_at, revoked_at, created_at
+		FROM api_keys
+		WHERE key_hash = $1
+	`
+
+	var apiKey entity.APIKey
+	err := r.db.GetContext(ctx, &apiKey, query, keyHash)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrAPIKeyNotFound.WithLocation("apiKeyRepository.FindByHash")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("apiKeyRepository.FindByHash").WithError(err)
+	}
+
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context, filter *entity.GetAPIKeysFilter) ([]entity.APIKey, int64, error) {
+	offset := min(max(filter.Offset, 0), 10000)
+	limit := min(max(filter.Limit, 10), 100)
+
+	var qb strings.Builder
+	var whereClauses strings.Builder
+	var args []any
+
+	qb.WriteString(`
+		SELECT id, name, prefix, key_hash, scopes, created_by, last_used_at, expires_at, revoked_at, created_at
+		FROM api_keys
+	`)
+
+	if !filter.IncludeRevoked {
+		whereClauses.WriteString(" AND revoked_at IS NULL")
+	}
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM api_keys WHERE 1=1"+whereClauses.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("apiKeyRepository.List.Count").WithError(err)
+	}
+
+	if whereClauses.Len() > 0 {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses.String())
+	}
+	qb.WriteString(" ORDER BY created_at DESC")
+	qb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2))
+
+	args = append(args, limit, offset)
+
+	var apiKeys []entity.APIKey
+	err = r.db.SelectContext(ctx, &apiKeys, qb.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("apiKeyRepository.List.Select").WithError(err)
+	}
+
+	if apiKeys == nil {
+		apiKeys = []entity.APIKey{}
+	}
+
+	return apiKeys, total, nil
+}
+
+// Revoke revokes a key that hasn't been revoked yet. Revoking an unknown or
+// already revoked key returns ErrAPIKeyNotFound.
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, revokedAt)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("apiKeyRepository.Revoke").WithError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("apiKeyRepository.Revoke.RowsAffected").WithError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errx.ErrAPIKeyNotFound.WithDetails(map[string]any{
+			"id": id,
+		}).WithLocation("apiKeyRepository.Revoke")
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsedAt(ctx context.Context, id uuid.UUID, lastUsedAt time.Time) error {
+	query := `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, id, lastUsedAt); err != nil {
+		return errx.ErrInternalServer.WithLocation("apiKeyRepository.UpdateLastUsedAt").WithError(err)
+	}
+
+	return nil
+}
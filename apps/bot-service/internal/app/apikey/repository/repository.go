@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/jmoiron/sqlx"
+)
+
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+func NewAPIKeyRepository(db *sqlx.DB) contracts.APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/google/uuid"
+)
+
+const (
+	// Makes keys easy to spot, e.g. by secret scanners
+	apiKeyPrefix = "hcppn_"
+
+	// Length of the part of the key that is stored in clear to tell keys apart
+	apiKeyDisplayLength = len(apiKeyPrefix) + 8
+
+	// How often last_used_at is updated, so busy callers don't write on every
+	// request
+	lastUsedAtResolution = time.Minute
+)
+
+// Create issues a new API key. The key itself is only returned here; only its
+// hash is stored.
+func (s *APIKeyService) Create(ctx context.Context, actor entity.AuditActor, req *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	var expiresAt *time.Time
+	if req.ExpiresAt != nil {
+		parsed, err := time.Parse(time.RFC3339, *req.ExpiresAt)
+		if err != nil {
+			return nil, errx.ErrInvalidDateFormat.WithDetails(map[string]any{
+				"req.ExpiresAt": *req.ExpiresAt,
+			}).WithLocation("APIKeyService.Create").WithError(err)
+		}
+
+		if !parsed.After(now) {
+			return nil, errx.ErrAPIKeyExpiryInPast.WithDetails(map[string]any{
+				"req.ExpiresAt": *req.ExpiresAt,
+			}).WithLocation("APIKeyService.Create")
+		}
+		expiresAt = &parsed
+	}
+
+	var createdBy *uuid.UUID
+	if actor.Type == entity.AuditActorAdmin && actor.ID != nil {
+		parsed, err := s.uuidPkg.Parse(*actor.ID)
+		if err != nil {
+			return nil, errx.ErrUnauthorized.WithDetails(map[string]any{
+				"admin_id": *actor.ID,
+			}).WithLocation("APIKeyService.Create").WithError(err)
+		}
+		createdBy = &parsed
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("APIKeyService.Create").WithError(err)
+	}
+
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("APIKeyService.Create").WithError(err)
+	}
+
+	apiKey := &entity.APIKey{
+		ID:        id,
+		Name:      req.Name,
+		Prefix:    key[:apiKeyDisplayLength],
+		KeyHash:   hashAPIKey(key),
+		Scopes:    strings.Join(req.Scopes, " "),
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+
+	if err := s.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	res := &dto.CreateAPIKeyResponse{
+		APIKey: dto.ToAPIKeyResponse(apiKey),
+		Key:    key,
+	}
+
+	targetID := id.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionAPIKeyCreate,
+		TargetType: entity.AuditTargetAPIKey,
+		TargetID:   &targetID,
+		After:      res.APIKey,
+	})
+
+	return res, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context, query *dto.GetAPIKeysQuery) (*dto.GetAPIKeysResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	limit := min(max(query.Limit, 10), 100)
+	page := max(query.Page, 1)
+
+	filter := entity.GetAPIKeysFilter{
+		Offset:         (page - 1) * limit,
+		Limit:          limit,
+		IncludeRevoked: query.IncludeRevoked,
+	}
+
+	apiKeys, total, err := s.apiKeyRepo.List(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyResponses := make([]dto.APIKeyResponse, 0, len(apiKeys))
+	for i := range apiKeys {
+		apiKeyResponses = append(apiKeyResponses, dto.ToAPIKeyResponse(&apiKeys[i]))
+	}
+
+	res := &dto.GetAPIKeysResponse{
+		APIKeys: apiKeyResponses,
+	}
+
+	res.Meta.Pagination = dto.NewPaginationResponse(total, page, limit)
+
+	return res, nil
+}
+
+func (s *APIKeyService) Revoke(ctx context.Context, actor entity.AuditActor, param *dto.RevokeAPIKeyParam) error {
+	if err := s.validator.Validate(param); err != nil {
+		return err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return errx.ErrAPIKeyNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("APIKeyService.Revoke").WithError(err)
+	}
+
+	if err := s.apiKeyRepo.Revoke(ctx, id, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	targetID := id.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionAPIKeyRevoke,
+		TargetType: entity.AuditTargetAPIKey,
+		TargetID:   &targetID,
+	})
+
+	return nil
+}
+
+// Authenticate returns the API key matching key if it's active and has scope.
+func (s *APIKeyService) Authenticate(ctx context.Context, key string, scope string) (*entity.APIKey, error) {
+	apiKey, err := s.apiKeyRepo.FindByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, errx.ErrAPIKeyNotFound) {
+			return nil, errx.ErrInvalidAPIKey.WithLocation("APIKeyService.Authenticate").WithError(err)
+		}
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if apiKey.RevokedAt != nil || (apiKey.ExpiresAt != nil && !now.Before(*apiKey.ExpiresAt)) {
+		return nil, errx.ErrInvalidAPIKey.WithDetails(map[string]any{
+			"id": apiKey.ID,
+		}).WithLocation("APIKeyService.Authenticate")
+	}
+
+	if !slices.Contains(strings.Fields(apiKey.Scopes), scope) {
+		return nil, errx.ErrAPIKeyScope.WithDetails(map[string]any{
+			"id":    apiKey.ID,
+			"scope": scope,
+		}).WithLocation("APIKeyService.Authenticate")
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= lastUsedAtResolution {
+		if err := s.apiKeyRepo.UpdateLastUsedAt(ctx, apiKey.ID, now); err != nil {
+			return nil, err
+		}
+		apiKey.LastUsedAt = &now
+	}
+
+	return apiKey, nil
+}
+
+func generateAPIKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	apiKeyRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/apikey/repository/mock"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIKeyService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := apiKeyRepoMock.NewMockAPIKeyRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAPIKeyService(mockAPIKeyRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	actorID := uuid.New()
+	actorIDString := actorID.String()
+	actor := entity.AuditActor{Type: entity.AuditActorAdmin, ID: &actorIDString}
+	futureExpiry := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
+	pastExpiry := time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+	tests := []struct {
+		name    string
+		req     *dto.CreateAPIKeyRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success",
+			req: &dto.CreateAPIKeyRequest{
+				Name:      "Dify workflow",
+				Scopes:    []string{entity.APIKeyScopeTopicsWrite, entity.APIKeyScopeFeedbacksWrite},
+				ExpiresAt: &futureExpiry,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(actorID.String()).Return(actorID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockAPIKeyRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, apiKey *entity.APIKey) error {
+					assert.Equal(t, testID, apiKey.ID)
+					assert.Equal(t, "Dify workflow", apiKey.Name)
+					assert.Equal(t, "topics:write feedbacks:write", apiKey.Scopes)
+					assert.Equal(t, &actorID, apiKey.CreatedBy)
+					assert.NotNil(t, apiKey.ExpiresAt)
+					assert.True(t, strings.HasPrefix(apiKey.Prefix, apiKeyPrefix))
+					assert.Len(t, apiKey.KeyHash, 64)
+					return nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionAPIKeyCreate, req.Action)
+					assert.Equal(t, entity.AuditTargetAPIKey, req.TargetType)
+					assert.Equal(t, testID.String(), *req.TargetID)
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "validation error - unknown scope",
+			req: &dto.CreateAPIKeyRequest{
+				Name:   "Dify workflow",
+				Scopes: []string{"users:write"},
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.scopes[0]": validator.ValidationError{
+						Message: "scopes[0] must be one of [topics:write feedbacks:write]",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "expiry in the past",
+			req: &dto.CreateAPIKeyRequest{
+				Name:      "Dify workflow",
+				Scopes:    []string{entity.APIKeyScopeTopicsWrite},
+				ExpiresAt: &pastExpiry,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrAPIKeyExpiryInPast,
+		},
+		{
+			name: "repository error",
+			req: &dto.CreateAPIKeyRequest{
+				Name:   "Dify workflow",
+				Scopes: []string{entity.APIKeyScopeTopicsWrite},
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(actorID.String()).Return(actorID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockAPIKeyRepo.EXPECT().Create(ctx, gomock.Any()).Return(errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.Create(ctx, actor, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testID.String(), result.APIKey.ID)
+				assert.True(t, strings.HasPrefix(result.Key, result.APIKey.Prefix))
+				assert.Equal(t, []string{"topics:write", "feedbacks:write"}, result.APIKey.Scopes)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := apiKeyRepoMock.NewMockAPIKeyRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAPIKeyService(mockAPIKeyRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	testAPIKeys := []entity.APIKey{
+		{
+			ID:        uuid.New(),
+			Name:      "Dify workflow",
+			Prefix:    "hcppn_abcdefgh",
+			Scopes:    entity.APIKeyScopeTopicsWrite,
+			CreatedAt: time.Now(),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		query     *dto.GetAPIKeysQuery
+		setup     func()
+		wantErr   bool
+		wantCount int
+	}{
+		{
+			name:  "success",
+			query: &dto.GetAPIKeysQuery{IncludeRevoked: true},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAPIKeyRepo.EXPECT().List(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetAPIKeysFilter) ([]entity.APIKey, int64, error) {
+					assert.True(t, filter.IncludeRevoked)
+					assert.Equal(t, 0, filter.Offset)
+					return testAPIKeys, 1, nil
+				})
+			},
+			wantErr:   false,
+			wantCount: 1,
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetAPIKeysQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAPIKeyRepo.EXPECT().List(ctx, gomock.Any()).Return(nil, int64(0), errx.ErrInternalServer)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.List(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.APIKeys, tt.wantCount)
+				assert.Equal(t, []string{entity.APIKeyScopeTopicsWrite}, result.APIKeys[0].Scopes)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := apiKeyRepoMock.NewMockAPIKeyRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAPIKeyService(mockAPIKeyRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	actorID := uuid.New().String()
+	actor := entity.AuditActor{Type: entity.AuditActorAdmin, ID: &actorID}
+
+	tests := []struct {
+		name    string
+		param   *dto.RevokeAPIKeyParam
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name:  "success",
+			param: &dto.RevokeAPIKeyParam{ID: testID.String()},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAPIKeyRepo.EXPECT().Revoke(ctx, testID, gomock.Any()).Return(nil)
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionAPIKeyRevoke, req.Action)
+					assert.Equal(t, testID.String(), *req.TargetID)
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name:  "already revoked",
+			param: &dto.RevokeAPIKeyParam{ID: testID.String()},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAPIKeyRepo.EXPECT().Revoke(ctx, testID, gomock.Any()).Return(errx.ErrAPIKeyNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrAPIKeyNotFound,
+		},
+		{
+			name:  "invalid uuid",
+			param: &dto.RevokeAPIKeyParam{ID: "invalid"},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse("invalid").Return(uuid.Nil, errors.New("invalid UUID"))
+			},
+			wantErr: true,
+			errType: errx.ErrAPIKeyNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := service.Revoke(ctx, actor, tt.param)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAPIKeyRepo := apiKeyRepoMock.NewMockAPIKeyRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAPIKeyService(mockAPIKeyRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	key := "hcppn_test-key"
+	recently := time.Now().Add(-10 * time.Second)
+	past := time.Now().Add(-time.Hour)
+
+	activeKey := func() *entity.APIKey {
+		return &entity.APIKey{
+			ID:     testID,
+			Scopes: "topics:write feedbacks:write",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		scope   string
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name:  "success - records first use",
+			scope: entity.APIKeyScopeTopicsWrite,
+			setup: func() {
+				mockAPIKeyRepo.EXPECT().FindByHash(ctx, hashAPIKey(key)).Return(activeKey(), nil)
+				mockAPIKeyRepo.EXPECT().UpdateLastUsedAt(ctx, testID, gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "success - recently used key isn't updated",
+			scope: entity.APIKeyScopeFeedbacksWrite,
+			setup: func() {
+				apiKey := activeKey()
+				apiKey.LastUsedAt = &recently
+				mockAPIKeyRepo.EXPECT().FindByHash(ctx, hashAPIKey(key)).Return(apiKey, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "unknown key",
+			scope: entity.APIKeyScopeTopicsWrite,
+			setup: func() {
+				mockAPIKeyRepo.EXPECT().FindByHash(ctx, hashAPIKey(key)).Return(nil, errx.ErrAPIKeyNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidAPIKey,
+		},
+		{
+			name:  "revoked key",
+			scope: entity.APIKeyScopeTopicsWrite,
+			setup: func() {
+				apiKey := activeKey()
+				apiKey.RevokedAt = &past
+				mockAPIKeyRepo.EXPECT().FindByHash(ctx, hashAPIKey(key)).Return(apiKey, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidAPIKey,
+		},
+		{
+			name:  "expired key",
+			scope: entity.APIKeyScopeTopicsWrite,
+			setup: func() {
+				apiKey := activeKey()
+				apiKey.ExpiresAt = &past
+				mockAPIKeyRepo.EXPECT().FindByHash(ctx, hashAPIKey(key)).Return(apiKey, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidAPIKey,
+		},
+		{
+			name:  "missing scope",
+			scope: entity.APIKeyScopeTopicsWrite,
+			setup: func() {
+				apiKey := activeKey()
+				apiKey.Scopes = entity.APIKeyScopeFeedbacksWrite
+				mockAPIKeyRepo.EXPECT().FindByHash(ctx, hashAPIKey(key)).Return(apiKey, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrAPIKeyScope,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.Authenticate(ctx, key, tt.scope)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testID, result.ID)
+				assert.NotNil(t, result.LastUsedAt)
+			}
+		})
+	}
+}
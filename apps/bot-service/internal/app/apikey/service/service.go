@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+)
+
+type APIKeyService struct {
+	apiKeyRepo contracts.APIKeyRepository
+	validator  validator.CustomValidatorInterface
+	uuidPkg    uuid.UUIDInterface
+	auditSvc   contracts.AuditService
+}
+
+func NewAPIKeyService(apiKeyRepo contracts.APIKeyRepository, validatorService validator.CustomValidatorInterface, uuidService uuid.UUIDInterface, auditService contracts.AuditService) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo: apiKeyRepo,
+		validator:  validatorService,
+		uuidPkg:    uuidService,
+		auditSvc:   auditService,
+	}
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (c *AuditController) list(ctx *fiber.Ctx) error {
+	var query dto.GetAuditEventsQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.auditSvc.List(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuditController struct {
+	auditSvc *service.AuditService
+}
+
+func InitAuditController(router fiber.Router, auditSvc *service.AuditService, middleware *middlewares.Middleware) {
+	controller := &AuditController{
+		auditSvc: auditSvc,
+	}
+
+	auditRouter := router.Group(
+		"/audit-events",
+		middleware.RequireAuth(),
+		middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin),
+	)
+
+	auditRouter.Get("/", controller.list)
+}
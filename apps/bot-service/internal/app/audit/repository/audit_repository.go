@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+)
+
+func (r *auditRepository) Create(ctx context.Context, event *entity.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (id, actor_type, actor_id, action, target_type, target_id, changes, metadata, ip_address, user_agent, created_at)
+		VALUES (:id, :actor_type, :actor_id, :action, :target_type, :target_id, :changes, :metadata, :ip_address, :user_agent, :created_at)
+	`
+
+	_, err := r.db.NamedExecContext(
+		ctx,
+		query,
+		event,
+	)
+
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("auditRepository.Create").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *auditRepository) List(ctx context.Context, filter *entity.GetAuditEventsFilter) ([]entity.AuditEvent, int64, error) {
+	offset := min(max(filter.Offset, 0), 10000)
+	limit := min(max(filter.Limit, 10), 100)
+
+	var qb strings.Builder
+	var whereClauses strings.Builder
+	var args []any
+
+	qb.WriteString(`
+		SELECT id, actor_type, actor_id, action, target_type, target_id, changes, metadata, ip_address, user_agent, created_at
+		FROM audit_events
+	`)
+
+	if filter.ActorType != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND actor_type = $%d", len(args)+1))
+		args = append(args, *filter.ActorType)
+	}
+
+	if filter.ActorID != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND actor_id = $%d", len(args)+1))
+		args = append(args, *filter.ActorID)
+	}
+
+	if filter.Action != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND action = $%d", len(args)+1))
+		args = append(args, *filter.Action)
+	}
+
+	if filter.TargetType != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND target_type = $%d", len(args)+1))
+		args = append(args, *filter.TargetType)
+	}
+
+	if filter.TargetID != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND target_id = $%d", len(args)+1))
+		args = append(args, *filter.TargetID)
+	}
+
+	if filter.CreatedFrom != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND created_at >= $%d", len(args)+1))
+		args = append(args, filter.CreatedFrom.UTC())
+	}
+
+	if filter.CreatedTo != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND created_at < $%d", len(args)+1))
+		args = append(args, filter.CreatedTo.UTC())
+	}
+
+	// Matches values inside the snapshots, e.g. a deleted employee's phone number
+	if filter.Search != "" {
+		whereClauses.WriteString(fmt.Sprintf(" AND (changes::text ILIKE $%d OR metadata::text ILIKE $%d)", len(args)+1, len(args)+1))
+		args = append(args, "%"+filter.Search+"%")
+	}
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM audit_events WHERE 1=1"+whereClauses.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("auditRepository.List.Count").WithError(err)
+	}
+
+	if whereClauses.Len() > 0 {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses.String())
+	}
+	qb.WriteString(" ORDER BY created_at DESC")
+	qb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2))
+
+	args = append(args, limit, offset)
+
+	var events []entity.AuditEvent
+	err = r.db.SelectContext(ctx, &events, qb.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("auditRepository.List.Select").WithError(err)
+	}
+
+	if events == nil {
+		events = []entity.AuditEvent{}
+	}
+
+	return events, total, nil
+}
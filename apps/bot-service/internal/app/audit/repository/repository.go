@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/jmoiron/sqlx"
+)
+
+type auditRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuditRepository(db *sqlx.DB) contracts.AuditRepository {
+	return &auditRepository{db: db}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/report"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
+)
+
+// Snapshot fields that change on every update and would only add noise
+var ignoredFields = []string{"updatedAt"}
+
+type fieldChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Record stores an audit event for a change that has been made. Only the
+// fields that differ between req.Before and req.After are kept. A failure is
+// logged rather than returned, since the change it describes is already made.
+func (s *AuditService) Record(ctx context.Context, req *dto.RecordAuditEventRequest) {
+	if err := s.record(ctx, req); err != nil {
+		log.Error(log.CustomLogInfo{
+			"error":  err.Error(),
+			"action": req.Action,
+		}, "[AuditService] Failed to record audit event")
+	}
+}
+
+func (s *AuditService) record(ctx context.Context, req *dto.RecordAuditEventRequest) error {
+	changes, err := diffSnapshots(req.Before, req.After)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("AuditService.Record").WithError(err)
+	}
+
+	metadata := req.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	rawMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("AuditService.Record").WithError(err)
+	}
+
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("AuditService.Record").WithError(err)
+	}
+
+	event := &entity.AuditEvent{
+		ID:         id,
+		ActorType:  req.Actor.Type,
+		ActorID:    req.Actor.ID,
+		Action:     req.Action,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+		Changes:    changes,
+		Metadata:   rawMetadata,
+		IPAddress:  optionalString(req.Actor.IPAddress),
+		UserAgent:  optionalString(req.Actor.UserAgent),
+		CreatedAt:  time.Now().UTC(),
+	}
+
+	if err := s.auditRepo.Create(ctx, event); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *AuditService) List(ctx context.Context, query *dto.GetAuditEventsQuery) (*dto.GetAuditEventsResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	limit := min(max(query.Limit, 10), 100)
+	page := max(query.Page, 1)
+
+	createdFrom, createdTo, err := report.ParseFilterRange(query.From, query.To, query.Timezone, "AuditService.List")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.GetAuditEventsFilter{
+		Offset:      (page - 1) * limit,
+		Limit:       limit,
+		ActorType:   query.ActorType,
+		ActorID:     query.ActorID,
+		Action:      query.Action,
+		TargetType:  query.TargetType,
+		TargetID:    query.TargetID,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		Search:      query.Search,
+	}
+
+	events, total, err := s.auditRepo.List(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	eventResponses := make([]dto.AuditEventResponse, 0, len(events))
+	for i := range events {
+		eventResponses = append(eventResponses, dto.ToAuditEventResponse(&events[i]))
+	}
+
+	res := &dto.GetAuditEventsResponse{
+		AuditEvents: eventResponses,
+	}
+
+	res.Meta.Pagination = dto.NewPaginationResponse(total, page, limit)
+
+	return res, nil
+}
+
+// diffSnapshots returns the fields of the JSON encodings of before and after
+// that differ. A nil snapshot has no fields, so creating or deleting the
+// target lists all of its fields.
+func diffSnapshots(before, after any) (json.RawMessage, error) {
+	beforeFields, err := snapshotFields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := snapshotFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := map[string]fieldChange{}
+	for _, fields := range []map[string]any{beforeFields, afterFields} {
+		for key := range fields {
+			if slices.Contains(ignoredFields, key) {
+				continue
+			}
+
+			if _, seen := changes[key]; seen {
+				continue
+			}
+
+			if reflect.DeepEqual(beforeFields[key], afterFields[key]) {
+				continue
+			}
+
+			changes[key] = fieldChange{
+				Before: beforeFields[key],
+				After:  afterFields[key],
+			}
+		}
+	}
+
+	return json.Marshal(changes)
+}
+
+func snapshotFields(snapshot any) (map[string]any, error) {
+	if snapshot == nil {
+		return map[string]any{}, nil
+	}
+
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/repository/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditService_Record(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := auditRepoMock.NewMockAuditRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewAuditService(mockAuditRepo, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	actorID := uuid.New().String()
+	targetID := uuid.New().String()
+	actor := entity.AuditActor{
+		Type:      entity.AuditActorAdmin,
+		ID:        &actorID,
+		IPAddress: "10.0.0.1",
+		UserAgent: "Mozilla/5.0",
+	}
+	jobTitle := "Engineer"
+
+	before := dto.UserResponse{
+		ID:          targetID,
+		PhoneNumber: "+6281234567890",
+		Name:        "Old Name",
+		UpdatedAt:   "2025-01-01T00:00:00Z",
+	}
+	after := before
+	after.Name = "New Name"
+	after.JobTitle = &jobTitle
+	after.UpdatedAt = "2025-02-01T00:00:00Z"
+
+	tests := []struct {
+		name        string
+		req         *dto.RecordAuditEventRequest
+		setup       func()
+		repoErr     error
+		wantChanges map[string]fieldChange
+	}{
+		{
+			name: "success - only changed fields are kept",
+			req: &dto.RecordAuditEventRequest{
+				Actor:      actor,
+				Action:     entity.AuditActionUserUpdate,
+				TargetType: entity.AuditTargetUser,
+				TargetID:   &targetID,
+				Before:     before,
+				After:      after,
+			},
+			setup: func() {
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+			},
+			wantChanges: map[string]fieldChange{
+				"name":     {Before: "Old Name", After: "New Name"},
+				"jobTitle": {Before: nil, After: "Engineer"},
+			},
+		},
+		{
+			name: "success - deletion keeps the whole snapshot",
+			req: &dto.RecordAuditEventRequest{
+				Actor:      actor,
+				Action:     entity.AuditActionUserDelete,
+				TargetType: entity.AuditTargetUser,
+				TargetID:   &targetID,
+				Before:     dto.UserResponse{ID: targetID, PhoneNumber: "+6281234567890", Name: "Old Name"},
+			},
+			setup: func() {
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+			},
+			wantChanges: map[string]fieldChange{
+				"id":          {Before: targetID, After: nil},
+				"phoneNumber": {Before: "+6281234567890", After: nil},
+				"name":        {Before: "Old Name", After: nil},
+				"createdAt":   {Before: "", After: nil},
+			},
+		},
+		{
+			name: "repository failure is only logged",
+			req: &dto.RecordAuditEventRequest{
+				Actor:      actor,
+				Action:     entity.AuditActionUserUpdate,
+				TargetType: entity.AuditTargetUser,
+				TargetID:   &targetID,
+				Before:     before,
+				After:      after,
+			},
+			setup: func() {
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+			},
+			repoErr: errx.ErrInternalServer,
+			wantChanges: map[string]fieldChange{
+				"name":     {Before: "Old Name", After: "New Name"},
+				"jobTitle": {Before: nil, After: "Engineer"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			mockAuditRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, event *entity.AuditEvent) error {
+				assert.Equal(t, testID, event.ID)
+				assert.Equal(t, entity.AuditActorAdmin, event.ActorType)
+				assert.Equal(t, &actorID, event.ActorID)
+				assert.Equal(t, tt.req.Action, event.Action)
+				assert.Equal(t, "10.0.0.1", *event.IPAddress)
+				assert.Equal(t, "Mozilla/5.0", *event.UserAgent)
+				assert.JSONEq(t, "{}", string(event.Metadata))
+
+				wantChanges, err := json.Marshal(tt.wantChanges)
+				assert.NoError(t, err)
+				assert.JSONEq(t, string(wantChanges), string(event.Changes))
+				return tt.repoErr
+			})
+
+			// Returns nothing, so a failure can't undo the caller's change
+			service.Record(ctx, tt.req)
+		})
+	}
+}
+
+func TestAuditService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuditRepo := auditRepoMock.NewMockAuditRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewAuditService(mockAuditRepo, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	targetID := uuid.New().String()
+	action := entity.AuditActionUserDelete
+	from := "2025-01-01"
+	to := "2025-01-31"
+	invalidDate := "2025-13-01"
+
+	testEvents := []entity.AuditEvent{
+		{
+			ID:         uuid.New(),
+			ActorType:  entity.AuditActorAdmin,
+			Action:     entity.AuditActionUserDelete,
+			TargetType: entity.AuditTargetUser,
+			TargetID:   &targetID,
+			Changes:    json.RawMessage(`{}`),
+			Metadata:   json.RawMessage(`{}`),
+			CreatedAt:  time.Now(),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		query     *dto.GetAuditEventsQuery
+		setup     func()
+		wantErr   bool
+		errType   error
+		wantCount int
+	}{
+		{
+			name: "success - with filters",
+			query: &dto.GetAuditEventsQuery{
+				Action:   &action,
+				TargetID: &targetID,
+				From:     &from,
+				To:       &to,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuditRepo.EXPECT().List(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetAuditEventsFilter) ([]entity.AuditEvent, int64, error) {
+					assert.Equal(t, &action, filter.Action)
+					assert.Equal(t, &targetID, filter.TargetID)
+					// Midnights in Asia/Jakarta, and "to" is inclusive
+					assert.Equal(t, time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC), filter.CreatedFrom.UTC())
+					assert.Equal(t, time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC), filter.CreatedTo.UTC())
+					return testEvents, 1, nil
+				})
+			},
+			wantErr:   false,
+			wantCount: 1,
+		},
+		{
+			name: "validation error",
+			query: &dto.GetAuditEventsQuery{
+				TargetID: func() *string { s := "invalid"; return &s }(),
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"query.targetId": validator.ValidationError{
+						Message: "targetId must be a valid UUID",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid date",
+			query: &dto.GetAuditEventsQuery{
+				From: &invalidDate,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.List(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.AuditEvents, tt.wantCount)
+				assert.Equal(t, targetID, *result.AuditEvents[0].TargetID)
+			}
+		})
+	}
+}
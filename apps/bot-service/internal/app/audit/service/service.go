@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+)
+
+type AuditService struct {
+	auditRepo contracts.AuditRepository
+	validator validator.CustomValidatorInterface
+	uuidPkg   uuid.UUIDInterface
+}
+
+func NewAuditService(auditRepo contracts.AuditRepository, validatorService validator.CustomValidatorInterface, uuidService uuid.UUIDInterface) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+		validator: validatorService,
+		uuidPkg:   uuidService,
+	}
+}
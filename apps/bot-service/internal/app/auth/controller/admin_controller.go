@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (c *AuthController) createAdmin(ctx *fiber.Ctx) error {
+	var req dto.CreateAdminRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return err
+	}
+
+	res, err := c.authSvc.CreateAdmin(ctx.Context(), middlewares.GetAuditActor(ctx), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusCreated, res)
+}
+
+func (c *AuthController) listAdmins(ctx *fiber.Ctx) error {
+	var query dto.GetAdminsQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.authSvc.ListAdmins(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *AuthController) getAdminByID(ctx *fiber.Ctx) error {
+	var params dto.GetAdminByIDParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	res, err := c.authSvc.GetAdminByID(ctx.Context(), &params)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *AuthController) updateAdmin(ctx *fiber.Ctx) error {
+	var params dto.UpdateAdminParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	var req dto.UpdateAdminRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return err
+	}
+
+	if err := c.authSvc.UpdateAdmin(ctx.Context(), middlewares.GetAuditActor(ctx), &params, &req); err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusNoContent, nil)
+}
+
+func (c *AuthController) deleteAdmin(ctx *fiber.Ctx) error {
+	var params dto.DeleteAdminParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	if err := c.authSvc.DeleteAdmin(ctx.Context(), middlewares.GetAuditActor(ctx), &params); err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusNoContent, nil)
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (c *AuthController) login(ctx *fiber.Ctx) error {
+	var req dto.LoginRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return err
+	}
+
+	res, err := c.authSvc.Login(ctx.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *AuthController) refresh(ctx *fiber.Ctx) error {
+	var req dto.RefreshTokenRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return err
+	}
+
+	res, err := c.authSvc.Refresh(ctx.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *AuthController) getMe(ctx *fiber.Ctx) error {
+	claims, ok := middlewares.GetClaims(ctx)
+	if !ok {
+		return errx.ErrUnauthorized
+	}
+
+	res, err := c.authSvc.GetMe(ctx.Context(), claims.Subject)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
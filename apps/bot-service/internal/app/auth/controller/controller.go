@@ -0,0 +1,32 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/auth/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AuthController struct {
+	authSvc *service.AuthService
+}
+
+func InitAuthController(router fiber.Router, authSvc *service.AuthService, middleware *middlewares.Middleware) {
+	controller := &AuthController{
+		authSvc: authSvc,
+	}
+
+	authRouter := router.Group("/auth")
+
+	authRouter.Post("/login", controller.login)
+	authRouter.Post("/refresh", controller.refresh)
+	authRouter.Get("/me", middleware.RequireAuth(), controller.getMe)
+
+	adminRouter := router.Group("/admins", middleware.RequireAuth(), middleware.RequireRole(entity.AdminRoleSuperadmin))
+
+	adminRouter.Post("/", controller.createAdmin)
+	adminRouter.Get("/", controller.listAdmins)
+	adminRouter.Patch("/:id", controller.updateAdmin)
+	adminRouter.Delete("/:id", controller.deleteAdmin)
+	adminRouter.Get("/:id", controller.getAdminByID)
+}
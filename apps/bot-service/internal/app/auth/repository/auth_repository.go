@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/pg"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (r *authRepository) CreateAdmin(ctx context.Context, admin *entity.Admin) error {
+	query := `
+		INSERT INTO admins (id, email, name, password_hash, role, created_at, updated_at)
+		VALUES (:id, :email, :name, :password_hash, :role, :created_at, :updated_at)
+	`
+
+	_, err := r.db.NamedExecContext(
+		ctx,
+		query,
+		admin,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			pgErrors := []pg.PgError{
+				{
+					Code:           pg.UniqueViolation,
+					ConstraintName: "admins_email_key",
+					Err: errx.ErrAdminEmailExists.WithDetails(map[string]any{
+						"email": admin.Email,
+					}).WithLocation("authRepository.CreateAdmin"),
+				},
+			}
+
+			if customPgErr := pg.HandlePgError(pgErr, pgErrors); customPgErr != nil {
+				return customPgErr
+			}
+		}
+
+		return errx.ErrInternalServer.WithLocation("authRepository.CreateAdmin").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *authRepository) FindAdminByID(ctx context.Context, id uuid.UUID) (*entity.Admin, error) {
+	query := `
+		SELECT id, email, name, password_hash, role, last_login_at, created_at, updated_at
+		FROM admins
+		WHERE id = $1
+	`
+
+	var admin entity.Admin
+	err := r.db.GetContext(ctx, &admin, query, id)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrAdminNotFound.WithDetails(map[string]any{
+				"id": id,
+			}).WithLocation("authRepository.FindAdminByID")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("authRepository.FindAdminByID").WithError(err)
+	}
+
+	return &admin, nil
+}
+
+func (r *authRepository) FindAdminByEmail(ctx context.Context, email string) (*entity.Admin, error) {
+	query := `
+		SELECT id, email, name, password_hash, role, last_login_at, created_at, updated_at
+		FROM admins
+		WHERE email = $1
+	`
+
+	var admin entity.Admin
+	err := r.db.GetContext(ctx, &admin, query, email)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrAdminNotFound.WithDetails(map[string]any{
+				"email": email,
+			}).WithLocation("authRepository.FindAdminByEmail")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("authRepository.FindAdminByEmail").WithError(err)
+	}
+
+	return &admin, nil
+}
+
+func (r *authRepository) ListAdmins(ctx context.Context, filter *entity.GetAdminsFilter) ([]entity.Admin, int64, error) {
+	offset := min(max(filter.Offset, 0), 10000)
+	limit := min(max(filter.Limit, 10), 100)
+
+	var qb strings.Builder
+	var whereClauses strings.Builder
+	var args []any
+
+	qb.WriteString(`
+		SELECT id, email, name, password_hash, role, last_login_at, created_at, updated_at
+		FROM admins
+	`)
+
+	if filter.Role != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND role = $%d", len(args)+1))
+		args = append(args, *filter.Role)
+	}
+
+	if filter.Search != "" {
+		whereClauses.WriteString(fmt.Sprintf(" AND (email ILIKE $%d OR name ILIKE $%d)", len(args)+1, len(args)+1))
+		args = append(args, "%"+filter.Search+"%")
+	}
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM admins WHERE 1=1"+whereClauses.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("authRepository.ListAdmins.Count").WithError(err)
+	}
+
+	if whereClauses.Len() > 0 {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses.String())
+	}
+	qb.WriteString(" ORDER BY created_at DESC")
+	qb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2))
+
+	args = append(args, limit, offset)
+
+	var admins []entity.Admin
+	err = r.db.SelectContext(ctx, &admins, qb.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("authRepository.ListAdmins.Select").WithError(err)
+	}
+
+	if admins == nil {
+		admins = []entity.Admin{}
+	}
+
+	return admins, total, nil
+}
+
+func (r *authRepository) UpdateAdmin(ctx context.Context, admin *entity.Admin) error {
+	query := `
+		UPDATE admins
+		SET name = :name, password_hash = :password_hash, role = :role, updated_at = :updated_at
+		WHERE id = :id
+	`
+
+	result, err := r.db.NamedExecContext(
+		ctx,
+		query,
+		admin,
+	)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.UpdateAdmin").WithError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.UpdateAdmin.RowsAffected").WithError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errx.ErrAdminNotFound.WithDetails(map[string]any{
+			"id": admin.ID,
+		}).WithLocation("authRepository.UpdateAdmin")
+	}
+
+	return nil
+}
+
+func (r *authRepository) DeleteAdmin(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM admins WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.DeleteAdmin").WithError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.DeleteAdmin.RowsAffected").WithError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errx.ErrAdminNotFound.WithDetails(map[string]any{
+			"id": id,
+		}).WithLocation("authRepository.DeleteAdmin")
+	}
+
+	return nil
+}
+
+func (r *authRepository) UpdateLastLoginAt(ctx context.Context, id uuid.UUID, lastLoginAt time.Time) error {
+	query := `UPDATE admins SET last_login_at = $2 WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, lastLoginAt)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.UpdateLastLoginAt").WithError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.UpdateLastLoginAt.RowsAffected").WithError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errx.ErrAdminNotFound.WithDetails(map[string]any{
+			"id": id,
+		}).WithLocation("authRepository.UpdateLastLoginAt")
+	}
+
+	return nil
+}
+
+func (r *authRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error {
+	query := `
+		INSERT INTO admin_refresh_tokens (id, admin_id, token_hash, expires_at, created_at)
+		VALUES (:id, :admin_id, :token_hash, :expires_at, :created_at)
+	`
+
+	_, err := r.db.NamedExecContext(
+		ctx,
+		query,
+		token,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			pgErrors := []pg.PgError{
+				{
+					Code:           pg.ForeignKey,
+					ConstraintName: "fk_admin_refresh_token_admin",
+					Err: errx.ErrAdminNotFound.WithDetails(map[string]any{
+						"admin_id": token.AdminID,
+					}).WithLocation("authRepository.CreateRefreshToken"),
+				},
+			}
+
+			if customPgErr := pg.HandlePgError(pgErr, pgErrors); customPgErr != nil {
+				return customPgErr
+			}
+		}
+
+		return errx.ErrInternalServer.WithLocation("authRepository.CreateRefreshToken").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *authRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	query := `
+		SELECT id, admin_id, token_hash, expires_at, revoked_at, created_at
+		FROM admin_refresh_tokens
+		WHERE token_hash = $1
+	`
+
+	var token entity.RefreshToken
+	err := r.db.GetContext(ctx, &token, query, tokenHash)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrInvalidRefreshToken.WithLocation("authRepository.FindRefreshTokenByHash")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("authRepository.FindRefreshTokenByHash").WithError(err)
+	}
+
+	return &token, nil
+}
+
+// RevokeRefreshToken revokes a token that hasn't been revoked yet, so that a
+// token can only be exchanged once even under concurrent refreshes.
+func (r *authRepository) RevokeRefreshToken(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE admin_refresh_tokens SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := r.db.ExecContext(ctx, query, id, revokedAt)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.RevokeRefreshToken").WithError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.RevokeRefreshToken.RowsAffected").WithError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errx.ErrInvalidRefreshToken.WithDetails(map[string]any{
+			"id": id,
+		}).WithLocation("authRepository.RevokeRefreshToken")
+	}
+
+	return nil
+}
+
+// RevokeAdminRefreshTokens revokes every active refresh token of an admin,
+// signing them out once their current access token expires.
+func (r *authRepository) RevokeAdminRefreshTokens(ctx context.Context, adminID uuid.UUID, revokedAt time.Time) error {
+	query := `UPDATE admin_refresh_tokens SET revoked_at = $2 WHERE admin_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, adminID, revokedAt); err != nil {
+		return errx.ErrInternalServer.WithLocation("authRepository.RevokeAdminRefreshTokens").WithError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts (interfaces: AuthRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../internal/app/auth/repository/mock/mock_auth_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts AuthRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthRepository is a mock of AuthRepository interface.
+type MockAuthRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthRepositoryMockRecorder is the mock recorder for MockAuthRepository.
+type MockAuthRepositoryMockRecorder struct {
+	mock *MockAuthRepository
+}
+
+// NewMockAuthRepository creates a new mock instance.
+func NewMockAuthRepository(ctrl *gomock.Controller) *MockAuthRepository {
+	mock := &MockAuthRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthRepository) EXPECT() *MockAuthRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateAdmin mocks base method.
+func (m *MockAuthRepository) CreateAdmin(ctx context.Context, admin *entity.Admin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdmin", ctx, admin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAdmin indicates an expected call of CreateAdmin.
+func (mr *MockAuthRepositoryMockRecorder) CreateAdmin(ctx, admin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdmin", reflect.TypeOf((*MockAuthRepository)(nil).CreateAdmin), ctx, admin)
+}
+
+// CreateRefreshToken mocks base method.
+func (m *MockAuthRepository) CreateRefreshToken(ctx context.Context, token *entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRefreshToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRefreshToken indicates an expected call of CreateRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) CreateRefreshToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).CreateRefreshToken), ctx, token)
+}
+
+// DeleteAdmin mocks base method.
+func (m *MockAuthRepository) DeleteAdmin(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAdmin", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAdmin indicates an expected call of DeleteAdmin.
+func (mr *MockAuthRepositoryMockRecorder) DeleteAdmin(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAdmin", reflect.TypeOf((*MockAuthRepository)(nil).DeleteAdmin), ctx, id)
+}
+
+// FindAdminByEmail mocks base method.
+func (m *MockAuthRepository) FindAdminByEmail(ctx context.Context, email string) (*entity.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAdminByEmail", ctx, email)
+	ret0, _ := ret[0].(*entity.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAdminByEmail indicates an expected call of FindAdminByEmail.
+func (mr *MockAuthRepositoryMockRecorder) FindAdminByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdminByEmail", reflect.TypeOf((*MockAuthRepository)(nil).FindAdminByEmail), ctx, email)
+}
+
+// FindAdminByID mocks base method.
+func (m *MockAuthRepository) FindAdminByID(ctx context.Context, id uuid.UUID) (*entity.Admin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAdminByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Admin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAdminByID indicates an expected call of FindAdminByID.
+func (mr *MockAuthRepositoryMockRecorder) FindAdminByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAdminByID", reflect.TypeOf((*MockAuthRepository)(nil).FindAdminByID), ctx, id)
+}
+
+// FindRefreshTokenByHash mocks base method.
+func (m *MockAuthRepository) FindRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRefreshTokenByHash", ctx, tokenHash)
+	ret0, _ := ret[0].(*entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRefreshTokenByHash indicates an expected call of FindRefreshTokenByHash.
+func (mr *MockAuthRepositoryMockRecorder) FindRefreshTokenByHash(ctx, tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRefreshTokenByHash", reflect.TypeOf((*MockAuthRepository)(nil).FindRefreshTokenByHash), ctx, tokenHash)
+}
+
+// ListAdmins mocks base method.
+func (m *MockAuthRepository) ListAdmins(ctx context.Context, filter *entity.GetAdminsFilter) ([]entity.Admin, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdmins", ctx, filter)
+	ret0, _ := ret[0].([]entity.Admin)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListAdmins indicates an expected call of ListAdmins.
+func (mr *MockAuthRepositoryMockRecorder) ListAdmins(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdmins", reflect.TypeOf((*MockAuthRepository)(nil).ListAdmins), ctx, filter)
+}
+
+// RevokeAdminRefreshTokens mocks base method.
+func (m *MockAuthRepository) RevokeAdminRefreshTokens(ctx context.Context, adminID uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAdminRefreshTokens", ctx, adminID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAdminRefreshTokens indicates an expected call of RevokeAdminRefreshTokens.
+func (mr *MockAuthRepositoryMockRecorder) RevokeAdminRefreshTokens(ctx, adminID, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAdminRefreshTokens", reflect.TypeOf((*MockAuthRepository)(nil).RevokeAdminRefreshTokens), ctx, adminID, revokedAt)
+}
+
+// RevokeRefreshToken mocks base method.
+func (m *MockAuthRepository) RevokeRefreshToken(ctx context.Context, id uuid.UUID, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRefreshToken", ctx, id, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRefreshToken indicates an expected call of RevokeRefreshToken.
+func (mr *MockAuthRepositoryMockRecorder) RevokeRefreshToken(ctx, id, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRefreshToken", reflect.TypeOf((*MockAuthRepository)(nil).RevokeRefreshToken), ctx, id, revokedAt)
+}
+
+// UpdateAdmin mocks base method.
+func (m *MockAuthRepository) UpdateAdmin(ctx context.Context, admin *entity.Admin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAdmin", ctx, admin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAdmin indicates an expected call of UpdateAdmin.
+func (mr *MockAuthRepositoryMockRecorder) UpdateAdmin(ctx, admin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAdmin", reflect.TypeOf((*MockAuthRepository)(nil).UpdateAdmin), ctx, admin)
+}
+
+// UpdateLastLoginAt mocks base method.
+func (m *MockAuthRepository) UpdateLastLoginAt(ctx context.Context, id uuid.UUID, lastLoginAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastLoginAt", ctx, id, lastLoginAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastLoginAt indicates an expected call of UpdateLastLoginAt.
+func (mr *MockAuthRepositoryMockRecorder) UpdateLastLoginAt(ctx, id, lastLoginAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastLoginAt", reflect.TypeOf((*MockAuthRepository)(nil).UpdateLastLoginAt), ctx, id, lastLoginAt)
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/jmoiron/sqlx"
+)
+
+type authRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuthRepository(db *sqlx.DB) contracts.AuthRepository {
+	return &authRepository{db: db}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+)
+
+func (s *AuthService) GetAdminByID(ctx context.Context, param *dto.GetAdminByIDParam) (*dto.GetAdminByIDResponse, error) {
+	if err := s.validator.Validate(param); err != nil {
+		return nil, err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return nil, errx.ErrAdminNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("AuthService.GetAdminByID").WithError(err)
+	}
+
+	admin, err := s.authRepo.FindAdminByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.GetAdminByIDResponse{
+		Admin: dto.ToAdminResponse(admin),
+	}
+
+	return res, nil
+}
+
+func (s *AuthService) ListAdmins(ctx context.Context, query *dto.GetAdminsQuery) (*dto.GetAdminsResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	limit := min(max(query.Limit, 10), 100)
+	page := max(query.Page, 1)
+
+	filter := entity.GetAdminsFilter{
+		Offset: (page - 1) * limit,
+		Limit:  limit,
+		Role:   query.Role,
+		Search: query.Search,
+	}
+
+	admins, total, err := s.authRepo.ListAdmins(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	adminResponses := make([]dto.AdminResponse, 0, len(admins))
+	for i := range admins {
+		adminResponses = append(adminResponses, dto.ToAdminResponse(&admins[i]))
+	}
+
+	res := &dto.GetAdminsResponse{
+		Admins: adminResponses,
+	}
+
+	res.Meta.Pagination = dto.NewPaginationResponse(total, page, limit)
+
+	return res, nil
+}
+
+// UpdateAdmin updates an admin on behalf of actor. Admins can't change their
+// own role, so there's always a superadmin left. Changing an admin's role or
+// password revokes their refresh tokens, so they have to sign in again once
+// their current access token expires.
+func (s *AuthService) UpdateAdmin(ctx context.Context, actor entity.AuditActor, param *dto.UpdateAdminParam, req *dto.UpdateAdminRequest) error {
+	if err := s.validator.Validate(param); err != nil {
+		return err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		return err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return errx.ErrAdminNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("AuthService.UpdateAdmin").WithError(err)
+	}
+
+	admin, err := s.authRepo.FindAdminByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	before := dto.ToAdminResponse(admin)
+	revokeTokens := false
+
+	if req.Name != nil {
+		admin.Name = *req.Name
+	}
+	if req.Role != nil && *req.Role != admin.Role {
+		if isActor(actor, admin.ID.String()) {
+			return errx.ErrCannotModifySelf.WithDetails(map[string]any{
+				"id":   admin.ID,
+				"role": *req.Role,
+			}).WithLocation("AuthService.UpdateAdmin")
+		}
+
+		admin.Role = *req.Role
+		revokeTokens = true
+	}
+	if req.Password != nil {
+		passwordHash, err := s.bcryptPkg.Hash(*req.Password)
+		if err != nil {
+			return errx.ErrInternalServer.WithLocation("AuthService.UpdateAdmin").WithError(err)
+		}
+
+		admin.PasswordHash = passwordHash
+		revokeTokens = true
+	}
+
+	now := time.Now().UTC()
+	admin.UpdatedAt = now
+
+	if err := s.authRepo.UpdateAdmin(ctx, admin); err != nil {
+		return err
+	}
+
+	if revokeTokens {
+		if err := s.authRepo.RevokeAdminRefreshTokens(ctx, admin.ID, now); err != nil {
+			return err
+		}
+	}
+
+	// The password hash isn't part of the snapshot, so a new password only
+	// shows up in the metadata
+	targetID := admin.ID.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionAdminUpdate,
+		TargetType: entity.AuditTargetAdmin,
+		TargetID:   &targetID,
+		Before:     before,
+		After:      dto.ToAdminResponse(admin),
+		Metadata: map[string]any{
+			"passwordChanged": req.Password != nil,
+		},
+	})
+
+	return nil
+}
+
+func (s *AuthService) DeleteAdmin(ctx context.Context, actor entity.AuditActor, param *dto.DeleteAdminParam) error {
+	if err := s.validator.Validate(param); err != nil {
+		return err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return errx.ErrAdminNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("AuthService.DeleteAdmin").WithError(err)
+	}
+
+	if isActor(actor, id.String()) {
+		return errx.ErrCannotModifySelf.WithDetails(map[string]any{
+			"id": id,
+		}).WithLocation("AuthService.DeleteAdmin")
+	}
+
+	// Keep a snapshot so the audit log shows who was removed
+	admin, err := s.authRepo.FindAdminByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.authRepo.DeleteAdmin(ctx, id); err != nil {
+		return err
+	}
+
+	targetID := id.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionAdminDelete,
+		TargetType: entity.AuditTargetAdmin,
+		TargetID:   &targetID,
+		Before:     dto.ToAdminResponse(admin),
+	})
+
+	return nil
+}
+
+// isActor reports whether actor is the admin with adminID
+func isActor(actor entity.AuditActor, adminID string) bool {
+	return actor.Type == entity.AuditActorAdmin && actor.ID != nil && *actor.ID == adminID
+}
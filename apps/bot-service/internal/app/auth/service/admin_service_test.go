@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
+	authRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/auth/repository/mock"
+	mockBcrypt "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/bcrypt/mock"
+	mockJwt "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/jwt/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthService_ListAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := authRepoMock.NewMockAuthRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockJwt := mockJwt.NewMockCustomJwtInterface(ctrl)
+	mockBcrypt := mockBcrypt.NewMockCustomBcryptInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAuthService(mockAuthRepo, mockValidator, mockUUID, mockJwt, mockBcrypt, time.Hour, mockAudit)
+	ctx := context.Background()
+
+	viewerRole := entity.AdminRoleViewer
+	testAdmins := []entity.Admin{
+		{
+			ID:    uuid.New(),
+			Email: "viewer@example.com",
+			Name:  "Viewer",
+			Role:  entity.AdminRoleViewer,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		query     *dto.GetAdminsQuery
+		setup     func()
+		wantErr   bool
+		wantCount int
+	}{
+		{
+			name: "success - filtered by role",
+			query: &dto.GetAdminsQuery{
+				Page:  2,
+				Limit: 10,
+				Role:  &viewerRole,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().ListAdmins(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetAdminsFilter) ([]entity.Admin, int64, error) {
+					assert.Equal(t, 10, filter.Offset)
+					assert.Equal(t, 10, filter.Limit)
+					assert.Equal(t, &viewerRole, filter.Role)
+					return testAdmins, 11, nil
+				})
+			},
+			wantErr:   false,
+			wantCount: 1,
+		},
+		{
+			name: "validation error - unknown role",
+			query: &dto.GetAdminsQuery{
+				Role: func() *string { r := "owner"; return &r }(),
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"query.role": validator.ValidationError{
+						Message: "role must be one of [superadmin hc_admin viewer]",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetAdminsQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().ListAdmins(ctx, gomock.Any()).Return(nil, int64(0), errx.ErrInternalServer)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.ListAdmins(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Admins, tt.wantCount)
+				assert.Equal(t, entity.AdminRoleViewer, result.Admins[0].Role)
+				assert.Equal(t, int64(11), result.Meta.Pagination.TotalData)
+			}
+		})
+	}
+}
+
+func TestAuthService_UpdateAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := authRepoMock.NewMockAuthRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockJwt := mockJwt.NewMockCustomJwtInterface(ctrl)
+	mockBcrypt := mockBcrypt.NewMockCustomBcryptInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAuthService(mockAuthRepo, mockValidator, mockUUID, mockJwt, mockBcrypt, time.Hour, mockAudit)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	testID := uuid.New()
+	newName := "New Name"
+	newPassword := "new-secret-password"
+	superadminRole := entity.AdminRoleSuperadmin
+	hcAdminRole := entity.AdminRoleHCAdmin
+
+	testAdmin := func() *entity.Admin {
+		return &entity.Admin{
+			ID:           testID,
+			Email:        "admin@example.com",
+			Name:         "Admin",
+			PasswordHash: "hashed-password",
+			Role:         entity.AdminRoleViewer,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		actorID string
+		param   *dto.UpdateAdminParam
+		req     *dto.UpdateAdminRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name:    "success - name only keeps tokens",
+			actorID: actorID.String(),
+			param:   &dto.UpdateAdminParam{ID: testID.String()},
+			req:     &dto.UpdateAdminRequest{Name: &newName},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testID).Return(testAdmin(), nil)
+				mockAuthRepo.EXPECT().UpdateAdmin(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, admin *entity.Admin) error {
+					assert.Equal(t, newName, admin.Name)
+					assert.Equal(t, entity.AdminRoleViewer, admin.Role)
+					return nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any())
+			},
+			wantErr: false,
+		},
+		{
+			name:    "success - role change revokes refresh tokens",
+			actorID: actorID.String(),
+			param:   &dto.UpdateAdminParam{ID: testID.String()},
+			req:     &dto.UpdateAdminRequest{Role: &hcAdminRole},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testID).Return(testAdmin(), nil)
+				mockAuthRepo.EXPECT().UpdateAdmin(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, admin *entity.Admin) error {
+					assert.Equal(t, entity.AdminRoleHCAdmin, admin.Role)
+					return nil
+				})
+				mockAuthRepo.EXPECT().RevokeAdminRefreshTokens(ctx, testID, gomock.Any()).Return(nil)
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionAdminUpdate, req.Action)
+					assert.Equal(t, entity.AdminRoleViewer, req.Before.(dto.AdminResponse).Role)
+					assert.Equal(t, entity.AdminRoleHCAdmin, req.After.(dto.AdminResponse).Role)
+					assert.Equal(t, false, req.Metadata["passwordChanged"])
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name:    "success - password change revokes refresh tokens",
+			actorID: testID.String(),
+			param:   &dto.UpdateAdminParam{ID: testID.String()},
+			req:     &dto.UpdateAdminRequest{Password: &newPassword},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testID).Return(testAdmin(), nil)
+				mockBcrypt.EXPECT().Hash(newPassword).Return("new-hashed-password", nil)
+				mockAuthRepo.EXPECT().UpdateAdmin(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, admin *entity.Admin) error {
+					assert.Equal(t, "new-hashed-password", admin.PasswordHash)
+					return nil
+				})
+				mockAuthRepo.EXPECT().RevokeAdminRefreshTokens(ctx, testID, gomock.Any()).Return(nil)
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, true, req.Metadata["passwordChanged"])
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name:    "cannot change own role",
+			actorID: testID.String(),
+			param:   &dto.UpdateAdminParam{ID: testID.String()},
+			req:     &dto.UpdateAdminRequest{Role: &superadminRole},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testID).Return(testAdmin(), nil)
+			},
+			wantErr: true,
+			errType: errx.ErrCannotModifySelf,
+		},
+		{
+			name:    "admin not found",
+			actorID: actorID.String(),
+			param:   &dto.UpdateAdminParam{ID: testID.String()},
+			req:     &dto.UpdateAdminRequest{Name: &newName},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testID).Return(nil, errx.ErrAdminNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrAdminNotFound,
+		},
+		{
+			name:    "invalid uuid",
+			actorID: actorID.String(),
+			param:   &dto.UpdateAdminParam{ID: "invalid"},
+			req:     &dto.UpdateAdminRequest{Name: &newName},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse("invalid").Return(uuid.Nil, errors.New("invalid UUID"))
+			},
+			wantErr: true,
+			errType: errx.ErrAdminNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := service.UpdateAdmin(ctx, entity.AuditActor{Type: entity.AuditActorAdmin, ID: &tt.actorID}, tt.param, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_DeleteAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := authRepoMock.NewMockAuthRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockJwt := mockJwt.NewMockCustomJwtInterface(ctrl)
+	mockBcrypt := mockBcrypt.NewMockCustomBcryptInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAuthService(mockAuthRepo, mockValidator, mockUUID, mockJwt, mockBcrypt, time.Hour, mockAudit)
+	ctx := context.Background()
+
+	actorID := uuid.New()
+	testID := uuid.New()
+
+	tests := []struct {
+		name    string
+		actorID string
+		param   *dto.DeleteAdminParam
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name:    "success",
+			actorID: actorID.String(),
+			param:   &dto.DeleteAdminParam{ID: testID.String()},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testID).Return(&entity.Admin{ID: testID, Role: entity.AdminRoleViewer}, nil)
+				mockAuthRepo.EXPECT().DeleteAdmin(ctx, testID).Return(nil)
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionAdminDelete, req.Action)
+					assert.Equal(t, testID.String(), *req.TargetID)
+					assert.Nil(t, req.After)
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name:    "cannot delete self",
+			actorID: testID.String(),
+			param:   &dto.DeleteAdminParam{ID: testID.String()},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrCannotModifySelf,
+		},
+		{
+			name:    "admin not found",
+			actorID: actorID.String(),
+			param:   &dto.DeleteAdminParam{ID: testID.String()},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testID).Return(nil, errx.ErrAdminNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrAdminNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := service.DeleteAdmin(ctx, entity.AuditActor{Type: entity.AuditActorAdmin, ID: &tt.actorID}, tt.param)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
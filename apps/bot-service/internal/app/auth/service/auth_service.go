@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+)
+
+// A bcrypt hash at the same cost as real ones, checked when the email is
+// unknown so the response takes as long as for a wrong password
+const dummyPasswordHash = "$2a$12$7eAe6Mw/JUWkBJdGK0t6POJ18xuMOGwip8UBQoqk.AnLOuv7G6S/i"
+
+func (s *AuthService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	admin, err := s.authRepo.FindAdminByEmail(ctx, normalizeEmail(req.Email))
+	if err != nil {
+		if errors.Is(err, errx.ErrAdminNotFound) {
+			s.bcryptPkg.Compare(dummyPasswordHash, req.Password)
+			return nil, errx.ErrInvalidCredentials.WithLocation("AuthService.Login").WithError(err)
+		}
+		return nil, err
+	}
+
+	if !s.bcryptPkg.Compare(admin.PasswordHash, req.Password) {
+		return nil, errx.ErrInvalidCredentials.WithDetails(map[string]any{
+			"admin_id": admin.ID,
+		}).WithLocation("AuthService.Login")
+	}
+
+	now := time.Now().UTC()
+	if err := s.authRepo.UpdateLastLoginAt(ctx, admin.ID, now); err != nil {
+		return nil, err
+	}
+	admin.LastLoginAt = &now
+
+	tokens, err := s.issueTokens(ctx, admin, now)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.LoginResponse{
+		AuthTokensResponse: *tokens,
+		Admin:              dto.ToAdminResponse(admin),
+	}
+
+	return res, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. Refresh tokens are
+// single use: the one presented is revoked.
+func (s *AuthService) Refresh(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.RefreshTokenResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	token, err := s.authRepo.FindRefreshTokenByHash(ctx, hashRefreshToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+		return nil, errx.ErrInvalidRefreshToken.WithDetails(map[string]any{
+			"id": token.ID,
+		}).WithLocation("AuthService.Refresh")
+	}
+
+	if err := s.authRepo.RevokeRefreshToken(ctx, token.ID, now); err != nil {
+		return nil, err
+	}
+
+	// Reload the admin so the new access token carries their current role
+	admin, err := s.authRepo.FindAdminByID(ctx, token.AdminID)
+	if err != nil {
+		if errors.Is(err, errx.ErrAdminNotFound) {
+			return nil, errx.ErrInvalidRefreshToken.WithLocation("AuthService.Refresh").WithError(err)
+		}
+		return nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, admin, now)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.RefreshTokenResponse{
+		AuthTokensResponse: *tokens,
+	}
+
+	return res, nil
+}
+
+func (s *AuthService) GetMe(ctx context.Context, adminID string) (*dto.GetMeResponse, error) {
+	id, err := s.uuidPkg.Parse(adminID)
+	if err != nil {
+		return nil, errx.ErrUnauthorized.WithDetails(map[string]any{
+			"admin_id": adminID,
+		}).WithLocation("AuthService.GetMe").WithError(err)
+	}
+
+	admin, err := s.authRepo.FindAdminByID(ctx, id)
+	if err != nil {
+		// The token outlived its admin
+		if errors.Is(err, errx.ErrAdminNotFound) {
+			return nil, errx.ErrUnauthorized.WithLocation("AuthService.GetMe").WithError(err)
+		}
+		return nil, err
+	}
+
+	res := &dto.GetMeResponse{
+		Admin: dto.ToAdminResponse(admin),
+	}
+
+	return res, nil
+}
+
+// Authorize looks up the admin a token was issued to and checks their current
+// role, so a deleted or demoted admin loses access before the token expires
+func (s *AuthService) Authorize(ctx context.Context, adminID string, roles []string) (*entity.Admin, error) {
+	id, err := s.uuidPkg.Parse(adminID)
+	if err != nil {
+		return nil, errx.ErrUnauthorized.WithDetails(map[string]any{
+			"admin_id": adminID,
+		}).WithLocation("AuthService.Authorize").WithError(err)
+	}
+
+	admin, err := s.authRepo.FindAdminByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, errx.ErrAdminNotFound) {
+			return nil, errx.ErrUnauthorized.WithLocation("AuthService.Authorize").WithError(err)
+		}
+		return nil, err
+	}
+
+	if !slices.Contains(roles, admin.Role) {
+		return nil, errx.ErrForbidden.WithDetails(map[string]any{
+			"role":          admin.Role,
+			"allowed_roles": roles,
+		}).WithLocation("AuthService.Authorize")
+	}
+
+	return admin, nil
+}
+
+func (s *AuthService) CreateAdmin(ctx context.Context, actor entity.AuditActor, req *dto.CreateAdminRequest) (*dto.CreateAdminResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := s.bcryptPkg.Hash(req.Password)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("AuthService.CreateAdmin").WithError(err)
+	}
+
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("AuthService.CreateAdmin").WithError(err)
+	}
+
+	now := time.Now().UTC()
+	admin := &entity.Admin{
+		ID:           id,
+		Email:        normalizeEmail(req.Email),
+		Name:         req.Name,
+		PasswordHash: passwordHash,
+		Role:         req.Role,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := s.authRepo.CreateAdmin(ctx, admin); err != nil {
+		return nil, err
+	}
+
+	targetID := id.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionAdminCreate,
+		TargetType: entity.AuditTargetAdmin,
+		TargetID:   &targetID,
+		After:      dto.ToAdminResponse(admin),
+	})
+
+	res := &dto.CreateAdminResponse{
+		ID: id.String(),
+	}
+
+	return res, nil
+}
+
+func (s *AuthService) issueTokens(ctx context.Context, admin *entity.Admin, now time.Time) (*dto.AuthTokensResponse, error) {
+	accessToken, err := s.jwtPkg.Create(admin.ID.String(), admin.Role)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("AuthService.issueTokens").WithError(err)
+	}
+
+	refreshToken, err := generateRefreshToken()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("AuthService.issueTokens").WithError(err)
+	}
+
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("AuthService.issueTokens").WithError(err)
+	}
+
+	expiresAt := now.Add(s.refreshTokenTTL)
+	token := &entity.RefreshToken{
+		ID:        id,
+		AdminID:   admin.ID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+
+	if err := s.authRepo.CreateRefreshToken(ctx, token); err != nil {
+		return nil, err
+	}
+
+	res := &dto.AuthTokensResponse{
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: expiresAt.Format(time.RFC3339),
+		TokenType:             "Bearer",
+	}
+
+	return res, nil
+}
+
+func generateRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
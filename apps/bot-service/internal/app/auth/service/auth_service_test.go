@@ -0,0 +1,614 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
+	authRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/auth/repository/mock"
+	mockBcrypt "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/bcrypt/mock"
+	mockJwt "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/jwt/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestDummyPasswordHash(t *testing.T) {
+	// The same cost as real hashes, or the unknown email path would be faster
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	assert.NoError(t, err)
+	assert.Equal(t, 12, cost)
+}
+
+func TestAuthService_Login(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := authRepoMock.NewMockAuthRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockJwt := mockJwt.NewMockCustomJwtInterface(ctrl)
+	mockBcrypt := mockBcrypt.NewMockCustomBcryptInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAuthService(mockAuthRepo, mockValidator, mockUUID, mockJwt, mockBcrypt, time.Hour, mockAudit)
+	ctx := context.Background()
+
+	testAdminID := uuid.New()
+	testTokenID := uuid.New()
+	testAdmin := &entity.Admin{
+		ID:           testAdminID,
+		Email:        "admin@example.com",
+		Name:         "Admin",
+		PasswordHash: "hashed-password",
+		Role:         entity.AdminRoleHCAdmin,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	tests := []struct {
+		name    string
+		req     *dto.LoginRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success",
+			req: &dto.LoginRequest{
+				Email:    " Admin@Example.com ",
+				Password: "secret-password",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindAdminByEmail(ctx, "admin@example.com").Return(testAdmin, nil)
+				mockBcrypt.EXPECT().Compare("hashed-password", "secret-password").Return(true)
+				mockAuthRepo.EXPECT().UpdateLastLoginAt(ctx, testAdminID, gomock.Any()).Return(nil)
+				mockJwt.EXPECT().Create(testAdminID.String(), entity.AdminRoleHCAdmin).Return("access-token", nil)
+				mockUUID.EXPECT().NewV7().Return(testTokenID, nil)
+				mockAuthRepo.EXPECT().CreateRefreshToken(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, token *entity.RefreshToken) error {
+					assert.Equal(t, testTokenID, token.ID)
+					assert.Equal(t, testAdminID, token.AdminID)
+					assert.Len(t, token.TokenHash, 64)
+					assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute)
+					assert.Nil(t, token.RevokedAt)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "validation error",
+			req: &dto.LoginRequest{
+				Email: "not-an-email",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.email": validator.ValidationError{
+						Message: "email must be a valid email address",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown email",
+			req: &dto.LoginRequest{
+				Email:    "nobody@example.com",
+				Password: "secret-password",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindAdminByEmail(ctx, "nobody@example.com").Return(nil, errx.ErrAdminNotFound)
+				// Checked anyway, so the email can't be told apart by timing
+				mockBcrypt.EXPECT().Compare(dummyPasswordHash, "secret-password").Return(false)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidCredentials,
+		},
+		{
+			name: "wrong password",
+			req: &dto.LoginRequest{
+				Email:    "admin@example.com",
+				Password: "wrong-password",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindAdminByEmail(ctx, "admin@example.com").Return(testAdmin, nil)
+				mockBcrypt.EXPECT().Compare("hashed-password", "wrong-password").Return(false)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidCredentials,
+		},
+		{
+			name: "token signing error",
+			req: &dto.LoginRequest{
+				Email:    "admin@example.com",
+				Password: "secret-password",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindAdminByEmail(ctx, "admin@example.com").Return(testAdmin, nil)
+				mockBcrypt.EXPECT().Compare("hashed-password", "secret-password").Return(true)
+				mockAuthRepo.EXPECT().UpdateLastLoginAt(ctx, testAdminID, gomock.Any()).Return(nil)
+				mockJwt.EXPECT().Create(testAdminID.String(), entity.AdminRoleHCAdmin).Return("", errors.New("signing failed"))
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.Login(ctx, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "access-token", result.AccessToken)
+				assert.NotEmpty(t, result.RefreshToken)
+				assert.Equal(t, "Bearer", result.TokenType)
+				assert.Equal(t, testAdminID.String(), result.Admin.ID)
+				assert.NotNil(t, result.Admin.LastLoginAt)
+			}
+		})
+	}
+}
+
+func TestAuthService_Refresh(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := authRepoMock.NewMockAuthRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockJwt := mockJwt.NewMockCustomJwtInterface(ctrl)
+	mockBcrypt := mockBcrypt.NewMockCustomBcryptInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAuthService(mockAuthRepo, mockValidator, mockUUID, mockJwt, mockBcrypt, time.Hour, mockAudit)
+	ctx := context.Background()
+
+	testAdminID := uuid.New()
+	testTokenID := uuid.New()
+	testNewTokenID := uuid.New()
+	refreshToken := "refresh-token"
+	revokedAt := time.Now().Add(-time.Minute)
+
+	validToken := &entity.RefreshToken{
+		ID:        testTokenID,
+		AdminID:   testAdminID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+
+	tests := []struct {
+		name    string
+		req     *dto.RefreshTokenRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success - rotates the refresh token",
+			req: &dto.RefreshTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindRefreshTokenByHash(ctx, hashRefreshToken(refreshToken)).Return(validToken, nil)
+				mockAuthRepo.EXPECT().RevokeRefreshToken(ctx, testTokenID, gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testAdminID).Return(&entity.Admin{
+					ID:   testAdminID,
+					Role: entity.AdminRoleViewer,
+				}, nil)
+				mockJwt.EXPECT().Create(testAdminID.String(), entity.AdminRoleViewer).Return("new-access-token", nil)
+				mockUUID.EXPECT().NewV7().Return(testNewTokenID, nil)
+				mockAuthRepo.EXPECT().CreateRefreshToken(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, token *entity.RefreshToken) error {
+					assert.Equal(t, testNewTokenID, token.ID)
+					assert.Equal(t, testAdminID, token.AdminID)
+					assert.NotEqual(t, hashRefreshToken(refreshToken), token.TokenHash)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "validation error",
+			req:  &dto.RefreshTokenRequest{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.refreshToken": validator.ValidationError{
+						Message: "refreshToken is a required field",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown token",
+			req: &dto.RefreshTokenRequest{
+				RefreshToken: "unknown",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindRefreshTokenByHash(ctx, hashRefreshToken("unknown")).Return(nil, errx.ErrInvalidRefreshToken)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidRefreshToken,
+		},
+		{
+			name: "revoked token",
+			req: &dto.RefreshTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindRefreshTokenByHash(ctx, hashRefreshToken(refreshToken)).Return(&entity.RefreshToken{
+					ID:        testTokenID,
+					AdminID:   testAdminID,
+					ExpiresAt: time.Now().Add(time.Hour),
+					RevokedAt: &revokedAt,
+				}, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidRefreshToken,
+		},
+		{
+			name: "expired token",
+			req: &dto.RefreshTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindRefreshTokenByHash(ctx, hashRefreshToken(refreshToken)).Return(&entity.RefreshToken{
+					ID:        testTokenID,
+					AdminID:   testAdminID,
+					ExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidRefreshToken,
+		},
+		{
+			name: "token used concurrently",
+			req: &dto.RefreshTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindRefreshTokenByHash(ctx, hashRefreshToken(refreshToken)).Return(validToken, nil)
+				mockAuthRepo.EXPECT().RevokeRefreshToken(ctx, testTokenID, gomock.Any()).Return(errx.ErrInvalidRefreshToken)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidRefreshToken,
+		},
+		{
+			name: "admin deleted",
+			req: &dto.RefreshTokenRequest{
+				RefreshToken: refreshToken,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindRefreshTokenByHash(ctx, hashRefreshToken(refreshToken)).Return(validToken, nil)
+				mockAuthRepo.EXPECT().RevokeRefreshToken(ctx, testTokenID, gomock.Any()).Return(nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testAdminID).Return(nil, errx.ErrAdminNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidRefreshToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.Refresh(ctx, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "new-access-token", result.AccessToken)
+				assert.NotEmpty(t, result.RefreshToken)
+				assert.NotEqual(t, refreshToken, result.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_GetMe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := authRepoMock.NewMockAuthRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockJwt := mockJwt.NewMockCustomJwtInterface(ctrl)
+	mockBcrypt := mockBcrypt.NewMockCustomBcryptInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAuthService(mockAuthRepo, mockValidator, mockUUID, mockJwt, mockBcrypt, time.Hour, mockAudit)
+	ctx := context.Background()
+
+	testAdminID := uuid.New()
+
+	tests := []struct {
+		name    string
+		adminID string
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name:    "success",
+			adminID: testAdminID.String(),
+			setup: func() {
+				mockUUID.EXPECT().Parse(testAdminID.String()).Return(testAdminID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testAdminID).Return(&entity.Admin{
+					ID:           testAdminID,
+					Email:        "admin@example.com",
+					Name:         "Admin",
+					PasswordHash: "hashed-password",
+					CreatedAt:    time.Now(),
+					UpdatedAt:    time.Now(),
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "invalid subject",
+			adminID: "",
+			setup: func() {
+				mockUUID.EXPECT().Parse("").Return(uuid.Nil, errors.New("invalid UUID length: 0"))
+			},
+			wantErr: true,
+			errType: errx.ErrUnauthorized,
+		},
+		{
+			name:    "admin deleted",
+			adminID: testAdminID.String(),
+			setup: func() {
+				mockUUID.EXPECT().Parse(testAdminID.String()).Return(testAdminID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testAdminID).Return(nil, errx.ErrAdminNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.GetMe(ctx, tt.adminID)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testAdminID.String(), result.Admin.ID)
+				assert.Equal(t, "admin@example.com", result.Admin.Email)
+			}
+		})
+	}
+}
+
+func TestAuthService_CreateAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := authRepoMock.NewMockAuthRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockJwt := mockJwt.NewMockCustomJwtInterface(ctrl)
+	mockBcrypt := mockBcrypt.NewMockCustomBcryptInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAuthService(mockAuthRepo, mockValidator, mockUUID, mockJwt, mockBcrypt, time.Hour, mockAudit)
+	ctx := context.Background()
+
+	testID := uuid.New()
+
+	tests := []struct {
+		name    string
+		req     *dto.CreateAdminRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success",
+			req: &dto.CreateAdminRequest{
+				Email:    "Admin@Example.com",
+				Name:     "Admin",
+				Password: "secret-password",
+				Role:     entity.AdminRoleViewer,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockBcrypt.EXPECT().Hash("secret-password").Return("hashed-password", nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockAuthRepo.EXPECT().CreateAdmin(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, admin *entity.Admin) error {
+					assert.Equal(t, testID, admin.ID)
+					assert.Equal(t, "admin@example.com", admin.Email)
+					assert.Equal(t, "Admin", admin.Name)
+					assert.Equal(t, "hashed-password", admin.PasswordHash)
+					assert.Equal(t, entity.AdminRoleViewer, admin.Role)
+					return nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionAdminCreate, req.Action)
+					assert.Equal(t, entity.AuditTargetAdmin, req.TargetType)
+					assert.Equal(t, testID.String(), *req.TargetID)
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "validation error - short password",
+			req: &dto.CreateAdminRequest{
+				Email:    "admin@example.com",
+				Name:     "Admin",
+				Password: "short",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.password": validator.ValidationError{
+						Message: "password must be at least 8 characters in length",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "email already exists",
+			req: &dto.CreateAdminRequest{
+				Email:    "admin@example.com",
+				Name:     "Admin",
+				Password: "secret-password",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockBcrypt.EXPECT().Hash("secret-password").Return("hashed-password", nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockAuthRepo.EXPECT().CreateAdmin(ctx, gomock.Any()).Return(errx.ErrAdminEmailExists)
+			},
+			wantErr: true,
+			errType: errx.ErrAdminEmailExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.CreateAdmin(ctx, entity.AuditActor{Type: entity.AuditActorSystem}, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testID.String(), result.ID)
+			}
+		})
+	}
+}
+
+func TestAuthService_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthRepo := authRepoMock.NewMockAuthRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockJwt := mockJwt.NewMockCustomJwtInterface(ctrl)
+	mockBcrypt := mockBcrypt.NewMockCustomBcryptInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewAuthService(mockAuthRepo, mockValidator, mockUUID, mockJwt, mockBcrypt, time.Hour, mockAudit)
+	ctx := context.Background()
+
+	testAdminID := uuid.New()
+	roles := []string{entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin}
+
+	tests := []struct {
+		name    string
+		adminID string
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name:    "success - current role allowed",
+			adminID: testAdminID.String(),
+			setup: func() {
+				mockUUID.EXPECT().Parse(testAdminID.String()).Return(testAdminID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testAdminID).Return(&entity.Admin{ID: testAdminID, Role: entity.AdminRoleHCAdmin}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:    "demoted since the token was issued",
+			adminID: testAdminID.String(),
+			setup: func() {
+				mockUUID.EXPECT().Parse(testAdminID.String()).Return(testAdminID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testAdminID).Return(&entity.Admin{ID: testAdminID, Role: entity.AdminRoleViewer}, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrForbidden,
+		},
+		{
+			name:    "deleted since the token was issued",
+			adminID: testAdminID.String(),
+			setup: func() {
+				mockUUID.EXPECT().Parse(testAdminID.String()).Return(testAdminID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testAdminID).Return(nil, errx.ErrAdminNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrUnauthorized,
+		},
+		{
+			name:    "invalid subject",
+			adminID: "",
+			setup: func() {
+				mockUUID.EXPECT().Parse("").Return(uuid.Nil, errors.New("invalid UUID length: 0"))
+			},
+			wantErr: true,
+			errType: errx.ErrUnauthorized,
+		},
+		{
+			name:    "repository error",
+			adminID: testAdminID.String(),
+			setup: func() {
+				mockUUID.EXPECT().Parse(testAdminID.String()).Return(testAdminID, nil)
+				mockAuthRepo.EXPECT().FindAdminByID(ctx, testAdminID).Return(nil, errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.Authorize(ctx, tt.adminID, roles)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, entity.AdminRoleHCAdmin, result.Role)
+			}
+		})
+	}
+}
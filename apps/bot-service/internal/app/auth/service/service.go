@@ -0,0 +1,40 @@
+package service
+
+import (
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/bcrypt"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/jwt"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+)
+
+// Used when JWT_REFRESH_EXP_TIME isn't set
+const defaultRefreshTokenTTL = 7 * 24 * time.Hour
+
+type AuthService struct {
+	authRepo        contracts.AuthRepository
+	validator       validator.CustomValidatorInterface
+	uuidPkg         uuid.UUIDInterface
+	jwtPkg          jwt.CustomJwtInterface
+	bcryptPkg       bcrypt.CustomBcryptInterface
+	refreshTokenTTL time.Duration
+	auditSvc        contracts.AuditService
+}
+
+func NewAuthService(authRepo contracts.AuthRepository, validatorService validator.CustomValidatorInterface, uuidService uuid.UUIDInterface, jwtService jwt.CustomJwtInterface, bcryptService bcrypt.CustomBcryptInterface, refreshTokenTTL time.Duration, auditService contracts.AuditService) *AuthService {
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = defaultRefreshTokenTTL
+	}
+
+	return &AuthService{
+		authRepo:        authRepo,
+		validator:       validatorService,
+		uuidPkg:         uuidService,
+		jwtPkg:          jwtService,
+		bcryptPkg:       bcryptService,
+		refreshTokenTTL: refreshTokenTTL,
+		auditSvc:        auditService,
+	}
+}
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ConversationController struct {
+	conversationSvc *service.ConversationService
+}
+
+func InitConversationController(router fiber.Router, conversationSvc *service.ConversationService, middleware *middlewares.Middleware) {
+	controller := &ConversationController{
+		conversationSvc: conversationSvc,
+	}
+
+	// Transcripts are personal data, so viewers can't read them
+	conversationRouter := router.Group(
+		"/conversations",
+		middleware.RequireAuth(),
+		middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin),
+	)
+
+	conversationRouter.Get("/", controller.list)
+	conversationRouter.Get("/:id", controller.getByID)
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (c *ConversationController) getByID(ctx *fiber.Ctx) error {
+	var params dto.GetConversationByIDParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	res, err := c.conversationSvc.GetByID(ctx.Context(), &params)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *ConversationController) list(ctx *fiber.Ctx) error {
+	var query dto.GetConversationsQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.conversationSvc.List(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/pg"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func (r *conversationRepository) Create(ctx context.Context, conversation *entity.Conversation) error {
+	query := `
+		INSERT INTO conversations (id, user_id, dify_conversation_id, started_at, created_at, updated_at)
+		VALUES (:id, :user_id, :dify_conversation_id, :started_at, :created_at, :updated_at)
+	`
+
+	_, err := r.db.NamedExecContext(
+		ctx,
+		query,
+		conversation,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			pgErrors := []pg.PgError{
+				{
+					Code:           pg.ForeignKey,
+					ConstraintName: "fk_conversation_user",
+					Err: errx.ErrUserNotFound.WithDetails(map[string]any{
+						"user_id": conversation.UserID,
+					}).WithLocation("conversationRepository.Create"),
+				},
+			}
+
+			if customPgErr := pg.HandlePgError(pgErr, pgErrors); customPgErr != nil {
+				return customPgErr
+			}
+		}
+
+		return errx.ErrInternalServer.WithLocation("conversationRepository.Create").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *conversationRepository) UpdateDifyConversationID(ctx context.Context, id uuid.UUID, difyConversationID string) error {
+	query := `
+		UPDATE conversations
+		SET dify_conversation_id = $1, updated_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, difyConversationID, time.Now().UTC(), id)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("conversationRepository.UpdateDifyConversationID").WithError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("conversationRepository.UpdateDifyConversationID.RowsAffected").WithError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errx.ErrConversationNotFound.WithDetails(map[string]any{
+			"id": id,
+		}).WithLocation("conversationRepository.UpdateDifyConversationID")
+	}
+
+	return nil
+}
+
+func (r *conversationRepository) End(ctx context.Context, id uuid.UUID, endedAt time.Time) error {
+	query := `
+		UPDATE conversations
+		SET ended_at = $1, updated_at = $1
+		WHERE id = $2 AND ended_at IS NULL
+	`
+
+	_, err := r.db.ExecContext(ctx, query, endedAt, id)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("conversationRepository.End").WithError(err)
+	}
+
+	return nil
+}
+
+const selectConversationColumns = `
+	SELECT
+		conversations.id,
+		conversations.user_id,
+		conversations.dify_conversation_id,
+		conversations.started_at,
+		conversations.ended_at,
+		conversations.created_at,
+		conversations.updated_at,
+		(SELECT COUNT(*) FROM messages WHERE messages.conversation_id = conversations.id) AS message_count,
+
+		users.id AS "user.id",
+		users.phone_number AS "user.phone_number",
+		users.name AS "user.name",
+		users.job_title AS "user.job_title",
+		users.gender AS "user.gender",
+		users.date_of_birth AS "user.date_of_birth",
+		users.created_at AS "user.created_at",
+		users.updated_at AS "user.updated_at"
+	FROM conversations
+	LEFT JOIN users ON conversations.user_id = users.id
+`
+
+func (r *conversationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Conversation, error) {
+	query := selectConversationColumns + " WHERE conversations.id = $1"
+
+	var conversation entity.Conversation
+	err := r.db.GetContext(ctx, &conversation, query, id)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrConversationNotFound.WithDetails(map[string]any{
+				"id": id,
+			}).WithLocation("conversationRepository.FindByID")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("conversationRepository.FindByID").WithError(err)
+	}
+
+	return &conversation, nil
+}
+
+func (r *conversationRepository) List(ctx context.Context, filter *entity.GetConversationsFilter) ([]entity.Conversation, int64, error) {
+	offset := min(max(filter.Offset, 0), 10000)
+	limit := min(max(filter.Limit, 10), 100)
+
+	var qb strings.Builder
+	var whereClauses strings.Builder
+	var args []any
+
+	qb.WriteString(selectConversationColumns)
+
+	if filter.UserID != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND conversations.user_id = $%d", len(args)+1))
+		args = append(args, *filter.UserID)
+	}
+
+	if filter.StartedFrom != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND conversations.started_at >= $%d", len(args)+1))
+		args = append(args, filter.StartedFrom.UTC())
+	}
+
+	if filter.StartedTo != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND conversations.started_at < $%d", len(args)+1))
+		args = append(args, filter.StartedTo.UTC())
+	}
+
+	if filter.Search != "" {
+		whereClauses.WriteString(fmt.Sprintf(
+			" AND EXISTS (SELECT 1 FROM messages WHERE messages.conversation_id = conversations.id AND messages.content ILIKE $%d)",
+			len(args)+1,
+		))
+		args = append(args, "%"+filter.Search+"%")
+	}
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM conversations WHERE 1=1"+whereClauses.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("conversationRepository.List.Count").WithError(err)
+	}
+
+	if whereClauses.Len() > 0 {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses.String())
+	}
+	qb.WriteString(" ORDER BY conversations.started_at DESC")
+	qb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2))
+
+	args = append(args, limit, offset)
+
+	var conversations []entity.Conversation
+	err = r.db.SelectContext(ctx, &conversations, qb.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("conversationRepository.List.Select").WithError(err)
+	}
+
+	if conversations == nil {
+		conversations = []entity.Conversation{}
+	}
+
+	return conversations, total, nil
+}
+
+func (r *conversationRepository) CreateMessage(ctx context.Context, message *entity.Message) error {
+	query := `
+		INSERT INTO messages (id, conversation_id, role, kind, content, whatsapp_message_id, provider, created_at)
+		VALUES (:id, :conversation_id, :role, :kind, :content, :whatsapp_message_id, :provider, :created_at)
+	`
+
+	_, err := r.db.NamedExecContext(
+		ctx,
+		query,
+		message,
+	)
+
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			pgErrors := []pg.PgError{
+				{
+					Code:           pg.ForeignKey,
+					ConstraintName: "fk_message_conversation",
+					Err: errx.ErrConversationNotFound.WithDetails(map[string]any{
+						"conversation_id": message.ConversationID,
+					}).WithLocation("conversationRepository.CreateMessage"),
+				},
+			}
+
+			if customPgErr := pg.HandlePgError(pgErr, pgErrors); customPgErr != nil {
+				return customPgErr
+			}
+		}
+
+		return errx.ErrInternalServer.WithLocation("conversationRepository.CreateMessage").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *conversationRepository) ListMessages(ctx context.Context, conversationID uuid.UUID) ([]entity.Message, error) {
+	query := `
+		SELECT id, conversation_id, role, kind, content, whatsapp_message_id, provider, created_at
+		FROM messages
+		WHERE conversation_id = $1
+		ORDER BY created_at ASC, id ASC
+	`
+
+	var messages []entity.Message
+	err := r.db.SelectContext(ctx, &messages, query, conversationID)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("conversationRepository.ListMessages").WithError(err)
+	}
+
+	if messages == nil {
+		messages = []entity.Message{}
+	}
+
+	return messages, nil
+}
+
+// ListPendingQuestions returns the oldest user messages sent before
+// sentBefore that topic extraction hasn't seen, and whether the bot answered
+// each one
+func (r *conversationRepository) ListPendingQuestions(ctx context.Context, sentBefore time.Time, limit int) ([]entity.PendingQuestion, error) {
+	query := `
+		SELECT
+			m.id,
+			m.content,
+			m.topic_extraction_attempts,
+			m.created_at,
+			COALESCE((
+				SELECT n.kind = 'answer'
+				FROM messages n
+				WHERE n.conversation_id = m.conversation_id
+					AND (n.created_at, n.id) > (m.created_at, m.id)
+				ORDER BY n.created_at ASC, n.id ASC
+				LIMIT 1
+			), FALSE) AS answered
+		FROM messages m
+		WHERE m.topics_extracted_at IS NULL
+			AND m.role = 'user'
+			AND m.kind = 'text'
+			AND m.created_at < $1
+		ORDER BY m.created_at ASC, m.id ASC
+		LIMIT $2
+	`
+
+	var questions []entity.PendingQuestion
+	err := r.db.SelectContext(ctx, &questions, query, sentBefore, limit)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("conversationRepository.ListPendingQuestions").WithError(err)
+	}
+
+	if questions == nil {
+		questions = []entity.PendingQuestion{}
+	}
+
+	return questions, nil
+}
+
+func (r *conversationRepository) MarkTopicsExtracted(ctx context.Context, ids []uuid.UUID, extractedAt time.Time) error {
+	messageIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		messageIDs = append(messageIDs, id.String())
+	}
+
+	query := `
+		UPDATE messages
+		SET topics_extracted_at = $1
+		WHERE id = ANY($2)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, extractedAt, messageIDs)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("conversationRepository.MarkTopicsExtracted").WithError(err)
+	}
+
+	return nil
+}
+
+// RecordTopicExtractionFailure counts a failed attempt at classifying the
+// messages with ids
+func (r *conversationRepository) RecordTopicExtractionFailure(ctx context.Context, ids []uuid.UUID) error {
+	messageIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		messageIDs = append(messageIDs, id.String())
+	}
+
+	query := `
+		UPDATE messages
+		SET topic_extraction_attempts = topic_extraction_attempts + 1
+		WHERE id = ANY($1)
+	`
+
+	_, err := r.db.ExecContext(ctx, query, messageIDs)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("conversationRepository.RecordTopicExtractionFailure").WithError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts (interfaces: ConversationRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../internal/app/conversation/repository/mock/mock_conversation_repository.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts ConversationRepository
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConversationRepository is a mock of ConversationRepository interface.
+type MockConversationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationRepositoryMockRecorder is the mock recorder for MockConversationRepository.
+type MockConversationRepositoryMockRecorder struct {
+	mock *MockConversationRepository
+}
+
+// NewMockConversationRepository creates a new mock instance.
+func NewMockConversationRepository(ctrl *gomock.Controller) *MockConversationRepository {
+	mock := &MockConversationRepository{ctrl: ctrl}
+	mock.recorder = &MockConversationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationRepository) EXPECT() *MockConversationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockConversationRepository) Create(ctx context.Context, conversation *entity.Conversation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, conversation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockConversationRepositoryMockRecorder) Create(ctx, conversation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockConversationRepository)(nil).Create), ctx, conversation)
+}
+
+// CreateMessage mocks base method.
+func (m *MockConversationRepository) CreateMessage(ctx context.Context, message *entity.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessage", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMessage indicates an expected call of CreateMessage.
+func (mr *MockConversationRepositoryMockRecorder) CreateMessage(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessage", reflect.TypeOf((*MockConversationRepository)(nil).CreateMessage), ctx, message)
+}
+
+// End mocks base method.
+func (m *MockConversationRepository) End(ctx context.Context, id uuid.UUID, endedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "End", ctx, id, endedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// End indicates an expected call of End.
+func (mr *MockConversationRepositoryMockRecorder) End(ctx, id, endedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "End", reflect.TypeOf((*MockConversationRepository)(nil).End), ctx, id, endedAt)
+}
+
+// FindByID mocks base method.
+func (m *MockConversationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockConversationRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockConversationRepository)(nil).FindByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockConversationRepository) List(ctx context.Context, filter *entity.GetConversationsFilter) ([]entity.Conversation, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]entity.Conversation)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockConversationRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockConversationRepository)(nil).List), ctx, filter)
+}
+
+// ListMessages mocks base method.
+func (m *MockConversationRepository) ListMessages(ctx context.Context, conversationID uuid.UUID) ([]entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessages", ctx, conversationID)
+	ret0, _ := ret[0].([]entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessages indicates an expected call of ListMessages.
+func (mr *MockConversationRepositoryMockRecorder) ListMessages(ctx, conversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*MockConversationRepository)(nil).ListMessages), ctx, conversationID)
+}
+
+// ListPendingQuestions mocks base method.
+func (m *MockConversationRepository) ListPendingQuestions(ctx context.Context, sentBefore time.Time, limit int) ([]entity.PendingQuestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingQuestions", ctx, sentBefore, limit)
+	ret0, _ := ret[0].([]entity.PendingQuestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingQuestions indicates an expected call of ListPendingQuestions.
+func (mr *MockConversationRepositoryMockRecorder) ListPendingQuestions(ctx, sentBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingQuestions", reflect.TypeOf((*MockConversationRepository)(nil).ListPendingQuestions), ctx, sentBefore, limit)
+}
+
+// MarkTopicsExtracted mocks base method.
+func (m *MockConversationRepository) MarkTopicsExtracted(ctx context.Context, ids []uuid.UUID, extractedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTopicsExtracted", ctx, ids, extractedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkTopicsExtracted indicates an expected call of MarkTopicsExtracted.
+func (mr *MockConversationRepositoryMockRecorder) MarkTopicsExtracted(ctx, ids, extractedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTopicsExtracted", reflect.TypeOf((*MockConversationRepository)(nil).MarkTopicsExtracted), ctx, ids, extractedAt)
+}
+
+// RecordTopicExtractionFailure mocks base method.
+func (m *MockConversationRepository) RecordTopicExtractionFailure(ctx context.Context, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordTopicExtractionFailure", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordTopicExtractionFailure indicates an expected call of RecordTopicExtractionFailure.
+func (mr *MockConversationRepositoryMockRecorder) RecordTopicExtractionFailure(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTopicExtractionFailure", reflect.TypeOf((*MockConversationRepository)(nil).RecordTopicExtractionFailure), ctx, ids)
+}
+
+// UpdateDifyConversationID mocks base method.
+func (m *MockConversationRepository) UpdateDifyConversationID(ctx context.Context, id uuid.UUID, difyConversationID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDifyConversationID", ctx, id, difyConversationID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDifyConversationID indicates an expected call of UpdateDifyConversationID.
+func (mr *MockConversationRepositoryMockRecorder) UpdateDifyConversationID(ctx, id, difyConversationID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDifyConversationID", reflect.TypeOf((*MockConversationRepository)(nil).UpdateDifyConversationID), ctx, id, difyConversationID)
+}
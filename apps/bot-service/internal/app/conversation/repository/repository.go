@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/jmoiron/sqlx"
+)
+
+type conversationRepository struct {
+	db *sqlx.DB
+}
+
+func NewConversationRepository(db *sqlx.DB) contracts.ConversationRepository {
+	return &conversationRepository{db: db}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/report"
+	"github.com/google/uuid"
+)
+
+func (s *ConversationService) Start(ctx context.Context, req *dto.StartConversationRequest) (*dto.StartConversationResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	userID, err := s.uuidPkg.Parse(req.UserID)
+	if err != nil {
+		return nil, errx.ErrUserNotFound.WithDetails(map[string]any{
+			"user_id": req.UserID,
+		}).WithLocation("ConversationService.Start").WithError(err)
+	}
+
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("ConversationService.Start").WithError(err)
+	}
+
+	now := time.Now().UTC()
+	conversation := &entity.Conversation{
+		ID:        id,
+		UserID:    userID,
+		StartedAt: now,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.conversationRepo.Create(ctx, conversation); err != nil {
+		return nil, err
+	}
+
+	res := &dto.StartConversationResponse{
+		ID: id.String(),
+	}
+
+	return res, nil
+}
+
+func (s *ConversationService) SetDifyConversationID(ctx context.Context, req *dto.SetDifyConversationIDRequest) error {
+	if err := s.validator.Validate(req); err != nil {
+		return err
+	}
+
+	id, err := s.uuidPkg.Parse(req.ID)
+	if err != nil {
+		return errx.ErrConversationNotFound.WithDetails(map[string]any{
+			"id": req.ID,
+		}).WithLocation("ConversationService.SetDifyConversationID").WithError(err)
+	}
+
+	return s.conversationRepo.UpdateDifyConversationID(ctx, id, req.DifyConversationID)
+}
+
+func (s *ConversationService) End(ctx context.Context, req *dto.EndConversationRequest) error {
+	if err := s.validator.Validate(req); err != nil {
+		return err
+	}
+
+	id, err := s.uuidPkg.Parse(req.ID)
+	if err != nil {
+		return errx.ErrConversationNotFound.WithDetails(map[string]any{
+			"id": req.ID,
+		}).WithLocation("ConversationService.End").WithError(err)
+	}
+
+	return s.conversationRepo.End(ctx, id, time.Now().UTC())
+}
+
+func (s *ConversationService) RecordMessage(ctx context.Context, req *dto.RecordMessageRequest) error {
+	if err := s.validator.Validate(req); err != nil {
+		return err
+	}
+
+	conversationID, err := s.uuidPkg.Parse(req.ConversationID)
+	if err != nil {
+		return errx.ErrConversationNotFound.WithDetails(map[string]any{
+			"conversation_id": req.ConversationID,
+		}).WithLocation("ConversationService.RecordMessage").WithError(err)
+	}
+
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("ConversationService.RecordMessage").WithError(err)
+	}
+
+	message := &entity.Message{
+		ID:                id,
+		ConversationID:    conversationID,
+		Role:              req.Role,
+		Kind:              req.Kind,
+		Content:           req.Content,
+		WhatsAppMessageID: req.WhatsAppMessageID,
+		Provider:          req.Provider,
+		CreatedAt:         time.Now().UTC(),
+	}
+
+	return s.conversationRepo.CreateMessage(ctx, message)
+}
+
+func (s *ConversationService) GetByID(ctx context.Context, param *dto.GetConversationByIDParam) (*dto.GetConversationByIDResponse, error) {
+	if err := s.validator.Validate(param); err != nil {
+		return nil, err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return nil, errx.ErrConversationNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("ConversationService.GetByID").WithError(err)
+	}
+
+	conversation, err := s.conversationRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.conversationRepo.ListMessages(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	messageResponses := make([]dto.MessageResponse, 0, len(messages))
+	for i := range messages {
+		messageResponses = append(messageResponses, dto.ToMessageResponse(&messages[i]))
+	}
+
+	res := &dto.GetConversationByIDResponse{
+		Conversation: dto.ToConversationResponse(conversation),
+		Messages:     messageResponses,
+	}
+
+	return res, nil
+}
+
+func (s *ConversationService) List(ctx context.Context, query *dto.GetConversationsQuery) (*dto.GetConversationsResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	limit := min(max(query.Limit, 10), 100)
+	page := max(query.Page, 1)
+
+	var userID *uuid.UUID
+	if query.UserID != nil {
+		parsedUserID, err := s.uuidPkg.Parse(*query.UserID)
+		if err != nil {
+			return nil, errx.ErrUserNotFound.WithDetails(map[string]any{
+				"user_id": *query.UserID,
+			}).WithLocation("ConversationService.List").WithError(err)
+		}
+		userID = &parsedUserID
+	}
+
+	startedFrom, startedTo, err := report.ParseFilterRange(query.From, query.To, query.Timezone, "ConversationService.List")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.GetConversationsFilter{
+		Offset:      (page - 1) * limit,
+		Limit:       limit,
+		UserID:      userID,
+		StartedFrom: startedFrom,
+		StartedTo:   startedTo,
+		Search:      query.Search,
+	}
+
+	conversations, total, err := s.conversationRepo.List(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	conversationResponses := make([]dto.ConversationResponse, 0, len(conversations))
+	for i := range conversations {
+		conversationResponses = append(conversationResponses, dto.ToConversationResponse(&conversations[i]))
+	}
+
+	res := &dto.GetConversationsResponse{
+		Conversations: conversationResponses,
+	}
+
+	res.Meta.Pagination = dto.NewPaginationResponse(total, page, limit)
+
+	return res, nil
+}
@@ -0,0 +1,559 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	conversationRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/repository/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestConversationService_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConversationRepo := conversationRepoMock.NewMockConversationRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewConversationService(mockConversationRepo, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	testUserID := uuid.New()
+
+	tests := []struct {
+		name    string
+		req     *dto.StartConversationRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success",
+			req: &dto.StartConversationRequest{
+				UserID: testUserID.String(),
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testUserID.String()).Return(testUserID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockConversationRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, conversation *entity.Conversation) error {
+					assert.Equal(t, testID, conversation.ID)
+					assert.Equal(t, testUserID, conversation.UserID)
+					assert.Nil(t, conversation.EndedAt)
+					assert.False(t, conversation.StartedAt.IsZero())
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "validation error",
+			req: &dto.StartConversationRequest{
+				UserID: "invalid",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.userId": validator.ValidationError{
+						Message: "userId must be a valid UUID",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "user not found",
+			req: &dto.StartConversationRequest{
+				UserID: testUserID.String(),
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testUserID.String()).Return(testUserID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockConversationRepo.EXPECT().Create(ctx, gomock.Any()).Return(errx.ErrUserNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.Start(ctx, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testID.String(), result.ID)
+			}
+		})
+	}
+}
+
+func TestConversationService_RecordMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConversationRepo := conversationRepoMock.NewMockConversationRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewConversationService(mockConversationRepo, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	testConversationID := uuid.New()
+	whatsappMessageID := "3EB0C767D26A1D0E"
+	provider := "gemini"
+
+	tests := []struct {
+		name    string
+		req     *dto.RecordMessageRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success - user message",
+			req: &dto.RecordMessageRequest{
+				ConversationID:    testConversationID.String(),
+				Role:              entity.MessageRoleUser,
+				Kind:              entity.MessageKindText,
+				Content:           "Bagaimana cara mengajukan cuti?",
+				WhatsAppMessageID: &whatsappMessageID,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testConversationID.String()).Return(testConversationID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockConversationRepo.EXPECT().CreateMessage(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, message *entity.Message) error {
+					assert.Equal(t, testID, message.ID)
+					assert.Equal(t, testConversationID, message.ConversationID)
+					assert.Equal(t, entity.MessageRoleUser, message.Role)
+					assert.Equal(t, entity.MessageKindText, message.Kind)
+					assert.Equal(t, "Bagaimana cara mengajukan cuti?", message.Content)
+					assert.Equal(t, &whatsappMessageID, message.WhatsAppMessageID)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "success - assistant answer with provider",
+			req: &dto.RecordMessageRequest{
+				ConversationID: testConversationID.String(),
+				Role:           entity.MessageRoleAssistant,
+				Kind:           entity.MessageKindAnswer,
+				Content:        "Cuti dapat diajukan melalui portal HC.",
+				Provider:       &provider,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testConversationID.String()).Return(testConversationID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockConversationRepo.EXPECT().CreateMessage(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, message *entity.Message) error {
+					assert.Equal(t, entity.MessageRoleAssistant, message.Role)
+					assert.Equal(t, entity.MessageKindAnswer, message.Kind)
+					assert.Equal(t, &provider, message.Provider)
+					assert.Nil(t, message.WhatsAppMessageID)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "validation error - invalid role",
+			req: &dto.RecordMessageRequest{
+				ConversationID: testConversationID.String(),
+				Role:           "bot",
+				Kind:           entity.MessageKindAnswer,
+				Content:        "Halo",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.role": validator.ValidationError{
+						Message: "role must be one of [user assistant system]",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "conversation not found",
+			req: &dto.RecordMessageRequest{
+				ConversationID: testConversationID.String(),
+				Role:           entity.MessageRoleSystem,
+				Kind:           entity.MessageKindGoodbye,
+				Content:        "Sampai jumpa lagi!",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testConversationID.String()).Return(testConversationID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockConversationRepo.EXPECT().CreateMessage(ctx, gomock.Any()).Return(errx.ErrConversationNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrConversationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := service.RecordMessage(ctx, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConversationService_SetDifyConversationID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConversationRepo := conversationRepoMock.NewMockConversationRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewConversationService(mockConversationRepo, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockConversationRepo.EXPECT().UpdateDifyConversationID(ctx, testID, "dify-conversation-1").Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "conversation not found",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockConversationRepo.EXPECT().UpdateDifyConversationID(ctx, testID, "dify-conversation-1").Return(errx.ErrConversationNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrConversationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := service.SetDifyConversationID(ctx, &dto.SetDifyConversationIDRequest{
+				ID:                 testID.String(),
+				DifyConversationID: "dify-conversation-1",
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConversationService_End(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConversationRepo := conversationRepoMock.NewMockConversationRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewConversationService(mockConversationRepo, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockConversationRepo.EXPECT().End(ctx, testID, gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockConversationRepo.EXPECT().End(ctx, testID, gomock.Any()).Return(errx.ErrInternalServer)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			err := service.End(ctx, &dto.EndConversationRequest{ID: testID.String()})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConversationService_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConversationRepo := conversationRepoMock.NewMockConversationRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewConversationService(mockConversationRepo, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	testUserID := uuid.New()
+	difyConversationID := "dify-conversation-1"
+	now := time.Now()
+
+	testConversation := &entity.Conversation{
+		ID:                 testID,
+		UserID:             testUserID,
+		DifyConversationID: &difyConversationID,
+		StartedAt:          now,
+		MessageCount:       2,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		User: entity.User{
+			ID:          testUserID,
+			PhoneNumber: "+6281234567890",
+			Name:        "Test User",
+		},
+	}
+
+	testMessages := []entity.Message{
+		{ID: uuid.New(), ConversationID: testID, Role: entity.MessageRoleUser, Kind: entity.MessageKindText, Content: "Halo", CreatedAt: now},
+		{ID: uuid.New(), ConversationID: testID, Role: entity.MessageRoleAssistant, Kind: entity.MessageKindAnswer, Content: "Halo juga", CreatedAt: now},
+	}
+
+	tests := []struct {
+		name    string
+		param   *dto.GetConversationByIDParam
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name:  "success",
+			param: &dto.GetConversationByIDParam{ID: testID.String()},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockConversationRepo.EXPECT().FindByID(ctx, testID).Return(testConversation, nil)
+				mockConversationRepo.EXPECT().ListMessages(ctx, testID).Return(testMessages, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:  "invalid uuid",
+			param: &dto.GetConversationByIDParam{ID: "invalid"},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse("invalid").Return(uuid.Nil, errors.New("invalid UUID"))
+			},
+			wantErr: true,
+			errType: errx.ErrConversationNotFound,
+		},
+		{
+			name:  "conversation not found",
+			param: &dto.GetConversationByIDParam{ID: testID.String()},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockConversationRepo.EXPECT().FindByID(ctx, testID).Return(nil, errx.ErrConversationNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrConversationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.GetByID(ctx, tt.param)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testID.String(), result.Conversation.ID)
+				assert.Equal(t, &difyConversationID, result.Conversation.DifyConversationID)
+				assert.Equal(t, "Test User", result.Conversation.User.Name)
+				assert.Len(t, result.Messages, 2)
+				assert.Equal(t, entity.MessageRoleUser, result.Messages[0].Role)
+				assert.Equal(t, "Halo juga", result.Messages[1].Content)
+			}
+		})
+	}
+}
+
+func TestConversationService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockConversationRepo := conversationRepoMock.NewMockConversationRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewConversationService(mockConversationRepo, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testUserID := uuid.New()
+	from := "2025-12-01"
+	to := "2025-12-07"
+	invalidDate := "07-12-2025"
+
+	testConversations := []entity.Conversation{
+		{ID: uuid.New(), UserID: testUserID, StartedAt: time.Now()},
+		{ID: uuid.New(), UserID: testUserID, StartedAt: time.Now()},
+	}
+
+	tests := []struct {
+		name      string
+		query     *dto.GetConversationsQuery
+		setup     func()
+		wantErr   bool
+		wantCount int
+		errType   error
+	}{
+		{
+			name:  "success with default pagination",
+			query: &dto.GetConversationsQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockConversationRepo.EXPECT().List(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetConversationsFilter) ([]entity.Conversation, int64, error) {
+					assert.Equal(t, 0, filter.Offset)
+					assert.Equal(t, 10, filter.Limit)
+					assert.Nil(t, filter.UserID)
+					assert.Nil(t, filter.StartedFrom)
+					assert.Nil(t, filter.StartedTo)
+					return testConversations, 2, nil
+				})
+			},
+			wantErr:   false,
+			wantCount: 2,
+		},
+		{
+			name: "success with filters",
+			query: &dto.GetConversationsQuery{
+				Page:     2,
+				Limit:    20,
+				UserID:   func() *string { s := testUserID.String(); return &s }(),
+				From:     &from,
+				To:       &to,
+				Timezone: "UTC",
+				Search:   "cuti",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testUserID.String()).Return(testUserID, nil)
+				mockConversationRepo.EXPECT().List(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetConversationsFilter) ([]entity.Conversation, int64, error) {
+					assert.Equal(t, 20, filter.Offset)
+					assert.Equal(t, 20, filter.Limit)
+					assert.Equal(t, testUserID, *filter.UserID)
+					assert.Equal(t, time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC), filter.StartedFrom.UTC())
+					// "to" is inclusive
+					assert.Equal(t, time.Date(2025, 12, 8, 0, 0, 0, 0, time.UTC), filter.StartedTo.UTC())
+					assert.Equal(t, "cuti", filter.Search)
+					return testConversations[:1], 21, nil
+				})
+			},
+			wantErr:   false,
+			wantCount: 1,
+		},
+		{
+			name:  "invalid date format",
+			query: &dto.GetConversationsQuery{From: &invalidDate},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateFormat,
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetConversationsQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockConversationRepo.EXPECT().List(ctx, gomock.Any()).Return(nil, int64(0), errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.List(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Conversations, tt.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts (interfaces: ConversationService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../internal/app/conversation/service/mock/mock_conversation_service.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts ConversationService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConversationService is a mock of ConversationService interface.
+type MockConversationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockConversationServiceMockRecorder is the mock recorder for MockConversationService.
+type MockConversationServiceMockRecorder struct {
+	mock *MockConversationService
+}
+
+// NewMockConversationService creates a new mock instance.
+func NewMockConversationService(ctrl *gomock.Controller) *MockConversationService {
+	mock := &MockConversationService{ctrl: ctrl}
+	mock.recorder = &MockConversationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationService) EXPECT() *MockConversationServiceMockRecorder {
+	return m.recorder
+}
+
+// End mocks base method.
+func (m *MockConversationService) End(ctx context.Context, req *dto.EndConversationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "End", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// End indicates an expected call of End.
+func (mr *MockConversationServiceMockRecorder) End(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "End", reflect.TypeOf((*MockConversationService)(nil).End), ctx, req)
+}
+
+// GetByID mocks base method.
+func (m *MockConversationService) GetByID(ctx context.Context, param *dto.GetConversationByIDParam) (*dto.GetConversationByIDResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, param)
+	ret0, _ := ret[0].(*dto.GetConversationByIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockConversationServiceMockRecorder) GetByID(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockConversationService)(nil).GetByID), ctx, param)
+}
+
+// List mocks base method.
+func (m *MockConversationService) List(ctx context.Context, query *dto.GetConversationsQuery) (*dto.GetConversationsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, query)
+	ret0, _ := ret[0].(*dto.GetConversationsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockConversationServiceMockRecorder) List(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockConversationService)(nil).List), ctx, query)
+}
+
+// RecordMessage mocks base method.
+func (m *MockConversationService) RecordMessage(ctx context.Context, req *dto.RecordMessageRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordMessage", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordMessage indicates an expected call of RecordMessage.
+func (mr *MockConversationServiceMockRecorder) RecordMessage(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordMessage", reflect.TypeOf((*MockConversationService)(nil).RecordMessage), ctx, req)
+}
+
+// SetDifyConversationID mocks base method.
+func (m *MockConversationService) SetDifyConversationID(ctx context.Context, req *dto.SetDifyConversationIDRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDifyConversationID", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDifyConversationID indicates an expected call of SetDifyConversationID.
+func (mr *MockConversationServiceMockRecorder) SetDifyConversationID(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDifyConversationID", reflect.TypeOf((*MockConversationService)(nil).SetDifyConversationID), ctx, req)
+}
+
+// Start mocks base method.
+func (m *MockConversationService) Start(ctx context.Context, req *dto.StartConversationRequest) (*dto.StartConversationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, req)
+	ret0, _ := ret[0].(*dto.StartConversationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockConversationServiceMockRecorder) Start(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockConversationService)(nil).Start), ctx, req)
+}
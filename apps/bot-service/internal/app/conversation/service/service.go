@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+)
+
+type ConversationService struct {
+	conversationRepo contracts.ConversationRepository
+	validator        validator.CustomValidatorInterface
+	uuidPkg          uuid.UUIDInterface
+}
+
+func NewConversationService(
+	conversationRepo contracts.ConversationRepository,
+	validatorService validator.CustomValidatorInterface,
+	uuidService uuid.UUIDInterface,
+) *ConversationService {
+	return &ConversationService{
+		conversationRepo: conversationRepo,
+		validator:        validatorService,
+		uuidPkg:          uuidService,
+	}
+}
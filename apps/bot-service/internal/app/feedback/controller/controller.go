@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/feedback/service"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
 	"github.com/gofiber/fiber/v2"
@@ -17,10 +18,18 @@ func InitFeedbackController(router fiber.Router, feedbackSvc *service.FeedbackSe
 
 	feedbackRouter := router.Group("/feedbacks")
 
-	// TODO: Add middleware for authentication and authorization
-	feedbackRouter.Post("/", controller.create)
-	feedbackRouter.Get("/", controller.list)
-	feedbackRouter.Get("/metrics", controller.getMetrics)
-	feedbackRouter.Get("/satisfaction-trend", controller.getSatisfactionTrend)
-	feedbackRouter.Get("/:id", controller.getByID)
+	// Viewers only see aggregate numbers, not individual feedback
+	requireAuth := middleware.RequireAuth()
+	canRead := middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin, entity.AdminRoleViewer)
+	canManage := middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin)
+
+	// Feedback is submitted by machine callers, not by dashboard users
+	feedbackRouter.Post("/", middleware.APIKeyAuth(entity.APIKeyScopeFeedbacksWrite), controller.create)
+	feedbackRouter.Get("/", requireAuth, canManage, controller.list)
+	feedbackRouter.Get("/export", requireAuth, canManage, controller.export)
+	feedbackRouter.Get("/metrics", requireAuth, canRead, controller.getMetrics)
+	feedbackRouter.Get("/breakdowns/:dimension", requireAuth, canRead, controller.getBreakdown)
+	feedbackRouter.Get("/satisfaction-trend", requireAuth, canRead, controller.getSatisfactionTrend)
+	feedbackRouter.Get("/:id", requireAuth, canManage, controller.getByID)
+	feedbackRouter.Get("/:id/conversation", requireAuth, canManage, controller.getConversation)
 }
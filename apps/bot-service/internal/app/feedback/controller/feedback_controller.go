@@ -34,11 +34,28 @@ func (c *FeedbackController) getByID(ctx *fiber.Ctx) error {
 	return response.SendResponse(ctx, fiber.StatusOK, res)
 }
 
+func (c *FeedbackController) getConversation(ctx *fiber.Ctx) error {
+	var params dto.GetFeedbackConversationParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	res, err := c.feedbackSvc.GetConversation(ctx.Context(), &params)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
 func (c *FeedbackController) list(ctx *fiber.Ctx) error {
 	var query dto.GetFeedbacksQuery
 	if err := ctx.QueryParser(&query); err != nil {
 		return err
 	}
+	if query.Cursor == "" {
+		query.Cursor = ctx.Get("X-Cursor")
+	}
 
 	res, err := c.feedbackSvc.List(ctx.Context(), &query)
 	if err != nil {
@@ -48,8 +65,46 @@ func (c *FeedbackController) list(ctx *fiber.Ctx) error {
 	return response.SendResponse(ctx, fiber.StatusOK, res)
 }
 
+func (c *FeedbackController) export(ctx *fiber.Ctx) error {
+	var query dto.ExportFeedbacksQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	file, err := c.feedbackSvc.Export(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendFile(ctx, file)
+}
+
 func (c *FeedbackController) getMetrics(ctx *fiber.Ctx) error {
-	res, err := c.feedbackSvc.GetMetrics(ctx.Context())
+	var query dto.GetFeedbackMetricsQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.feedbackSvc.GetMetrics(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *FeedbackController) getBreakdown(ctx *fiber.Ctx) error {
+	var params dto.GetFeedbackBreakdownParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	var query dto.GetFeedbackBreakdownQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.feedbackSvc.GetBreakdown(ctx.Context(), &params, &query)
 	if err != nil {
 		return err
 	}
@@ -58,7 +113,12 @@ func (c *FeedbackController) getMetrics(ctx *fiber.Ctx) error {
 }
 
 func (c *FeedbackController) getSatisfactionTrend(ctx *fiber.Ctx) error {
-	res, err := c.feedbackSvc.GetSatisfactionTrend(ctx.Context())
+	var query dto.GetSatisfactionTrendQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.feedbackSvc.GetSatisfactionTrend(ctx.Context(), &query)
 	if err != nil {
 		return err
 	}
@@ -10,14 +10,15 @@ import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/pg"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/report"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
 func (r *feedbackRepository) Create(ctx context.Context, feedback *entity.Feedback) error {
 	query := `
-		INSERT INTO feedbacks (id, user_id, rating, comment, created_at)
-		VALUES (:id, :user_id, :rating, :comment, :created_at)
+		INSERT INTO feedbacks (id, user_id, conversation_id, dify_conversation_id, rating, comment, source, created_at)
+		VALUES (:id, :user_id, :conversation_id, :dify_conversation_id, :rating, :comment, :source, :created_at)
 	`
 
 	_, err := r.db.NamedExecContext(
@@ -37,6 +38,13 @@ func (r *feedbackRepository) Create(ctx context.Context, feedback *entity.Feedba
 						"user_id": feedback.UserID,
 					}).WithLocation("feedbackRepository.Create"),
 				},
+				{
+					Code:           pg.ForeignKey,
+					ConstraintName: "fk_feedback_conversation",
+					Err: errx.ErrConversationNotFound.WithDetails(map[string]any{
+						"conversation_id": feedback.ConversationID,
+					}).WithLocation("feedbackRepository.Create"),
+				},
 			}
 
 			if customPgErr := pg.HandlePgError(pgErr, pgErrors); customPgErr != nil {
@@ -55,8 +63,11 @@ func (r *feedbackRepository) FindByID(ctx context.Context, id uuid.UUID) (*entit
 		SELECT
 			feedbacks.id,
 			feedbacks.user_id,
+			feedbacks.conversation_id,
+			feedbacks.dify_conversation_id,
 			feedbacks.rating,
 			feedbacks.comment,
+			feedbacks.source,
 			feedbacks.created_at,
 
 			users.id AS "user.id",
@@ -93,15 +104,144 @@ func (r *feedbackRepository) List(ctx context.Context, filter *entity.GetFeedbac
 	limit := min(max(filter.Limit, 10), 100)
 
 	var qb strings.Builder
-	var whereClauses strings.Builder
-	var args []any
+	whereClauses, args := buildFeedbacksWhere(filter)
+
+	qb.WriteString(`
+		SELECT
+			feedbacks.id,
+			feedbacks.user_id,
+			feedbacks.conversation_id,
+			feedbacks.dify_conversation_id,
+			feedbacks.rating,
+			feedbacks.comment,
+			feedbacks.source,
+			feedbacks.created_at,
+
+			users.id AS "user.id",
+			users.phone_number AS "user.phone_number",
+			users.name AS "user.name",
+			users.job_title AS "user.job_title",
+			users.gender AS "user.gender",
+			users.date_of_birth AS "user.date_of_birth",
+			users.created_at AS "user.created_at",
+			users.updated_at AS "user.updated_at"
+		FROM feedbacks
+		LEFT JOIN users ON feedbacks.user_id = users.id
+	`)
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM feedbacks LEFT JOIN users ON feedbacks.user_id = users.id WHERE 1=1"+whereClauses, args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("feedbackRepository.List.Count").WithError(err)
+	}
+
+	if whereClauses != "" {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses)
+	}
+	qb.WriteString(feedbacksOrderBy(filter))
+	qb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2))
+
+	args = append(args, limit, offset)
+
+	var feedbacks []entity.Feedback
+	err = r.db.SelectContext(ctx, &feedbacks, qb.String(), args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("feedbackRepository.List.Select").WithError(err)
+	}
+
+	if feedbacks == nil {
+		feedbacks = []entity.Feedback{}
+	}
+
+	return feedbacks, total, nil
+}
+
+// ListByCursor returns the page of feedbacks after filter.After, or the first
+// page when it's nil, and whether there are more after it. Offset is ignored.
+func (r *feedbackRepository) ListByCursor(ctx context.Context, filter *entity.GetFeedbacksFilter) ([]entity.Feedback, bool, error) {
+	limit := min(max(filter.Limit, 10), 100)
+
+	var qb strings.Builder
+	whereClauses, args := buildFeedbacksWhere(filter)
+
+	if filter.After != nil {
+		comparison := "<"
+		if filter.SortOrder == entity.SortOrderAsc {
+			comparison = ">"
+		}
+
+		whereClauses += fmt.Sprintf(" AND (feedbacks.created_at, feedbacks.id) %s ($%d, $%d)", comparison, len(args)+1, len(args)+2)
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+	}
+
+	qb.WriteString(`
+		SELECT
+			feedbacks.id,
+			feedbacks.user_id,
+			feedbacks.conversation_id,
+			feedbacks.dify_conversation_id,
+			feedbacks.rating,
+			feedbacks.comment,
+			feedbacks.source,
+			feedbacks.created_at,
+
+			users.id AS "user.id",
+			users.phone_number AS "user.phone_number",
+			users.name AS "user.name",
+			users.job_title AS "user.job_title",
+			users.gender AS "user.gender",
+			users.date_of_birth AS "user.date_of_birth",
+			users.created_at AS "user.created_at",
+			users.updated_at AS "user.updated_at"
+		FROM feedbacks
+		LEFT JOIN users ON feedbacks.user_id = users.id
+	`)
+
+	if whereClauses != "" {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses)
+	}
+	qb.WriteString(feedbacksOrderBy(filter))
+	// One extra row tells whether there's another page
+	qb.WriteString(fmt.Sprintf(" LIMIT $%d", len(args)+1))
+
+	args = append(args, limit+1)
+
+	var feedbacks []entity.Feedback
+	err := r.db.SelectContext(ctx, &feedbacks, qb.String(), args...)
+	if err != nil {
+		return nil, false, errx.ErrInternalServer.WithLocation("feedbackRepository.ListByCursor").WithError(err)
+	}
+
+	hasMore := len(feedbacks) > limit
+	if hasMore {
+		feedbacks = feedbacks[:limit]
+	}
+
+	if feedbacks == nil {
+		feedbacks = []entity.Feedback{}
+	}
+
+	return feedbacks, hasMore, nil
+}
+
+// Each calls fn for every feedback matching filter, newest first, reading rows
+// off the cursor one at a time. Offset and Limit are ignored. It stops at the
+// first error fn returns.
+func (r *feedbackRepository) Each(ctx context.Context, filter *entity.GetFeedbacksFilter, fn func(feedback *entity.Feedback) error) error {
+	var qb strings.Builder
+	whereClauses, args := buildFeedbacksWhere(filter)
 
 	qb.WriteString(`
 		SELECT
 			feedbacks.id,
 			feedbacks.user_id,
+			feedbacks.conversation_id,
+			feedbacks.dify_conversation_id,
 			feedbacks.rating,
 			feedbacks.comment,
+			feedbacks.source,
 			feedbacks.created_at,
 
 			users.id AS "user.id",
@@ -116,8 +256,45 @@ func (r *feedbackRepository) List(ctx context.Context, filter *entity.GetFeedbac
 		LEFT JOIN users ON feedbacks.user_id = users.id
 	`)
 
+	if whereClauses != "" {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses)
+	}
+	qb.WriteString(" ORDER BY feedbacks.created_at DESC")
+
+	rows, err := r.db.QueryxContext(ctx, qb.String(), args...)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("feedbackRepository.Each.Query").WithError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var feedback entity.Feedback
+		if err := rows.StructScan(&feedback); err != nil {
+			return errx.ErrInternalServer.WithLocation("feedbackRepository.Each.Scan").WithError(err)
+		}
+
+		if err := fn(&feedback); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return errx.ErrInternalServer.WithLocation("feedbackRepository.Each.Rows").WithError(err)
+	}
+
+	return nil
+}
+
+// buildFeedbacksWhere returns the filter's conditions for a query that joins
+// users onto feedbacks. created_at is written in UTC, so the date bounds are
+// passed in UTC too.
+func buildFeedbacksWhere(filter *entity.GetFeedbacksFilter) (string, []any) {
+	var whereClauses strings.Builder
+	var args []any
+
 	if filter.UserID != nil {
-		whereClauses.WriteString(fmt.Sprintf(" AND user_id = $%d", len(args)+1))
+		whereClauses.WriteString(fmt.Sprintf(" AND feedbacks.user_id = $%d", len(args)+1))
 		args = append(args, *filter.UserID)
 	}
 
@@ -127,90 +304,238 @@ func (r *feedbackRepository) List(ctx context.Context, filter *entity.GetFeedbac
 			placeholders[i] = fmt.Sprintf("$%d", len(args)+1)
 			args = append(args, rating)
 		}
-		whereClauses.WriteString(fmt.Sprintf(" AND rating IN (%s)", strings.Join(placeholders, ",")))
+		whereClauses.WriteString(fmt.Sprintf(" AND feedbacks.rating IN (%s)", strings.Join(placeholders, ",")))
 	} else {
 		if filter.MinRating != nil {
-			whereClauses.WriteString(fmt.Sprintf(" AND rating >= $%d", len(args)+1))
+			whereClauses.WriteString(fmt.Sprintf(" AND feedbacks.rating >= $%d", len(args)+1))
 			args = append(args, *filter.MinRating)
 		}
 
 		if filter.MaxRating != nil {
-			whereClauses.WriteString(fmt.Sprintf(" AND rating <= $%d", len(args)+1))
+			whereClauses.WriteString(fmt.Sprintf(" AND feedbacks.rating <= $%d", len(args)+1))
 			args = append(args, *filter.MaxRating)
 		}
 	}
 
-	var total int64
-	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM feedbacks WHERE 1=1"+whereClauses.String(), args...)
-	if err != nil {
-		return nil, 0, errx.ErrInternalServer.WithLocation("feedbackRepository.List.Count").WithError(err)
+	if filter.JobTitle != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND LOWER(users.job_title) = LOWER($%d)", len(args)+1))
+		args = append(args, *filter.JobTitle)
 	}
 
-	if whereClauses.Len() > 0 {
-		qb.WriteString(" WHERE 1=1")
-		qb.WriteString(whereClauses.String())
+	if filter.CreatedFrom != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND feedbacks.created_at >= $%d", len(args)+1))
+		args = append(args, filter.CreatedFrom.UTC())
 	}
-	qb.WriteString(" ORDER BY created_at DESC")
-	qb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2))
 
-	args = append(args, limit, offset)
+	if filter.CreatedTo != nil {
+		whereClauses.WriteString(fmt.Sprintf(" AND feedbacks.created_at < $%d", len(args)+1))
+		args = append(args, filter.CreatedTo.UTC())
+	}
 
-	var feedbacks []entity.Feedback
-	err = r.db.SelectContext(ctx, &feedbacks, qb.String(), args...)
-	if err != nil {
-		return nil, 0, errx.ErrInternalServer.WithLocation("feedbackRepository.List.Select").WithError(err)
+	if filter.Search != "" {
+		whereClauses.WriteString(fmt.Sprintf(" AND feedbacks.comment_search @@ websearch_to_tsquery('indonesian', $%d)", len(args)+1))
+		args = append(args, filter.Search)
 	}
 
-	if feedbacks == nil {
-		feedbacks = []entity.Feedback{}
+	if filter.HasComment != nil {
+		if *filter.HasComment {
+			whereClauses.WriteString(" AND BTRIM(COALESCE(feedbacks.comment, '')) <> ''")
+		} else {
+			whereClauses.WriteString(" AND BTRIM(COALESCE(feedbacks.comment, '')) = ''")
+		}
 	}
 
-	return feedbacks, total, nil
+	return whereClauses.String(), args
 }
 
-func (r *feedbackRepository) GetMetrics(ctx context.Context) (float64, int, error) {
-	query := `
+// feedbacksOrderBy returns the ORDER BY clause for the filter's sort. Ties
+// are broken by date and then ID, newest first, so pages don't overlap.
+func feedbacksOrderBy(filter *entity.GetFeedbacksFilter) string {
+	direction := "DESC"
+	if filter.SortOrder == entity.SortOrderAsc {
+		direction = "ASC"
+	}
+
+	switch filter.SortBy {
+	case entity.FeedbackSortRating:
+		return fmt.Sprintf(" ORDER BY feedbacks.rating %s, feedbacks.created_at DESC, feedbacks.id DESC", direction)
+	default:
+		return fmt.Sprintf(" ORDER BY feedbacks.created_at %s, feedbacks.id %s", direction, direction)
+	}
+}
+
+// GetMetrics counts the current period and, when filter.PreviousFrom is
+// set, the previous one in a single scan. A period without feedback comes
+// back zeroed.
+func (r *feedbackRepository) GetMetrics(ctx context.Context, filter *entity.FeedbackMetricsFilter) (*entity.FeedbackMetrics, *entity.FeedbackMetrics, error) {
+	isCurrent := "TRUE"
+	where := ""
+	var args []any
+	if filter.From != nil {
+		previousFrom := *filter.From
+		if filter.PreviousFrom != nil {
+			previousFrom = *filter.PreviousFrom
+		}
+
+		isCurrent = "created_at >= $1"
+		where = "WHERE created_at >= $2 AND created_at < $3"
+		args = append(args, filter.From.UTC(), previousFrom.UTC(), filter.To.UTC())
+	}
+
+	query := fmt.Sprintf(`
 		SELECT
-			COALESCE(
-				(SUM(rating) * 100.0) / NULLIF(COUNT(*) * 5, 0),
-				0
-			) AS satisfaction_score,
-			COUNT(*) AS total_feedbacks
+			%s AS is_current,
+			COUNT(*) AS total_feedbacks,
+			COALESCE(SUM(rating), 0) AS rating_sum,
+			COUNT(*) FILTER (WHERE rating = 1) AS rating_1,
+			COUNT(*) FILTER (WHERE rating = 2) AS rating_2,
+			COUNT(*) FILTER (WHERE rating = 3) AS rating_3,
+			COUNT(*) FILTER (WHERE rating = 4) AS rating_4,
+			COUNT(*) FILTER (WHERE rating = 5) AS rating_5,
+			COUNT(*) FILTER (WHERE source = '%s') AS auto_feedbacks,
+			COUNT(*) FILTER (WHERE BTRIM(COALESCE(comment, '')) <> '') AS commented_feedbacks
 		FROM feedbacks
-	`
+		%s
+		GROUP BY 1
+	`, isCurrent, entity.FeedbackSourceAuto, where)
 
-	var result struct {
-		SatisfactionScore float64 `db:"satisfaction_score"`
-		TotalFeedbacks    int     `db:"total_feedbacks"`
+	var rows []struct {
+		IsCurrent bool `db:"is_current"`
+		entity.FeedbackMetrics
 	}
-	err := r.db.GetContext(ctx, &result, query)
+	err := r.db.SelectContext(ctx, &rows, query, args...)
 	if err != nil {
-		return 0, 0, errx.ErrInternalServer.WithLocation("feedbackRepository.GetMetrics").WithError(err)
+		return nil, nil, errx.ErrInternalServer.WithLocation("feedbackRepository.GetMetrics").WithError(err)
+	}
+
+	current := &entity.FeedbackMetrics{}
+	var previous *entity.FeedbackMetrics
+	if filter.PreviousFrom != nil {
+		previous = &entity.FeedbackMetrics{}
+	}
+	for _, row := range rows {
+		if row.IsCurrent {
+			*current = row.FeedbackMetrics
+		} else if previous != nil {
+			*previous = row.FeedbackMetrics
+		}
 	}
 
-	return result.SatisfactionScore, result.TotalFeedbacks, nil
+	return current, previous, nil
 }
 
-func (r *feedbackRepository) GetSatisfactionTrend(ctx context.Context) ([]entity.SatisfactionTrendRow, error) {
+// breakdownGroups maps a breakdown dimension to the value a feedback is
+// grouped under, given feedbacks f joined with users u
+var breakdownGroups = map[string]string{
+	// Job titles are free text, so group them case-insensitively
+	entity.BreakdownDimensionJobTitle: "NULLIF(BTRIM(u.job_title), '')",
+	entity.BreakdownDimensionGender:   "u.gender",
+	entity.BreakdownDimensionAgeBand: fmt.Sprintf(`
+		CASE
+			WHEN u.date_of_birth IS NULL THEN NULL
+			WHEN date_part('year', age(f.created_at, u.date_of_birth)) < 25 THEN '%s'
+			WHEN date_part('year', age(f.created_at, u.date_of_birth)) < 35 THEN '%s'
+			WHEN date_part('year', age(f.created_at, u.date_of_birth)) < 45 THEN '%s'
+			WHEN date_part('year', age(f.created_at, u.date_of_birth)) < 55 THEN '%s'
+			ELSE '%s'
+		END`,
+		entity.AgeBandUnder25, entity.AgeBand25To34, entity.AgeBand35To44, entity.AgeBand45To54, entity.AgeBand55Plus,
+	),
+}
+
+// GetBreakdown returns every group of filter.Dimension with feedback in the
+// range, largest first. Callers hide the small ones.
+func (r *feedbackRepository) GetBreakdown(ctx context.Context, filter *entity.FeedbackBreakdownFilter) ([]entity.FeedbackBreakdownRow, error) {
+	group, ok := breakdownGroups[filter.Dimension]
+	if !ok {
+		return nil, errx.ErrInternalServer.WithDetails(map[string]any{
+			"dimension": filter.Dimension,
+		}).WithLocation("feedbackRepository.GetBreakdown")
+	}
+
+	where := ""
+	var args []any
+	if filter.From != nil {
+		where = "WHERE f.created_at >= $1 AND f.created_at < $2"
+		args = append(args, filter.From.UTC(), filter.To.UTC())
+	}
+
+	query := fmt.Sprintf(`
+		SELECT
+			MIN(g.value) AS value,
+			AVG(g.rating) AS avg_rating,
+			COUNT(*) AS feedback_count,
+			COUNT(*) FILTER (WHERE g.commented) AS commented_count,
+			COUNT(DISTINCT g.user_id) AS user_count
+		FROM (
+			SELECT
+				%s AS value,
+				f.rating,
+				f.user_id,
+				BTRIM(COALESCE(f.comment, '')) <> '' AS commented
+			FROM feedbacks f
+			JOIN users u ON u.id = f.user_id
+			%s
+		) g
+		GROUP BY LOWER(g.value)
+		ORDER BY feedback_count DESC, value ASC
+	`, group, where)
+
+	var results []entity.FeedbackBreakdownRow
+	err := r.db.SelectContext(ctx, &results, query, args...)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("feedbackRepository.GetBreakdown").WithError(err)
+	}
+
+	if results == nil {
+		results = []entity.FeedbackBreakdownRow{}
+	}
+
+	return results, nil
+}
+
+// GetSatisfactionTrend returns one row per bucket from filter.From up to
+// filter.To, including buckets without feedback. created_at is stored in UTC
+// and converted to filter.Timezone before bucketing. Buckets at the edges can
+// start before From but only count feedback inside the range.
+func (r *feedbackRepository) GetSatisfactionTrend(ctx context.Context, filter *entity.SatisfactionTrendFilter) ([]entity.SatisfactionTrendRow, error) {
 	query := `
-		WITH date_series AS (
+		WITH buckets AS (
 			SELECT generate_series(
-				CURRENT_DATE - 30 * INTERVAL '1 day',
-				CURRENT_DATE,
-				INTERVAL '1 day'
-			)::date AS date
+				date_trunc($1, $2::timestamp),
+				$3::timestamp - INTERVAL '1 day',
+				('1 ' || $1)::interval
+			) AS bucket
+		),
+		local_feedbacks AS (
+			SELECT
+				date_trunc($1, created_at AT TIME ZONE 'UTC' AT TIME ZONE $4) AS bucket,
+				rating
+			FROM feedbacks
+			WHERE created_at >= $5 AND created_at < $6
 		)
 		SELECT
-			ds.date,
-			COALESCE(AVG(f.rating), 0) AS avg_satisfaction
-		FROM date_series ds
-		LEFT JOIN feedbacks f ON DATE(f.created_at) = ds.date
-		GROUP BY ds.date
-		ORDER BY ds.date ASC
+			b.bucket AS date,
+			COALESCE(AVG(lf.rating), 0) AS avg_satisfaction,
+			COUNT(lf.rating) AS feedback_count
+		FROM buckets b
+		LEFT JOIN local_feedbacks lf ON lf.bucket = b.bucket
+		GROUP BY b.bucket
+		ORDER BY b.bucket ASC
 	`
 
 	var results []entity.SatisfactionTrendRow
-	err := r.db.SelectContext(ctx, &results, query)
+	err := r.db.SelectContext(
+		ctx,
+		&results,
+		query,
+		filter.Granularity,
+		report.WallClock(filter.From),
+		report.WallClock(filter.To),
+		filter.Timezone,
+		filter.From.UTC(),
+		filter.To.UTC(),
+	)
 	if err != nil {
 		return nil, errx.ErrInternalServer.WithLocation("feedbackRepository.GetSatisfactionTrend").WithError(err)
 	}
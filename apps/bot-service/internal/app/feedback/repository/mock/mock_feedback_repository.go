@@ -56,6 +56,20 @@ func (mr *MockFeedbackRepositoryMockRecorder) Create(ctx, feedback any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFeedbackRepository)(nil).Create), ctx, feedback)
 }
 
+// Each mocks base method.
+func (m *MockFeedbackRepository) Each(ctx context.Context, filter *entity.GetFeedbacksFilter, fn func(*entity.Feedback) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockFeedbackRepositoryMockRecorder) Each(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockFeedbackRepository)(nil).Each), ctx, filter, fn)
+}
+
 // FindByID mocks base method.
 func (m *MockFeedbackRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Feedback, error) {
 	m.ctrl.T.Helper()
@@ -71,35 +85,50 @@ func (mr *MockFeedbackRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockFeedbackRepository)(nil).FindByID), ctx, id)
 }
 
+// GetBreakdown mocks base method.
+func (m *MockFeedbackRepository) GetBreakdown(ctx context.Context, filter *entity.FeedbackBreakdownFilter) ([]entity.FeedbackBreakdownRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBreakdown", ctx, filter)
+	ret0, _ := ret[0].([]entity.FeedbackBreakdownRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBreakdown indicates an expected call of GetBreakdown.
+func (mr *MockFeedbackRepositoryMockRecorder) GetBreakdown(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBreakdown", reflect.TypeOf((*MockFeedbackRepository)(nil).GetBreakdown), ctx, filter)
+}
+
 // GetMetrics mocks base method.
-func (m *MockFeedbackRepository) GetMetrics(ctx context.Context) (float64, int, error) {
+func (m *MockFeedbackRepository) GetMetrics(ctx context.Context, filter *entity.FeedbackMetricsFilter) (*entity.FeedbackMetrics, *entity.FeedbackMetrics, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMetrics", ctx)
-	ret0, _ := ret[0].(float64)
-	ret1, _ := ret[1].(int)
+	ret := m.ctrl.Call(m, "GetMetrics", ctx, filter)
+	ret0, _ := ret[0].(*entity.FeedbackMetrics)
+	ret1, _ := ret[1].(*entity.FeedbackMetrics)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetMetrics indicates an expected call of GetMetrics.
-func (mr *MockFeedbackRepositoryMockRecorder) GetMetrics(ctx any) *gomock.Call {
+func (mr *MockFeedbackRepositoryMockRecorder) GetMetrics(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockFeedbackRepository)(nil).GetMetrics), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockFeedbackRepository)(nil).GetMetrics), ctx, filter)
 }
 
 // GetSatisfactionTrend mocks base method.
-func (m *MockFeedbackRepository) GetSatisfactionTrend(ctx context.Context) ([]entity.SatisfactionTrendRow, error) {
+func (m *MockFeedbackRepository) GetSatisfactionTrend(ctx context.Context, filter *entity.SatisfactionTrendFilter) ([]entity.SatisfactionTrendRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSatisfactionTrend", ctx)
+	ret := m.ctrl.Call(m, "GetSatisfactionTrend", ctx, filter)
 	ret0, _ := ret[0].([]entity.SatisfactionTrendRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetSatisfactionTrend indicates an expected call of GetSatisfactionTrend.
-func (mr *MockFeedbackRepositoryMockRecorder) GetSatisfactionTrend(ctx any) *gomock.Call {
+func (mr *MockFeedbackRepositoryMockRecorder) GetSatisfactionTrend(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSatisfactionTrend", reflect.TypeOf((*MockFeedbackRepository)(nil).GetSatisfactionTrend), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSatisfactionTrend", reflect.TypeOf((*MockFeedbackRepository)(nil).GetSatisfactionTrend), ctx, filter)
 }
 
 // List mocks base method.
@@ -117,3 +146,19 @@ func (mr *MockFeedbackRepositoryMockRecorder) List(ctx, filter any) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFeedbackRepository)(nil).List), ctx, filter)
 }
+
+// ListByCursor mocks base method.
+func (m *MockFeedbackRepository) ListByCursor(ctx context.Context, filter *entity.GetFeedbacksFilter) ([]entity.Feedback, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", ctx, filter)
+	ret0, _ := ret[0].([]entity.Feedback)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockFeedbackRepositoryMockRecorder) ListByCursor(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockFeedbackRepository)(nil).ListByCursor), ctx, filter)
+}
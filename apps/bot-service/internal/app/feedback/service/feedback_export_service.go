@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/report"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
+	"github.com/google/uuid"
+)
+
+var feedbackExportColumns = []string{
+	"id",
+	"created_at",
+	"rating",
+	"source",
+	"comment",
+	"user_name",
+	"user_phone_number",
+	"user_job_title",
+}
+
+// Export returns every feedback matching query as a CSV or Excel file, one
+// row per feedback with the user who gave it. Feedbacks are written as
+// they're read from the database rather than loaded all at once.
+func (s *FeedbackService) Export(ctx context.Context, query *dto.ExportFeedbacksQuery) (*dto.ExportFile, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	format := query.Format
+	if format == "" {
+		format = tabular.FormatCSV
+	}
+
+	var userID *uuid.UUID
+	if query.UserID != nil {
+		parsedUserID, err := s.uuidPkg.Parse(*query.UserID)
+		if err != nil {
+			return nil, errx.ErrUserNotFound.WithDetails(map[string]any{
+				"user_id": *query.UserID,
+			}).WithLocation("FeedbackService.Export").WithError(err)
+		}
+		userID = &parsedUserID
+	}
+
+	createdFrom, createdTo, err := report.ParseFilterRange(query.From, query.To, query.Timezone, "FeedbackService.Export")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.GetFeedbacksFilter{
+		UserID:      userID,
+		Ratings:     query.Ratings,
+		MinRating:   query.MinRating,
+		MaxRating:   query.MaxRating,
+		JobTitle:    query.JobTitle,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+	}
+
+	res := &dto.ExportFile{
+		FileName:    fmt.Sprintf("feedbacks-%s.%s", time.Now().Format("20060102"), format),
+		ContentType: tabular.ContentType(format),
+		Write: func(ctx context.Context, w io.Writer) error {
+			return s.writeFeedbacks(ctx, format, &filter, w)
+		},
+	}
+
+	return res, nil
+}
+
+func (s *FeedbackService) writeFeedbacks(ctx context.Context, format string, filter *entity.GetFeedbacksFilter, w io.Writer) error {
+	writer, err := s.tabularPkg.NewWriter(format, w)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("FeedbackService.Export.NewWriter").WithError(err)
+	}
+
+	if err := writer.WriteRow(feedbackExportColumns); err != nil {
+		writer.Discard()
+		return errx.ErrInternalServer.WithLocation("FeedbackService.Export.WriteHeader").WithError(err)
+	}
+
+	err = s.feedbackRepo.Each(ctx, filter, func(feedback *entity.Feedback) error {
+		if err := writer.WriteRow(feedbackExportRecord(feedback)); err != nil {
+			return errx.ErrInternalServer.WithLocation("FeedbackService.Export.WriteRow").WithError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		writer.Discard()
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return errx.ErrInternalServer.WithLocation("FeedbackService.Export.Close").WithError(err)
+	}
+
+	return nil
+}
+
+func feedbackExportRecord(feedback *entity.Feedback) []string {
+	var comment, jobTitle string
+	if feedback.Comment != nil {
+		comment = *feedback.Comment
+	}
+	if feedback.User.JobTitle != nil {
+		jobTitle = *feedback.User.JobTitle
+	}
+
+	return []string{
+		feedback.ID.String(),
+		// Spreadsheets read this layout as a date and time
+		feedback.CreatedAt.Format(time.DateTime),
+		strconv.Itoa(feedback.Rating),
+		feedback.Source,
+		escapeFormula(comment),
+		escapeFormula(feedback.User.Name),
+		feedback.User.PhoneNumber,
+		escapeFormula(jobTitle),
+	}
+}
+
+// escapeFormula keeps a spreadsheet from running free text as a formula by
+// putting a ' in front of text that starts like one
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
@@ -0,0 +1,218 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	conversationSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/service/mock"
+	feedbackRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/feedback/repository/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
+	mockTabular "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFeedbackService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
+	ctx := context.Background()
+
+	testUserID := uuid.New()
+	testUserIDStr := testUserID.String()
+	feedbackID := uuid.MustParse("0193a0e4-0000-7000-8000-000000000001")
+	jobTitle := "Engineer"
+	comment := "Quick, but \"too\" formal"
+	minRating := 3
+	from := "2025-01-01"
+	to := "2025-01-31"
+	invalidDate := "2025-02-30"
+
+	testFeedbacks := []entity.Feedback{
+		{
+			ID:        feedbackID,
+			UserID:    testUserID,
+			Rating:    4,
+			Comment:   &comment,
+			Source:    entity.FeedbackSourceUser,
+			CreatedAt: time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC),
+			User: entity.User{
+				ID:          testUserID,
+				PhoneNumber: "+6281234567890",
+				Name:        "Siti",
+				JobTitle:    &jobTitle,
+			},
+		},
+	}
+
+	// The real CSV writer, so the test checks the file that's written
+	newCSVWriter := func(format string, w io.Writer) (tabular.Writer, error) {
+		return tabular.Tabular.NewWriter(format, w)
+	}
+
+	tests := []struct {
+		name         string
+		query        *dto.ExportFeedbacksQuery
+		setup        func()
+		wantErr      bool
+		errType      error
+		wantWriteErr error
+		wantFile     string
+	}{
+		{
+			name: "success - with filters",
+			query: &dto.ExportFeedbacksQuery{
+				UserID:    &testUserIDStr,
+				JobTitle:  &jobTitle,
+				MinRating: &minRating,
+				From:      &from,
+				To:        &to,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testUserIDStr).Return(testUserID, nil)
+				mockTabular.EXPECT().NewWriter(tabular.FormatCSV, gomock.Any()).DoAndReturn(newCSVWriter)
+				mockFeedbackRepo.EXPECT().Each(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetFeedbacksFilter, fn func(feedback *entity.Feedback) error) error {
+					assert.Equal(t, &testUserID, filter.UserID)
+					assert.Equal(t, &jobTitle, filter.JobTitle)
+					assert.Equal(t, &minRating, filter.MinRating)
+					// Midnights in Asia/Jakarta, and "to" is inclusive
+					assert.Equal(t, time.Date(2024, 12, 31, 17, 0, 0, 0, time.UTC), filter.CreatedFrom.UTC())
+					assert.Equal(t, time.Date(2025, 1, 31, 17, 0, 0, 0, time.UTC), filter.CreatedTo.UTC())
+					for i := range testFeedbacks {
+						if err := fn(&testFeedbacks[i]); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+			wantErr: false,
+			wantFile: "id,created_at,rating,source,comment,user_name,user_phone_number,user_job_title\n" +
+				feedbackID.String() + ",2025-01-15 09:30:00,4,user,\"Quick, but \"\"too\"\" formal\",Siti,+6281234567890,Engineer\n",
+		},
+		{
+			name:  "success - formula-like text is written as text",
+			query: &dto.ExportFeedbacksQuery{},
+			setup: func() {
+				formula := "=HYPERLINK(\"http://example.com\")"
+				formulaJobTitle := "-1+2"
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTabular.EXPECT().NewWriter(tabular.FormatCSV, gomock.Any()).DoAndReturn(newCSVWriter)
+				mockFeedbackRepo.EXPECT().Each(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetFeedbacksFilter, fn func(feedback *entity.Feedback) error) error {
+					return fn(&entity.Feedback{
+						ID:        feedbackID,
+						UserID:    testUserID,
+						Rating:    1,
+						Comment:   &formula,
+						Source:    entity.FeedbackSourceUser,
+						CreatedAt: time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC),
+						User: entity.User{
+							ID:          testUserID,
+							PhoneNumber: "+6281234567890",
+							Name:        "@Siti",
+							JobTitle:    &formulaJobTitle,
+						},
+					})
+				})
+			},
+			wantErr: false,
+			wantFile: "id,created_at,rating,source,comment,user_name,user_phone_number,user_job_title\n" +
+				feedbackID.String() + ",2025-01-15 09:30:00,1,user,\"'=HYPERLINK(\"\"http://example.com\"\")\",'@Siti,+6281234567890,'-1+2\n",
+		},
+		{
+			name:  "repository error stops the export",
+			query: &dto.ExportFeedbacksQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTabular.EXPECT().NewWriter(tabular.FormatCSV, gomock.Any()).DoAndReturn(newCSVWriter)
+				mockFeedbackRepo.EXPECT().Each(ctx, gomock.Any(), gomock.Any()).Return(errx.ErrInternalServer)
+			},
+			wantErr:      false,
+			wantWriteErr: errx.ErrInternalServer,
+		},
+		{
+			name: "validation error",
+			query: &dto.ExportFeedbacksQuery{
+				Format: "pdf",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"query.format": validator.ValidationError{
+						Message: "format must be one of [csv xlsx]",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid date",
+			query: &dto.ExportFeedbacksQuery{
+				To: &invalidDate,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateFormat,
+		},
+		{
+			name: "invalid time zone",
+			query: &dto.ExportFeedbacksQuery{
+				From:     &from,
+				Timezone: "Mars/Olympus_Mons",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidTimezone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.Export(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Regexp(t, `^feedbacks-\d{8}\.csv$`, result.FileName)
+
+			var buf bytes.Buffer
+			err = result.Write(ctx, &buf)
+
+			if tt.wantWriteErr != nil {
+				assert.ErrorIs(t, err, tt.wantWriteErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFile, buf.String())
+			}
+		})
+	}
+}
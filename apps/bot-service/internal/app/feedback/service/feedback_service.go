@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/report"
 	"github.com/google/uuid"
 )
 
@@ -22,17 +24,36 @@ func (s *FeedbackService) Create(ctx context.Context, req *dto.CreateFeedbackReq
 		}).WithLocation("FeedbackService.Create").WithError(err)
 	}
 
+	var conversationID *uuid.UUID
+	if req.ConversationID != nil {
+		parsedConversationID, err := s.uuidPkg.Parse(*req.ConversationID)
+		if err != nil {
+			return nil, errx.ErrConversationNotFound.WithDetails(map[string]any{
+				"conversation_id": *req.ConversationID,
+			}).WithLocation("FeedbackService.Create").WithError(err)
+		}
+		conversationID = &parsedConversationID
+	}
+
+	source := req.Source
+	if source == "" {
+		source = entity.FeedbackSourceUser
+	}
+
 	id, err := s.uuidPkg.NewV7()
 	if err != nil {
 		return nil, errx.ErrInternalServer.WithLocation("FeedbackService.Create").WithError(err)
 	}
 
 	feedback := &entity.Feedback{
-		ID:        id,
-		UserID:    userID,
-		Rating:    req.Rating,
-		Comment:   req.Comment,
-		CreatedAt: time.Now(),
+		ID:                 id,
+		UserID:             userID,
+		ConversationID:     conversationID,
+		DifyConversationID: req.DifyConversationID,
+		Rating:             req.Rating,
+		Comment:            req.Comment,
+		Source:             source,
+		CreatedAt:          time.Now().UTC(),
 	}
 
 	if err := s.feedbackRepo.Create(ctx, feedback); err != nil {
@@ -72,6 +93,34 @@ func (s *FeedbackService) GetByID(ctx context.Context, param *dto.GetFeedbackByI
 	return res, nil
 }
 
+func (s *FeedbackService) GetConversation(ctx context.Context, param *dto.GetFeedbackConversationParam) (*dto.GetConversationByIDResponse, error) {
+	if err := s.validator.Validate(param); err != nil {
+		return nil, err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return nil, errx.ErrFeedbackNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("FeedbackService.GetConversation").WithError(err)
+	}
+
+	feedback, err := s.feedbackRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if feedback.ConversationID == nil {
+		return nil, errx.ErrFeedbackHasNoConversation.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("FeedbackService.GetConversation")
+	}
+
+	return s.conversationSvc.GetByID(ctx, &dto.GetConversationByIDParam{
+		ID: feedback.ConversationID.String(),
+	})
+}
+
 func (s *FeedbackService) List(ctx context.Context, query *dto.GetFeedbacksQuery) (*dto.GetFeedbacksResponse, error) {
 	if err := s.validator.Validate(query); err != nil {
 		return nil, err
@@ -91,13 +140,37 @@ func (s *FeedbackService) List(ctx context.Context, query *dto.GetFeedbacksQuery
 		userID = &parsedUserID
 	}
 
+	createdFrom, createdTo, err := report.ParseFilterRange(query.CreatedFrom, query.CreatedTo, query.Timezone, "FeedbackService.List")
+	if err != nil {
+		return nil, err
+	}
+
 	filter := entity.GetFeedbacksFilter{
-		Offset:    (page - 1) * limit,
-		Limit:     limit,
-		UserID:    userID,
-		Ratings:   query.Ratings,
-		MinRating: query.MinRating,
-		MaxRating: query.MaxRating,
+		Offset:      (page - 1) * limit,
+		Limit:       limit,
+		UserID:      userID,
+		Ratings:     query.Ratings,
+		MinRating:   query.MinRating,
+		MaxRating:   query.MaxRating,
+		CreatedFrom: createdFrom,
+		CreatedTo:   createdTo,
+		Search:      query.Search,
+		HasComment:  query.HasComment,
+		SortBy:      query.SortBy,
+		SortOrder:   query.SortOrder,
+	}
+
+	// Cursors are keyed on date, so they can't page through a rating sort
+	canUseCursor := filter.SortBy != entity.FeedbackSortRating
+
+	if query.Cursor != "" {
+		if !canUseCursor {
+			return nil, errx.ErrCursorRequiresDateSort.WithDetails(map[string]any{
+				"sortBy": filter.SortBy,
+			}).WithLocation("FeedbackService.List")
+		}
+
+		return s.listByCursor(ctx, query.Cursor, &filter)
 	}
 
 	feedbacks, total, err := s.feedbackRepo.List(ctx, &filter)
@@ -105,52 +178,266 @@ func (s *FeedbackService) List(ctx context.Context, query *dto.GetFeedbacksQuery
 		return nil, err
 	}
 
+	paginationResponse := dto.NewPaginationResponse(total, page, limit)
+
+	res := &dto.GetFeedbacksResponse{
+		Feedbacks: toFeedbackResponses(feedbacks),
+	}
+
+	res.Meta.Pagination = &paginationResponse
+
+	// Lets the next page be fetched by cursor, past the offset limit
+	if canUseCursor && len(feedbacks) > 0 && int64(filter.Offset+len(feedbacks)) < total {
+		last := feedbacks[len(feedbacks)-1]
+		nextCursor := dto.EncodeCursor(last.CreatedAt, last.ID)
+		res.Meta.NextCursor = &nextCursor
+	}
+
+	return res, nil
+}
+
+func (s *FeedbackService) listByCursor(ctx context.Context, cursorValue string, filter *entity.GetFeedbacksFilter) (*dto.GetFeedbacksResponse, error) {
+	cursor, err := dto.DecodeCursor(cursorValue)
+	if err != nil {
+		return nil, errx.ErrInvalidCursor.WithDetails(map[string]any{
+			"cursor": cursorValue,
+		}).WithLocation("FeedbackService.List").WithError(err)
+	}
+
+	filter.Offset = 0
+	filter.After = cursor
+
+	feedbacks, hasMore, err := s.feedbackRepo.ListByCursor(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.GetFeedbacksResponse{
+		Feedbacks: toFeedbackResponses(feedbacks),
+	}
+
+	if hasMore {
+		last := feedbacks[len(feedbacks)-1]
+		nextCursor := dto.EncodeCursor(last.CreatedAt, last.ID)
+		res.Meta.NextCursor = &nextCursor
+	}
+
+	return res, nil
+}
+
+func toFeedbackResponses(feedbacks []entity.Feedback) []dto.FeedbackResponse {
 	feedbackResponses := make([]dto.FeedbackResponse, 0, len(feedbacks))
 	for i := range feedbacks {
 		feedbackResponses = append(feedbackResponses, dto.ToFeedbackResponse(&feedbacks[i]))
 	}
 
-	paginationResponse := dto.NewPaginationResponse(total, page, limit)
+	return feedbackResponses
+}
 
-	res := &dto.GetFeedbacksResponse{
-		Feedbacks: feedbackResponses,
+func (s *FeedbackService) GetMetrics(ctx context.Context, query *dto.GetFeedbackMetricsQuery) (*dto.GetFeedbackMetricsResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
 	}
 
-	res.Meta.Pagination = paginationResponse
+	if query.From == nil && query.To == nil {
+		current, _, err := s.feedbackRepo.GetMetrics(ctx, &entity.FeedbackMetricsFilter{})
+		if err != nil {
+			return nil, err
+		}
+
+		res := &dto.GetFeedbackMetricsResponse{
+			FeedbackPeriodMetrics: dto.ToFeedbackPeriodMetrics(current),
+		}
+
+		return res, nil
+	}
+
+	loc, err := report.LoadLocation(query.Timezone, "FeedbackService.GetMetrics")
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := report.ParseRange(query.From, query.To, loc, report.LastDays(30), "FeedbackService.GetMetrics")
+	if err != nil {
+		return nil, err
+	}
+
+	previousFrom := report.PreviousStart(from, to)
+
+	filter := entity.FeedbackMetricsFilter{
+		From:         &from,
+		To:           &to,
+		PreviousFrom: &previousFrom,
+	}
+
+	current, previous, err := s.feedbackRepo.GetMetrics(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	currentMetrics := toPeriodMetrics(current, from, to)
+	previousMetrics := toPeriodMetrics(previous, previousFrom, from)
+
+	res := &dto.GetFeedbackMetricsResponse{
+		FeedbackPeriodMetrics: currentMetrics,
+		Timezone:              loc.String(),
+		Previous:              &previousMetrics,
+		Delta: &dto.FeedbackMetricsDelta{
+			SatisfactionScore: currentMetrics.SatisfactionScore - previousMetrics.SatisfactionScore,
+			TotalFeedbacks:    currentMetrics.TotalFeedbacks - previousMetrics.TotalFeedbacks,
+			NetScore:          currentMetrics.NetScore - previousMetrics.NetScore,
+			AutoSubmittedRate: currentMetrics.AutoSubmittedRate - previousMetrics.AutoSubmittedRate,
+			CommentRate:       currentMetrics.CommentRate - previousMetrics.CommentRate,
+		},
+	}
 
 	return res, nil
 }
 
-func (s *FeedbackService) GetMetrics(ctx context.Context) (*dto.GetFeedbackMetricsResponse, error) {
-	satisfactionScore, totalFeedbacks, err := s.feedbackRepo.GetMetrics(ctx)
+// toPeriodMetrics labels metrics with the inclusive dates of [from, to)
+func toPeriodMetrics(metrics *entity.FeedbackMetrics, from, to time.Time) dto.FeedbackPeriodMetrics {
+	res := dto.ToFeedbackPeriodMetrics(metrics)
+
+	fromDate, toDate := report.Dates(from, to)
+	res.From = &fromDate
+	res.To = &toDate
+
+	return res
+}
+
+// Breakdown groups with feedback from fewer users than this are hidden, so
+// nobody's ratings can be singled out
+const minBreakdownGroupSize = 5
+
+// Age bands are listed youngest first rather than by size
+var ageBandOrder = map[string]int{
+	entity.AgeBandUnder25: 0,
+	entity.AgeBand25To34:  1,
+	entity.AgeBand35To44:  2,
+	entity.AgeBand45To54:  3,
+	entity.AgeBand55Plus:  4,
+}
+
+func (s *FeedbackService) GetBreakdown(ctx context.Context, param *dto.GetFeedbackBreakdownParam, query *dto.GetFeedbackBreakdownQuery) (*dto.GetFeedbackBreakdownResponse, error) {
+	if err := s.validator.Validate(param); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	res := &dto.GetFeedbackBreakdownResponse{
+		Dimension:    param.Dimension,
+		MinGroupSize: minBreakdownGroupSize,
+	}
+
+	filter := entity.FeedbackBreakdownFilter{
+		Dimension: param.Dimension,
+	}
+	if query.From != nil || query.To != nil {
+		loc, err := report.LoadLocation(query.Timezone, "FeedbackService.GetBreakdown")
+		if err != nil {
+			return nil, err
+		}
+
+		from, to, err := report.ParseRange(query.From, query.To, loc, report.LastDays(30), "FeedbackService.GetBreakdown")
+		if err != nil {
+			return nil, err
+		}
+
+		filter.From = &from
+		filter.To = &to
+
+		fromDate, toDate := report.Dates(from, to)
+		res.From = &fromDate
+		res.To = &toDate
+		res.Timezone = loc.String()
+	}
+
+	rows, err := s.feedbackRepo.GetBreakdown(ctx, &filter)
 	if err != nil {
 		return nil, err
 	}
 
-	res := &dto.GetFeedbackMetricsResponse{
-		SatisfactionScore: satisfactionScore,
-		TotalFeedbacks:    totalFeedbacks,
+	res.Groups = make([]dto.FeedbackBreakdownGroup, 0, len(rows))
+	for i := range rows {
+		if rows[i].UserCount < minBreakdownGroupSize {
+			res.SuppressedGroups++
+			continue
+		}
+
+		res.Groups = append(res.Groups, dto.ToFeedbackBreakdownGroup(&rows[i]))
+	}
+
+	if param.Dimension == entity.BreakdownDimensionAgeBand {
+		sort.SliceStable(res.Groups, func(i, j int) bool {
+			return ageBandRank(res.Groups[i].Value) < ageBandRank(res.Groups[j].Value)
+		})
 	}
 
 	return res, nil
 }
 
-func (s *FeedbackService) GetSatisfactionTrend(ctx context.Context) (*dto.GetSatisfactionTrendResponse, error) {
-	results, err := s.feedbackRepo.GetSatisfactionTrend(ctx)
+// ageBandRank puts users without a date of birth last
+func ageBandRank(band *string) int {
+	if band == nil {
+		return len(ageBandOrder)
+	}
+
+	return ageBandOrder[*band]
+}
+
+func (s *FeedbackService) GetSatisfactionTrend(ctx context.Context, query *dto.GetSatisfactionTrendQuery) (*dto.GetSatisfactionTrendResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	granularity := query.Granularity
+	if granularity == "" {
+		granularity = entity.TrendGranularityDay
+	}
+
+	loc, err := report.LoadLocation(query.Timezone, "FeedbackService.GetSatisfactionTrend")
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := report.ParseRange(query.From, query.To, loc, func(lastDay time.Time) time.Time {
+		return report.TrendStart(granularity, lastDay)
+	}, "FeedbackService.GetSatisfactionTrend")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.SatisfactionTrendFilter{
+		Granularity: granularity,
+		Timezone:    loc.String(),
+		From:        from,
+		To:          to,
+	}
+
+	results, err := s.feedbackRepo.GetSatisfactionTrend(ctx, &filter)
 	if err != nil {
 		return nil, err
 	}
 
 	trend := make([]dto.SatisfactionTrendData, 0, len(results))
 	for _, result := range results {
+		// The bucket is a wall clock time in loc
+		date := time.Date(result.Date.Year(), result.Date.Month(), result.Date.Day(), 0, 0, 0, 0, loc)
+
 		trend = append(trend, dto.SatisfactionTrendData{
-			Date:            result.Date.Format(time.RFC3339),
+			Date:            date.Format(time.RFC3339),
 			AvgSatisfaction: result.AvgSatisfaction,
+			Count:           result.Count,
 		})
 	}
 
 	res := &dto.GetSatisfactionTrendResponse{
-		Trend: trend,
+		Granularity: granularity,
+		Timezone:    loc.String(),
+		Trend:       trend,
 	}
 
 	return res, nil
@@ -9,7 +9,9 @@ import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	conversationSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/service/mock"
 	feedbackRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/feedback/repository/mock"
+	mockTabular "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular/mock"
 	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
 	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
@@ -23,14 +25,19 @@ func TestFeedbackService_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
 
-	service := NewFeedbackService(mockFeedbackRepo, mockValidator, mockUUID)
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
 	ctx := context.Background()
 
 	testID := uuid.New()
 	testUserID := uuid.New()
+	testConversationID := uuid.New()
+	testConversationIDStr := testConversationID.String()
+	difyConversationID := "dify-conversation-1"
 	comment := "Great service!"
 
 	tests := []struct {
@@ -56,6 +63,9 @@ func TestFeedbackService_Create(t *testing.T) {
 					assert.Equal(t, testUserID, feedback.UserID)
 					assert.Equal(t, 5, feedback.Rating)
 					assert.Equal(t, &comment, feedback.Comment)
+					assert.Equal(t, entity.FeedbackSourceUser, feedback.Source)
+					// The list filters compare created_at against UTC bounds
+					assert.Equal(t, time.UTC, feedback.CreatedAt.Location())
 					return nil
 				})
 			},
@@ -81,6 +91,46 @@ func TestFeedbackService_Create(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success auto-submitted",
+			req: &dto.CreateFeedbackRequest{
+				UserID: testUserID.String(),
+				Rating: 5,
+				Source: entity.FeedbackSourceAuto,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testUserID.String()).Return(testUserID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockFeedbackRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, feedback *entity.Feedback) error {
+					assert.Equal(t, entity.FeedbackSourceAuto, feedback.Source)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "success with conversation reference",
+			req: &dto.CreateFeedbackRequest{
+				UserID:             testUserID.String(),
+				ConversationID:     &testConversationIDStr,
+				DifyConversationID: &difyConversationID,
+				Rating:             1,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testUserID.String()).Return(testUserID, nil)
+				mockUUID.EXPECT().Parse(testConversationIDStr).Return(testConversationID, nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockFeedbackRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, feedback *entity.Feedback) error {
+					assert.Equal(t, &testConversationID, feedback.ConversationID)
+					assert.Equal(t, &difyConversationID, feedback.DifyConversationID)
+					assert.Equal(t, 1, feedback.Rating)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
 		{
 			name: "validation error",
 			req: &dto.CreateFeedbackRequest{
@@ -163,10 +213,12 @@ func TestFeedbackService_GetByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
 
-	service := NewFeedbackService(mockFeedbackRepo, mockValidator, mockUUID)
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
 	ctx := context.Background()
 
 	testID := uuid.New()
@@ -268,15 +320,131 @@ func TestFeedbackService_GetByID(t *testing.T) {
 	}
 }
 
+func TestFeedbackService_GetConversation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	testUserID := uuid.New()
+	testConversationID := uuid.New()
+	now := time.Now()
+
+	linkedFeedback := &entity.Feedback{
+		ID:             testID,
+		UserID:         testUserID,
+		ConversationID: &testConversationID,
+		Rating:         1,
+		CreatedAt:      now,
+	}
+	unlinkedFeedback := &entity.Feedback{
+		ID:        testID,
+		UserID:    testUserID,
+		Rating:    5,
+		CreatedAt: now,
+	}
+	testConversation := &entity.Conversation{
+		ID:        testConversationID,
+		UserID:    testUserID,
+		StartedAt: now,
+		CreatedAt: now,
+		User:      entity.User{ID: testUserID, Name: "Test User"},
+	}
+	testMessages := []entity.Message{
+		{ID: uuid.New(), ConversationID: testConversationID, Role: entity.MessageRoleUser, Kind: entity.MessageKindText, Content: "Halo", CreatedAt: now},
+	}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockFeedbackRepo.EXPECT().FindByID(ctx, testID).Return(linkedFeedback, nil)
+				mockConversationSvc.EXPECT().GetByID(ctx, &dto.GetConversationByIDParam{ID: testConversationID.String()}).Return(&dto.GetConversationByIDResponse{
+					Conversation: dto.ToConversationResponse(testConversation),
+					Messages:     []dto.MessageResponse{dto.ToMessageResponse(&testMessages[0])},
+				}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "feedback not found",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockFeedbackRepo.EXPECT().FindByID(ctx, testID).Return(nil, errx.ErrFeedbackNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrFeedbackNotFound,
+		},
+		{
+			name: "feedback without conversation",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockFeedbackRepo.EXPECT().FindByID(ctx, testID).Return(unlinkedFeedback, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrFeedbackHasNoConversation,
+		},
+		{
+			name: "conversation deleted",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockFeedbackRepo.EXPECT().FindByID(ctx, testID).Return(linkedFeedback, nil)
+				mockConversationSvc.EXPECT().GetByID(ctx, gomock.Any()).Return(nil, errx.ErrConversationNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrConversationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.GetConversation(ctx, &dto.GetFeedbackConversationParam{ID: testID.String()})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testConversationID.String(), result.Conversation.ID)
+				assert.Len(t, result.Messages, 1)
+			}
+		})
+	}
+}
+
 func TestFeedbackService_List(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
 
-	service := NewFeedbackService(mockFeedbackRepo, mockValidator, mockUUID)
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
 	ctx := context.Background()
 
 	testUserID := uuid.New()
@@ -312,6 +480,10 @@ func TestFeedbackService_List(t *testing.T) {
 	userIDStr := testUserID.String()
 	minRating := 3
 	maxRating := 5
+	createdFrom := "2025-01-06"
+	createdTo := "2025-01-12"
+	invalidDate := "12-01-2025"
+	hasComment := true
 
 	tests := []struct {
 		name      string
@@ -462,6 +634,71 @@ func TestFeedbackService_List(t *testing.T) {
 			wantTotal: 2,
 			wantPages: 1,
 		},
+		{
+			name: "success with date range, comment search and sort",
+			query: &dto.GetFeedbacksQuery{
+				Page:        1,
+				Limit:       10,
+				Ratings:     []int{1, 2},
+				CreatedFrom: &createdFrom,
+				CreatedTo:   &createdTo,
+				Search:      "cuti",
+				HasComment:  &hasComment,
+				SortBy:      entity.FeedbackSortRating,
+				SortOrder:   entity.SortOrderAsc,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().List(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetFeedbacksFilter) ([]entity.Feedback, int64, error) {
+					assert.Equal(t, []int{1, 2}, filter.Ratings)
+					// Midnights in Asia/Jakarta, and "to" is inclusive
+					assert.Equal(t, time.Date(2025, 1, 5, 17, 0, 0, 0, time.UTC), filter.CreatedFrom.UTC())
+					assert.Equal(t, time.Date(2025, 1, 12, 17, 0, 0, 0, time.UTC), filter.CreatedTo.UTC())
+					assert.Equal(t, "cuti", filter.Search)
+					assert.Equal(t, &hasComment, filter.HasComment)
+					assert.Equal(t, entity.FeedbackSortRating, filter.SortBy)
+					assert.Equal(t, entity.SortOrderAsc, filter.SortOrder)
+					return testFeedbacks, int64(2), nil
+				})
+			},
+			wantErr:   false,
+			wantCount: 2,
+			wantPage:  1,
+			wantLimit: 10,
+			wantTotal: 2,
+			wantPages: 1,
+		},
+		{
+			name: "success with an open-ended date range in another time zone",
+			query: &dto.GetFeedbacksQuery{
+				CreatedFrom: &createdFrom,
+				Timezone:    "UTC",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().List(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetFeedbacksFilter) ([]entity.Feedback, int64, error) {
+					assert.Equal(t, time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), filter.CreatedFrom.UTC())
+					assert.Nil(t, filter.CreatedTo)
+					return testFeedbacks, int64(2), nil
+				})
+			},
+			wantErr:   false,
+			wantCount: 2,
+			wantPage:  1,
+			wantLimit: 10,
+			wantTotal: 2,
+			wantPages: 1,
+		},
+		{
+			name: "invalid date",
+			query: &dto.GetFeedbacksQuery{
+				CreatedFrom: &invalidDate,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
 		{
 			name: "empty results",
 			query: &dto.GetFeedbacksQuery{
@@ -538,55 +775,450 @@ func TestFeedbackService_List(t *testing.T) {
 	}
 }
 
+func TestFeedbackService_ListByCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	testFeedbacks := []entity.Feedback{
+		{ID: uuid.New(), UserID: uuid.New(), Rating: 2, CreatedAt: createdAt},
+		{ID: uuid.New(), UserID: uuid.New(), Rating: 1, CreatedAt: createdAt.Add(time.Minute)},
+	}
+	cursor := dto.EncodeCursor(createdAt.Add(-time.Hour), uuid.New())
+	lastCursor := dto.EncodeCursor(testFeedbacks[1].CreatedAt, testFeedbacks[1].ID)
+
+	tests := []struct {
+		name           string
+		query          *dto.GetFeedbacksQuery
+		setup          func()
+		wantErr        bool
+		errType        error
+		wantPagination bool
+		wantNextCursor *string
+	}{
+		{
+			name: "cursor mode in ascending date order",
+			query: &dto.GetFeedbacksQuery{
+				Page:      3,
+				Ratings:   []int{1, 2},
+				SortOrder: entity.SortOrderAsc,
+				Cursor:    cursor,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().ListByCursor(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetFeedbacksFilter) ([]entity.Feedback, bool, error) {
+					assert.Equal(t, 0, filter.Offset)
+					assert.Equal(t, []int{1, 2}, filter.Ratings)
+					assert.Equal(t, entity.SortOrderAsc, filter.SortOrder)
+					assert.True(t, createdAt.Add(-time.Hour).Equal(filter.After.CreatedAt))
+					return testFeedbacks, true, nil
+				})
+			},
+			wantErr:        false,
+			wantPagination: false,
+			wantNextCursor: &lastCursor,
+		},
+		{
+			name: "page mode sorted by rating has no cursor",
+			query: &dto.GetFeedbacksQuery{
+				Limit:  2,
+				SortBy: entity.FeedbackSortRating,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().List(ctx, gomock.Any()).Return(testFeedbacks, int64(50), nil)
+			},
+			wantErr:        false,
+			wantPagination: true,
+			wantNextCursor: nil,
+		},
+		{
+			name: "cursor with a rating sort",
+			query: &dto.GetFeedbacksQuery{
+				SortBy: entity.FeedbackSortRating,
+				Cursor: cursor,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrCursorRequiresDateSort,
+		},
+		{
+			name: "invalid cursor",
+			query: &dto.GetFeedbacksQuery{
+				Cursor: "bm90LWEtY3Vyc29y",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.List(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, result.Feedbacks, len(testFeedbacks))
+				assert.Equal(t, tt.wantPagination, result.Meta.Pagination != nil)
+				assert.Equal(t, tt.wantNextCursor, result.Meta.NextCursor)
+			}
+		})
+	}
+}
 func TestFeedbackService_GetMetrics(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
+	ctx := context.Background()
+
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+
+	from := "2025-12-01"
+	to := "2025-12-10"
+	farFrom := "2020-01-01"
+	invalidDate := "2025-12-32"
+
+	// 4 feedbacks: ratings 5, 5, 4 and 2, one auto-submitted, one commented
+	current := &entity.FeedbackMetrics{
+		TotalFeedbacks:     4,
+		RatingSum:          16,
+		Rating2:            1,
+		Rating4:            1,
+		Rating5:            2,
+		AutoFeedbacks:      1,
+		CommentedFeedbacks: 1,
+	}
+	// 2 feedbacks: ratings 5 and 3, both commented
+	previous := &entity.FeedbackMetrics{
+		TotalFeedbacks:     2,
+		RatingSum:          8,
+		Rating3:            1,
+		Rating5:            1,
+		CommentedFeedbacks: 2,
+	}
+
+	tests := []struct {
+		name    string
+		query   *dto.GetFeedbackMetricsQuery
+		setup   func()
+		wantErr bool
+		errType error
+		check   func(*testing.T, *dto.GetFeedbackMetricsResponse)
+	}{
+		{
+			name: "success - range compared with the previous period",
+			query: &dto.GetFeedbackMetricsQuery{
+				From:     &from,
+				To:       &to,
+				Timezone: "Asia/Makassar",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().GetMetrics(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.FeedbackMetricsFilter) (*entity.FeedbackMetrics, *entity.FeedbackMetrics, error) {
+					assert.Equal(t, "2025-12-01T00:00:00+08:00", filter.From.Format(time.RFC3339))
+					// "to" is inclusive
+					assert.Equal(t, "2025-12-11T00:00:00+08:00", filter.To.Format(time.RFC3339))
+					assert.Equal(t, "2025-11-21T00:00:00+08:00", filter.PreviousFrom.Format(time.RFC3339))
+					return current, previous, nil
+				})
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetFeedbackMetricsResponse) {
+				assert.Equal(t, "Asia/Makassar", res.Timezone)
+				assert.Equal(t, from, *res.From)
+				assert.Equal(t, to, *res.To)
+				assert.Equal(t, 80.0, res.SatisfactionScore)
+				assert.Equal(t, 4, res.TotalFeedbacks)
+				assert.Equal(t, []dto.RatingCount{
+					{Rating: 1, Count: 0},
+					{Rating: 2, Count: 1},
+					{Rating: 3, Count: 0},
+					{Rating: 4, Count: 1},
+					{Rating: 5, Count: 2},
+				}, res.RatingDistribution)
+				assert.Equal(t, 2, res.Promoters)
+				assert.Equal(t, 1, res.Passives)
+				assert.Equal(t, 1, res.Detractors)
+				assert.Equal(t, 25.0, res.NetScore)
+				assert.Equal(t, 1, res.AutoSubmitted)
+				assert.Equal(t, 3, res.UserGiven)
+				assert.Equal(t, 25.0, res.AutoSubmittedRate)
+				assert.Equal(t, 25.0, res.CommentRate)
+
+				assert.Equal(t, "2025-11-21", *res.Previous.From)
+				assert.Equal(t, "2025-11-30", *res.Previous.To)
+				assert.Equal(t, 80.0, res.Previous.SatisfactionScore)
+				assert.Equal(t, 0.0, res.Previous.NetScore)
+				assert.Equal(t, 100.0, res.Previous.CommentRate)
+
+				assert.Equal(t, 0.0, res.Delta.SatisfactionScore)
+				assert.Equal(t, 2, res.Delta.TotalFeedbacks)
+				assert.Equal(t, 25.0, res.Delta.NetScore)
+				assert.Equal(t, 25.0, res.Delta.AutoSubmittedRate)
+				assert.Equal(t, -75.0, res.Delta.CommentRate)
+			},
+		},
+		{
+			name: "success - to alone covers the 30 days up to it in Jakarta",
+			query: &dto.GetFeedbackMetricsQuery{
+				To: &to,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().GetMetrics(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.FeedbackMetricsFilter) (*entity.FeedbackMetrics, *entity.FeedbackMetrics, error) {
+					assert.True(t, time.Date(2025, 12, 11, 0, 0, 0, 0, jakarta).Equal(*filter.To))
+					assert.True(t, time.Date(2025, 11, 11, 0, 0, 0, 0, jakarta).Equal(*filter.From))
+					assert.True(t, time.Date(2025, 10, 12, 0, 0, 0, 0, jakarta).Equal(*filter.PreviousFrom))
+					return &entity.FeedbackMetrics{}, &entity.FeedbackMetrics{}, nil
+				})
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetFeedbackMetricsResponse) {
+				assert.Equal(t, "Asia/Jakarta", res.Timezone)
+				assert.Equal(t, 0.0, res.SatisfactionScore)
+				assert.Equal(t, 0.0, res.CommentRate)
+				assert.Len(t, res.RatingDistribution, 5)
+				assert.NotNil(t, res.Delta)
+			},
+		},
+		{
+			name:  "success - all time without a comparison",
+			query: &dto.GetFeedbackMetricsQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().GetMetrics(ctx, &entity.FeedbackMetricsFilter{}).Return(current, nil, nil)
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetFeedbackMetricsResponse) {
+				assert.Nil(t, res.From)
+				assert.Empty(t, res.Timezone)
+				assert.Equal(t, 80.0, res.SatisfactionScore)
+				assert.Equal(t, 4, res.TotalFeedbacks)
+				assert.Nil(t, res.Previous)
+				assert.Nil(t, res.Delta)
+			},
+		},
+		{
+			name: "range longer than two years",
+			query: &dto.GetFeedbackMetricsQuery{
+				From: &farFrom,
+				To:   &to,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateRange,
+		},
+		{
+			name: "invalid date",
+			query: &dto.GetFeedbackMetricsQuery{
+				From: &invalidDate,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateFormat,
+		},
+		{
+			name: "validation error",
+			query: &dto.GetFeedbackMetricsQuery{
+				Timezone: "Mars/Olympus",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"query.tz": validator.ValidationError{
+						Message: "tz must be a valid time zone",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetFeedbackMetricsQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().GetMetrics(ctx, gomock.Any()).Return(nil, nil, errx.ErrInternalServer)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.GetMetrics(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestFeedbackService_GetBreakdown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
 
-	service := NewFeedbackService(mockFeedbackRepo, mockValidator, mockUUID)
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
 	ctx := context.Background()
 
+	from := "2025-12-01"
+	to := "2025-12-10"
+	invalidDate := "2025-12-32"
+	fieldStaff := "Field Staff"
+	officeStaff := "Office Staff"
+	director := "Director"
+	band25To34 := entity.AgeBand25To34
+	band55Plus := entity.AgeBand55Plus
+
 	tests := []struct {
-		name             string
-		setup            func()
-		wantErr          bool
-		wantSatisfaction float64
-		wantTotal        int
+		name    string
+		param   *dto.GetFeedbackBreakdownParam
+		query   *dto.GetFeedbackBreakdownQuery
+		setup   func()
+		wantErr bool
+		errType error
+		check   func(*testing.T, *dto.GetFeedbackBreakdownResponse)
 	}{
 		{
-			name: "success with satisfaction score and total feedbacks",
+			name:  "success - groups from too few users are suppressed",
+			param: &dto.GetFeedbackBreakdownParam{Dimension: entity.BreakdownDimensionJobTitle},
+			query: &dto.GetFeedbackBreakdownQuery{
+				From:     &from,
+				To:       &to,
+				Timezone: "Asia/Makassar",
+			},
 			setup: func() {
-				mockFeedbackRepo.EXPECT().GetMetrics(ctx).Return(85.5, 42, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockFeedbackRepo.EXPECT().GetBreakdown(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.FeedbackBreakdownFilter) ([]entity.FeedbackBreakdownRow, error) {
+					assert.Equal(t, entity.BreakdownDimensionJobTitle, filter.Dimension)
+					assert.Equal(t, "2025-12-01T00:00:00+08:00", filter.From.Format(time.RFC3339))
+					assert.Equal(t, "2025-12-11T00:00:00+08:00", filter.To.Format(time.RFC3339))
+					return []entity.FeedbackBreakdownRow{
+						{Value: &fieldStaff, AvgRating: 3.5, FeedbackCount: 40, CommentedCount: 10, UserCount: 12},
+						{Value: &officeStaff, AvgRating: 4.5, FeedbackCount: 20, CommentedCount: 0, UserCount: 5},
+						// Many ratings from few people are still identifying
+						{Value: &director, AvgRating: 2, FeedbackCount: 9, CommentedCount: 9, UserCount: 1},
+						{Value: nil, AvgRating: 4, FeedbackCount: 3, CommentedCount: 1, UserCount: 3},
+					}, nil
+				})
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetFeedbackBreakdownResponse) {
+				assert.Equal(t, entity.BreakdownDimensionJobTitle, res.Dimension)
+				assert.Equal(t, from, *res.From)
+				assert.Equal(t, to, *res.To)
+				assert.Equal(t, "Asia/Makassar", res.Timezone)
+				assert.Equal(t, 5, res.MinGroupSize)
+				assert.Equal(t, 2, res.SuppressedGroups)
+				assert.Equal(t, []dto.FeedbackBreakdownGroup{
+					{Value: &fieldStaff, AvgRating: 3.5, FeedbackCount: 40, CommentRate: 25},
+					{Value: &officeStaff, AvgRating: 4.5, FeedbackCount: 20, CommentRate: 0},
+				}, res.Groups)
 			},
-			wantErr:          false,
-			wantSatisfaction: 85.5,
-			wantTotal:        42,
 		},
 		{
-			name: "success with zero satisfaction score and zero feedbacks",
+			name:  "success - age bands youngest first over all time",
+			param: &dto.GetFeedbackBreakdownParam{Dimension: entity.BreakdownDimensionAgeBand},
+			query: &dto.GetFeedbackBreakdownQuery{},
 			setup: func() {
-				mockFeedbackRepo.EXPECT().GetMetrics(ctx).Return(0.0, 0, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockFeedbackRepo.EXPECT().GetBreakdown(ctx, &entity.FeedbackBreakdownFilter{
+					Dimension: entity.BreakdownDimensionAgeBand,
+				}).Return([]entity.FeedbackBreakdownRow{
+					{Value: nil, AvgRating: 4, FeedbackCount: 30, UserCount: 10},
+					{Value: &band55Plus, AvgRating: 4.2, FeedbackCount: 20, UserCount: 8},
+					{Value: &band25To34, AvgRating: 3.8, FeedbackCount: 10, UserCount: 6},
+				}, nil)
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetFeedbackBreakdownResponse) {
+				assert.Nil(t, res.From)
+				assert.Empty(t, res.Timezone)
+				assert.Len(t, res.Groups, 3)
+				assert.Equal(t, &band25To34, res.Groups[0].Value)
+				assert.Equal(t, &band55Plus, res.Groups[1].Value)
+				assert.Nil(t, res.Groups[2].Value)
 			},
-			wantErr:          false,
-			wantSatisfaction: 0.0,
-			wantTotal:        0,
 		},
 		{
-			name: "success with high feedback count",
+			name:  "invalid date",
+			param: &dto.GetFeedbackBreakdownParam{Dimension: entity.BreakdownDimensionGender},
+			query: &dto.GetFeedbackBreakdownQuery{
+				To: &invalidDate,
+			},
 			setup: func() {
-				mockFeedbackRepo.EXPECT().GetMetrics(ctx).Return(92.3, 1500, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateFormat,
+		},
+		{
+			name:  "validation error - unknown dimension",
+			param: &dto.GetFeedbackBreakdownParam{Dimension: "religion"},
+			query: &dto.GetFeedbackBreakdownQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"param.dimension": validator.ValidationError{
+						Message: "dimension must be one of job-title gender age-band",
+					},
+				})
 			},
-			wantErr:          false,
-			wantSatisfaction: 92.3,
-			wantTotal:        1500,
+			wantErr: true,
 		},
 		{
-			name: "repository error",
+			name:  "repository error",
+			param: &dto.GetFeedbackBreakdownParam{Dimension: entity.BreakdownDimensionGender},
+			query: &dto.GetFeedbackBreakdownQuery{},
 			setup: func() {
-				mockFeedbackRepo.EXPECT().GetMetrics(ctx).Return(0.0, 0, errx.ErrInternalServer)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockFeedbackRepo.EXPECT().GetBreakdown(ctx, gomock.Any()).Return(nil, errx.ErrInternalServer)
 			},
 			wantErr: true,
 		},
@@ -595,16 +1227,18 @@ func TestFeedbackService_GetMetrics(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			result, err := service.GetMetrics(ctx)
+			result, err := service.GetBreakdown(ctx, tt.param, tt.query)
 
 			if tt.wantErr {
 				assert.Error(t, err)
 				assert.Nil(t, result)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
-				assert.Equal(t, tt.wantSatisfaction, result.SatisfactionScore)
-				assert.Equal(t, tt.wantTotal, result.TotalFeedbacks)
+				tt.check(t, result)
 			}
 		})
 	}
@@ -615,49 +1249,140 @@ func TestFeedbackService_GetSatisfactionTrend(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockFeedbackRepo := feedbackRepoMock.NewMockFeedbackRepository(ctrl)
+	mockConversationSvc := conversationSvcMock.NewMockConversationService(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
 
-	service := NewFeedbackService(mockFeedbackRepo, mockValidator, mockUUID)
+	service := NewFeedbackService(mockFeedbackRepo, mockConversationSvc, mockValidator, mockUUID, mockTabular)
 	ctx := context.Background()
 
-	testDate := time.Date(2025, 12, 6, 0, 0, 0, 0, time.UTC)
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+
+	// Buckets come back as wall clock times in the requested zone
+	testDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	from := "2025-12-03"
+	to := "2025-12-20"
+	farFrom := "2020-01-01"
+	invalidDate := "2025-12-32"
 
 	tests := []struct {
 		name       string
+		query      *dto.GetSatisfactionTrendQuery
 		setup      func()
 		wantErr    bool
+		errType    error
 		wantCount  int
 		checkFirst func(*testing.T, *dto.GetSatisfactionTrendResponse)
 	}{
 		{
-			name: "success with trend data",
+			name: "success - weekly buckets in the requested zone",
+			query: &dto.GetSatisfactionTrendQuery{
+				From:        &from,
+				To:          &to,
+				Granularity: entity.TrendGranularityWeek,
+				Timezone:    "Asia/Makassar",
+			},
 			setup: func() {
-				mockFeedbackRepo.EXPECT().GetSatisfactionTrend(ctx).Return([]entity.SatisfactionTrendRow{
-					{Date: testDate, AvgSatisfaction: 4.5},
-					{Date: testDate.AddDate(0, 0, 1), AvgSatisfaction: 4.2},
-					{Date: testDate.AddDate(0, 0, 2), AvgSatisfaction: 4.8},
-				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().GetSatisfactionTrend(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.SatisfactionTrendFilter) ([]entity.SatisfactionTrendRow, error) {
+					assert.Equal(t, entity.TrendGranularityWeek, filter.Granularity)
+					assert.Equal(t, "Asia/Makassar", filter.Timezone)
+					assert.Equal(t, "2025-12-03T00:00:00+08:00", filter.From.Format(time.RFC3339))
+					// "to" is inclusive
+					assert.Equal(t, "2025-12-21T00:00:00+08:00", filter.To.Format(time.RFC3339))
+					return []entity.SatisfactionTrendRow{
+						{Date: testDate, AvgSatisfaction: 4.5, Count: 2},
+						{Date: testDate.AddDate(0, 0, 7), AvgSatisfaction: 0, Count: 0},
+						{Date: testDate.AddDate(0, 0, 14), AvgSatisfaction: 4.8, Count: 5},
+					}, nil
+				})
 			},
 			wantErr:   false,
 			wantCount: 3,
 			checkFirst: func(t *testing.T, res *dto.GetSatisfactionTrendResponse) {
-				assert.Equal(t, testDate.Format(time.RFC3339), res.Trend[0].Date)
+				assert.Equal(t, entity.TrendGranularityWeek, res.Granularity)
+				assert.Equal(t, "Asia/Makassar", res.Timezone)
+				assert.Equal(t, "2025-12-01T00:00:00+08:00", res.Trend[0].Date)
 				assert.Equal(t, 4.5, res.Trend[0].AvgSatisfaction)
+				assert.Equal(t, 2, res.Trend[0].Count)
+				assert.Equal(t, 0, res.Trend[1].Count)
 			},
 		},
 		{
-			name: "success with empty trend data",
+			name:  "success - defaults to the last 30 days in Jakarta",
+			query: &dto.GetSatisfactionTrendQuery{},
 			setup: func() {
-				mockFeedbackRepo.EXPECT().GetSatisfactionTrend(ctx).Return([]entity.SatisfactionTrendRow{}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().GetSatisfactionTrend(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.SatisfactionTrendFilter) ([]entity.SatisfactionTrendRow, error) {
+					now := time.Now().In(jakarta)
+					tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, jakarta)
+					assert.Equal(t, entity.TrendGranularityDay, filter.Granularity)
+					assert.Equal(t, "Asia/Jakarta", filter.Timezone)
+					assert.True(t, tomorrow.Equal(filter.To))
+					assert.True(t, tomorrow.AddDate(0, 0, -31).Equal(filter.From))
+					return []entity.SatisfactionTrendRow{}, nil
+				})
 			},
 			wantErr:   false,
 			wantCount: 0,
 		},
 		{
-			name: "repository error",
+			name: "range longer than two years",
+			query: &dto.GetSatisfactionTrendQuery{
+				From: &farFrom,
+				To:   &to,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateRange,
+		},
+		{
+			name: "from after to",
+			query: &dto.GetSatisfactionTrendQuery{
+				From: &to,
+				To:   &from,
+			},
 			setup: func() {
-				mockFeedbackRepo.EXPECT().GetSatisfactionTrend(ctx).Return(nil, errx.ErrInternalServer)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateRange,
+		},
+		{
+			name: "invalid date",
+			query: &dto.GetSatisfactionTrendQuery{
+				To: &invalidDate,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateFormat,
+		},
+		{
+			name: "validation error",
+			query: &dto.GetSatisfactionTrendQuery{
+				Timezone: "Mars/Olympus",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"query.tz": validator.ValidationError{
+						Message: "tz must be a valid time zone",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetSatisfactionTrendQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockFeedbackRepo.EXPECT().GetSatisfactionTrend(ctx, gomock.Any()).Return(nil, errx.ErrInternalServer)
 			},
 			wantErr: true,
 		},
@@ -666,10 +1391,13 @@ func TestFeedbackService_GetSatisfactionTrend(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			result, err := service.GetSatisfactionTrend(ctx)
+			result, err := service.GetSatisfactionTrend(ctx, tt.query)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
 				assert.Nil(t, result)
 			} else {
 				assert.NoError(t, err)
@@ -2,24 +2,31 @@ package service
 
 import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
 )
 
 type FeedbackService struct {
-	feedbackRepo contracts.FeedbackRepository
-	validator    validator.CustomValidatorInterface
-	uuidPkg      uuid.UUIDInterface
+	feedbackRepo    contracts.FeedbackRepository
+	conversationSvc contracts.ConversationService
+	validator       validator.CustomValidatorInterface
+	uuidPkg         uuid.UUIDInterface
+	tabularPkg      tabular.CustomTabularInterface
 }
 
 func NewFeedbackService(
 	feedbackRepo contracts.FeedbackRepository,
+	conversationSvc contracts.ConversationService,
 	validatorService validator.CustomValidatorInterface,
 	uuidService uuid.UUIDInterface,
+	tabularService tabular.CustomTabularInterface,
 ) *FeedbackService {
 	return &FeedbackService{
-		feedbackRepo: feedbackRepo,
-		validator:    validatorService,
-		uuidPkg:      uuidService,
+		feedbackRepo:    feedbackRepo,
+		conversationSvc: conversationSvc,
+		validator:       validatorService,
+		uuidPkg:         uuidService,
+		tabularPkg:      tabularService,
 	}
 }
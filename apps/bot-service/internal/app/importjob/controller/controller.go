@@ -0,0 +1,27 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportJobController struct {
+	importJobSvc *service.ImportJobService
+}
+
+func InitImportJobController(router fiber.Router, importJobSvc *service.ImportJobService, middleware *middlewares.Middleware) {
+	controller := &ImportJobController{
+		importJobSvc: importJobSvc,
+	}
+
+	// Same roles as uploading an import
+	importJobRouter := router.Group(
+		"/import-jobs",
+		middleware.RequireAuth(),
+		middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin),
+	)
+
+	importJobRouter.Get("/:id", controller.getByID)
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
+	"github.com/gofiber/fiber/v2"
+)
+
+func (c *ImportJobController) getByID(ctx *fiber.Ctx) error {
+	var params dto.GetImportJobByIDParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	res, err := c.importJobSvc.GetByID(ctx.Context(), &params)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
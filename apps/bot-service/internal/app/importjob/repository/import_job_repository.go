@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/google/uuid"
+)
+
+func (r *importJobRepository) Create(ctx context.Context, job *entity.ImportJob) error {
+	query := `
+		INSERT INTO import_jobs (id, type, status, file_name, file_format, file_data, options, actor_type, actor_id, ip_address, user_agent, created_at, updated_at)
+		VALUES (:id, :type, :status, :file_name, :file_format, :file_data, :options, :actor_type, :actor_id, :ip_address, :user_agent, :created_at, :updated_at)
+	`
+
+	_, err := r.db.NamedExecContext(
+		ctx,
+		query,
+		job,
+	)
+
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("importJobRepository.Create").WithError(err)
+	}
+
+	return nil
+}
+
+// FindByID returns a job without its file
+func (r *importJobRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.ImportJob, error) {
+	query := `
+		SELECT id, type, status, file_name, file_format, options, actor_type, actor_id, ip_address, user_agent,
+			total_rows, processed_rows, result, error_code, error_message, attempts, created_at, started_at, finished_at, updated_at
+		FROM import_jobs
+		WHERE id = $1
+	`
+
+	var job entity.ImportJob
+	err := r.db.GetContext(ctx, &job, query, id)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrImportJobNotFound.WithDetails(map[string]any{
+				"id": id,
+			}).WithLocation("importJobRepository.FindByID")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("importJobRepository.FindByID").WithError(err)
+	}
+
+	return &job, nil
+}
+
+// ClaimNext skips jobs locked by other instances, so several workers can share
+// the queue
+func (r *importJobRepository) ClaimNext(ctx context.Context, now time.Time) (*entity.ImportJob, error) {
+	query := `
+		UPDATE import_jobs
+		SET status = $1, attempts = attempts + 1, started_at = $2, updated_at = $2
+		WHERE id = (
+			SELECT id
+			FROM import_jobs
+			WHERE status = $3
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, type, status, file_name, file_format, file_data, options, actor_type, actor_id, ip_address, user_agent,
+			total_rows, processed_rows, result, committed_result, error_code, error_message, attempts, created_at, started_at, finished_at, updated_at
+	`
+
+	var job entity.ImportJob
+	err := r.db.GetContext(ctx, &job, query, entity.ImportJobStatusRunning, now, entity.ImportJobStatusPending)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("importJobRepository.ClaimNext").WithError(err)
+	}
+
+	return &job, nil
+}
+
+func (r *importJobRepository) UpdateProgress(ctx context.Context, id uuid.UUID, totalRows int, processedRows int, now time.Time) error {
+	query := `
+		UPDATE import_jobs
+		SET total_rows = $1, processed_rows = $2, updated_at = $3
+		WHERE id = $4
+	`
+
+	_, err := r.db.ExecContext(ctx, query, totalRows, processedRows, now, id)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("importJobRepository.UpdateProgress").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) Complete(ctx context.Context, id uuid.UUID, result json.RawMessage, now time.Time) error {
+	query := `
+		UPDATE import_jobs
+		SET status = $1, result = $2, processed_rows = total_rows, file_data = NULL, finished_at = $3, updated_at = $3
+		WHERE id = $4
+	`
+
+	_, err := r.db.ExecContext(ctx, query, entity.ImportJobStatusCompleted, result, now, id)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("importJobRepository.Complete").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) Fail(ctx context.Context, id uuid.UUID, errorCode string, errorMessage string, now time.Time) error {
+	query := `
+		UPDATE import_jobs
+		SET status = $1, error_code = $2, error_message = $3, file_data = NULL, finished_at = $4, updated_at = $4
+		WHERE id = $5
+	`
+
+	_, err := r.db.ExecContext(ctx, query, entity.ImportJobStatusFailed, errorCode, errorMessage, now, id)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("importJobRepository.Fail").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *importJobRepository) RequeueStale(ctx context.Context, staleBefore time.Time, maxAttempts int, now time.Time) error {
+	failQuery := `
+		UPDATE import_jobs
+		SET status = $1, error_code = $2, error_message = $3, file_data = NULL, finished_at = $4, updated_at = $4
+		WHERE status = $5 AND updated_at < $6 AND attempts >= $7
+	`
+
+	_, err := r.db.ExecContext(
+		ctx,
+		failQuery,
+		entity.ImportJobStatusFailed,
+		errx.ErrImportJobInterrupted.ErrorCode,
+		errx.ErrImportJobInterrupted.Message,
+		now,
+		entity.ImportJobStatusRunning,
+		staleBefore,
+		maxAttempts,
+	)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("importJobRepository.RequeueStale.Fail").WithError(err)
+	}
+
+	requeueQuery := `
+		UPDATE import_jobs
+		SET status = $1, updated_at = $2
+		WHERE status = $3 AND updated_at < $4
+	`
+
+	_, err = r.db.ExecContext(ctx, requeueQuery, entity.ImportJobStatusPending, now, entity.ImportJobStatusRunning, staleBefore)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("importJobRepository.RequeueStale.Requeue").WithError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/jmoiron/sqlx"
+)
+
+type importJobRepository struct {
+	db *sqlx.DB
+}
+
+func NewImportJobRepository(db *sqlx.DB) contracts.ImportJobRepository {
+	return &importJobRepository{db: db}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
+)
+
+const (
+	importJobPollInterval = 2 * time.Second
+	// Running jobs that haven't reported progress for this long were cut
+	// short, usually by a restart
+	importJobStaleAfter  = 15 * time.Minute
+	importJobMaxAttempts = 3
+)
+
+func (s *ImportJobService) GetByID(ctx context.Context, param *dto.GetImportJobByIDParam) (*dto.GetImportJobByIDResponse, error) {
+	if err := s.validator.Validate(param); err != nil {
+		return nil, err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return nil, errx.ErrImportJobNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("ImportJobService.GetByID").WithError(err)
+	}
+
+	job, err := s.importJobRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.GetImportJobByIDResponse{
+		ImportJob: dto.ToImportJobResponse(job),
+	}
+
+	return res, nil
+}
+
+// Run processes queued jobs one at a time until ctx is done
+func (s *ImportJobService) Run(ctx context.Context) {
+	ticker := time.NewTicker(importJobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.processPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ImportJobService) processPending(ctx context.Context) {
+	now := time.Now().UTC()
+	if err := s.importJobRepo.RequeueStale(ctx, now.Add(-importJobStaleAfter), importJobMaxAttempts, now); err != nil {
+		log.Error(log.CustomLogInfo{
+			"error": err.Error(),
+		}, "[ImportJobs] Failed to requeue stale jobs")
+	}
+
+	for ctx.Err() == nil {
+		processed, err := s.ProcessNext(ctx)
+		if err != nil {
+			log.Error(log.CustomLogInfo{
+				"error": err.Error(),
+			}, "[ImportJobs] Failed to process job")
+			return
+		}
+
+		if !processed {
+			return
+		}
+	}
+}
+
+// ProcessNext runs the oldest pending job and reports whether there was one.
+// A job that fails is marked as failed rather than returning an error.
+func (s *ImportJobService) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := s.importJobRepo.ClaimNext(ctx, time.Now().UTC())
+	if err != nil {
+		return false, err
+	}
+
+	if job == nil {
+		return false, nil
+	}
+
+	result, err := s.runJob(ctx, job)
+	if err != nil {
+		// Shutting down; the job is picked up again once it's stale
+		if ctx.Err() != nil {
+			return true, ctx.Err()
+		}
+
+		var reqErr *errx.RequestError
+		if !errors.As(err, &reqErr) {
+			reqErr = errx.ErrInternalServer
+		}
+
+		log.Error(log.CustomLogInfo{
+			"job_id": job.ID,
+			"type":   job.Type,
+			"error":  err.Error(),
+		}, "[ImportJobs] Job failed")
+
+		if err := s.importJobRepo.Fail(ctx, job.ID, reqErr.ErrorCode, reqErr.Message, time.Now().UTC()); err != nil {
+			return true, err
+		}
+
+		return true, nil
+	}
+
+	if err := s.importJobRepo.Complete(ctx, job.ID, result, time.Now().UTC()); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+func (s *ImportJobService) runJob(ctx context.Context, job *entity.ImportJob) (json.RawMessage, error) {
+	onProgress := func(totalRows int, processedRows int) {
+		if err := s.importJobRepo.UpdateProgress(ctx, job.ID, totalRows, processedRows, time.Now().UTC()); err != nil {
+			log.Warn(log.CustomLogInfo{
+				"job_id": job.ID,
+				"error":  err.Error(),
+			}, "[ImportJobs] Failed to update progress")
+		}
+	}
+
+	switch job.Type {
+	case entity.ImportJobTypeUsers:
+		res, err := s.userSvc.RunImportJob(ctx, job, onProgress)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := json.Marshal(res)
+		if err != nil {
+			return nil, errx.ErrInternalServer.WithLocation("ImportJobService.runJob").WithError(err)
+		}
+
+		return result, nil
+	default:
+		return nil, errx.ErrInternalServer.WithDetails(map[string]any{
+			"type": job.Type,
+		}).WithLocation("ImportJobService.runJob")
+	}
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	importJobRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/repository/mock"
+	userSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/service/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportJobService_GetByID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
+	mockUserSvc := userSvcMock.NewMockUserService(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewImportJobService(mockImportJobRepo, mockUserSvc, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	errorCode := errx.ErrCSVNoData.ErrorCode
+	errorMessage := errx.ErrCSVNoData.Message
+	finishedAt := time.Now()
+
+	tests := []struct {
+		name    string
+		param   *dto.GetImportJobByIDParam
+		setup   func()
+		wantErr bool
+		errType error
+		check   func(t *testing.T, res *dto.GetImportJobByIDResponse)
+	}{
+		{
+			name: "success - completed job",
+			param: &dto.GetImportJobByIDParam{
+				ID: testID.String(),
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockImportJobRepo.EXPECT().FindByID(ctx, testID).Return(&entity.ImportJob{
+					ID:            testID,
+					Type:          entity.ImportJobTypeUsers,
+					Status:        entity.ImportJobStatusCompleted,
+					FileName:      "roster.xlsx",
+					TotalRows:     2,
+					ProcessedRows: 2,
+					Result:        json.RawMessage(`{"committed":true}`),
+					CreatedAt:     time.Now(),
+					FinishedAt:    &finishedAt,
+				}, nil)
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetImportJobByIDResponse) {
+				assert.Equal(t, entity.ImportJobStatusCompleted, res.ImportJob.Status)
+				assert.Equal(t, 2, res.ImportJob.ProcessedRows)
+				assert.JSONEq(t, `{"committed":true}`, string(res.ImportJob.Result))
+				assert.Nil(t, res.ImportJob.Error)
+				assert.NotNil(t, res.ImportJob.FinishedAt)
+			},
+		},
+		{
+			name: "success - failed job",
+			param: &dto.GetImportJobByIDParam{
+				ID: testID.String(),
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockImportJobRepo.EXPECT().FindByID(ctx, testID).Return(&entity.ImportJob{
+					ID:           testID,
+					Type:         entity.ImportJobTypeUsers,
+					Status:       entity.ImportJobStatusFailed,
+					ErrorCode:    &errorCode,
+					ErrorMessage: &errorMessage,
+					CreatedAt:    time.Now(),
+				}, nil)
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetImportJobByIDResponse) {
+				assert.Equal(t, entity.ImportJobStatusFailed, res.ImportJob.Status)
+				assert.Equal(t, &dto.ImportJobErrorResponse{Code: errorCode, Message: errorMessage}, res.ImportJob.Error)
+				assert.Nil(t, res.ImportJob.Result)
+			},
+		},
+		{
+			name: "validation error",
+			param: &dto.GetImportJobByIDParam{
+				ID: "invalid",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"param.id": validator.ValidationError{
+						Message: "id must be a valid UUID",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name: "not found",
+			param: &dto.GetImportJobByIDParam{
+				ID: testID.String(),
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockImportJobRepo.EXPECT().FindByID(ctx, testID).Return(nil, errx.ErrImportJobNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrImportJobNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.GetByID(ctx, tt.param)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testID.String(), result.ImportJob.ID)
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestImportJobService_ProcessNext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
+	mockUserSvc := userSvcMock.NewMockUserService(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewImportJobService(mockImportJobRepo, mockUserSvc, mockValidator, mockUUID)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	job := &entity.ImportJob{
+		ID:     testID,
+		Type:   entity.ImportJobTypeUsers,
+		Status: entity.ImportJobStatusRunning,
+	}
+
+	tests := []struct {
+		name          string
+		setup         func()
+		wantErr       bool
+		wantProcessed bool
+	}{
+		{
+			name: "no pending job",
+			setup: func() {
+				mockImportJobRepo.EXPECT().ClaimNext(ctx, gomock.Any()).Return(nil, nil)
+			},
+			wantErr:       false,
+			wantProcessed: false,
+		},
+		{
+			name: "job completes with progress",
+			setup: func() {
+				mockImportJobRepo.EXPECT().ClaimNext(ctx, gomock.Any()).Return(job, nil)
+				mockUserSvc.EXPECT().RunImportJob(ctx, job, gomock.Any()).DoAndReturn(func(ctx context.Context, job *entity.ImportJob, onProgress func(int, int)) (*dto.ImportUsersResponse, error) {
+					onProgress(2, 0)
+					return &dto.ImportUsersResponse{Committed: true, TotalRows: 2, Imported: 2}, nil
+				})
+				mockImportJobRepo.EXPECT().UpdateProgress(ctx, testID, 2, 0, gomock.Any()).Return(nil)
+				mockImportJobRepo.EXPECT().Complete(ctx, testID, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id uuid.UUID, result json.RawMessage, now time.Time) error {
+					var res dto.ImportUsersResponse
+					assert.NoError(t, json.Unmarshal(result, &res))
+					assert.True(t, res.Committed)
+					assert.Equal(t, 2, res.Imported)
+					return nil
+				})
+			},
+			wantErr:       false,
+			wantProcessed: true,
+		},
+		{
+			name: "job fails with the error code",
+			setup: func() {
+				mockImportJobRepo.EXPECT().ClaimNext(ctx, gomock.Any()).Return(job, nil)
+				mockUserSvc.EXPECT().RunImportJob(ctx, job, gomock.Any()).Return(nil, errx.ErrInvalidCSVStructure.WithLocation("UserService.RunImportJob"))
+				mockImportJobRepo.EXPECT().Fail(ctx, testID, errx.ErrInvalidCSVStructure.ErrorCode, errx.ErrInvalidCSVStructure.Message, gomock.Any()).Return(nil)
+			},
+			wantErr:       false,
+			wantProcessed: true,
+		},
+		{
+			name: "unknown job type fails",
+			setup: func() {
+				mockImportJobRepo.EXPECT().ClaimNext(ctx, gomock.Any()).Return(&entity.ImportJob{ID: testID, Type: "topics"}, nil)
+				mockImportJobRepo.EXPECT().Fail(ctx, testID, errx.ErrInternalServer.ErrorCode, errx.ErrInternalServer.Message, gomock.Any()).Return(nil)
+			},
+			wantErr:       false,
+			wantProcessed: true,
+		},
+		{
+			name: "repository error",
+			setup: func() {
+				mockImportJobRepo.EXPECT().ClaimNext(ctx, gomock.Any()).Return(nil, errx.ErrInternalServer)
+			},
+			wantErr:       true,
+			wantProcessed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			processed, err := service.ProcessNext(ctx)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantProcessed, processed)
+		})
+	}
+}
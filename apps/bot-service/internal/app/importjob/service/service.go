@@ -0,0 +1,23 @@
+package service
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+)
+
+type ImportJobService struct {
+	importJobRepo contracts.ImportJobRepository
+	userSvc       contracts.UserService
+	validator     validator.CustomValidatorInterface
+	uuidPkg       uuid.UUIDInterface
+}
+
+func NewImportJobService(importJobRepo contracts.ImportJobRepository, userSvc contracts.UserService, validatorService validator.CustomValidatorInterface, uuidService uuid.UUIDInterface) *ImportJobService {
+	return &ImportJobService{
+		importJobRepo: importJobRepo,
+		userSvc:       userSvc,
+		validator:     validatorService,
+		uuidPkg:       uuidService,
+	}
+}
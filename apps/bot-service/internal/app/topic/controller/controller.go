@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/service"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
 	"github.com/gofiber/fiber/v2"
@@ -17,8 +18,22 @@ func InitTopicController(router fiber.Router, topicSvc *service.TopicService, mi
 
 	topicRouter := router.Group("/topics")
 
-	// TODO: Add middleware for authentication and authorization
-	topicRouter.Post("/bulk", controller.bulkCreate)
-	topicRouter.Get("/hot", controller.getHotTopics)
-	topicRouter.Get("/count", controller.getTopicsCount)
+	requireAuth := middleware.RequireAuth()
+	canRead := middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin, entity.AdminRoleViewer)
+	canManage := middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin)
+
+	// Topics are pushed by the Dify workflow, not by dashboard users
+	topicRouter.Post("/bulk", middleware.APIKeyAuth(entity.APIKeyScopeTopicsWrite), controller.bulkCreate)
+	topicRouter.Get("/hot", requireAuth, canRead, controller.getHotTopics)
+	topicRouter.Get("/count", requireAuth, canRead, controller.getTopicsCount)
+	topicRouter.Get("/series", requireAuth, canRead, controller.getTopicSeries)
+
+	// The catalogue decides which titles count as the same topic
+	topicRouter.Get("/catalog", requireAuth, canManage, controller.listCanonicalTopics)
+	topicRouter.Patch("/catalog/:id", requireAuth, canManage, controller.renameCanonicalTopic)
+	topicRouter.Post("/catalog/:id/merge", requireAuth, canManage, controller.mergeCanonicalTopics)
+
+	// Each /bulk call is a batch, kept so a bad one can be undone
+	topicRouter.Get("/batches", requireAuth, canManage, controller.listTopicBatches)
+	topicRouter.Post("/batches/:id/rollback", requireAuth, canManage, controller.rollbackTopicBatch)
 }
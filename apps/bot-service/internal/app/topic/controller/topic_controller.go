@@ -2,6 +2,7 @@ package controller
 
 import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
 	"github.com/gofiber/fiber/v2"
 )
@@ -12,16 +13,25 @@ func (c *TopicController) bulkCreate(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	err := c.topicSvc.BulkCreate(ctx.Context(), &req)
+	res, err := c.topicSvc.BulkCreate(ctx.Context(), middlewares.GetAuditActor(ctx), &req)
 	if err != nil {
 		return err
 	}
 
-	return response.SendResponse(ctx, fiber.StatusCreated, nil)
+	if res.Replayed {
+		return response.SendResponse(ctx, fiber.StatusOK, res)
+	}
+
+	return response.SendResponse(ctx, fiber.StatusCreated, res)
 }
 
 func (c *TopicController) getHotTopics(ctx *fiber.Ctx) error {
-	res, err := c.topicSvc.GetHotTopics(ctx.Context())
+	var query dto.GetHotTopicsQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.topicSvc.GetHotTopics(ctx.Context(), &query)
 	if err != nil {
 		return err
 	}
@@ -30,7 +40,106 @@ func (c *TopicController) getHotTopics(ctx *fiber.Ctx) error {
 }
 
 func (c *TopicController) getTopicsCount(ctx *fiber.Ctx) error {
-	res, err := c.topicSvc.GetTopicsCount(ctx.Context())
+	var query dto.GetTopicsCountQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.topicSvc.GetTopicsCount(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *TopicController) getTopicSeries(ctx *fiber.Ctx) error {
+	var query dto.GetTopicSeriesQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.topicSvc.GetTopicSeries(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *TopicController) listCanonicalTopics(ctx *fiber.Ctx) error {
+	var query dto.GetCanonicalTopicsQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.topicSvc.ListCanonicalTopics(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *TopicController) renameCanonicalTopic(ctx *fiber.Ctx) error {
+	var params dto.CanonicalTopicParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	var req dto.RenameCanonicalTopicRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return err
+	}
+
+	res, err := c.topicSvc.RenameCanonicalTopic(ctx.Context(), middlewares.GetAuditActor(ctx), &params, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *TopicController) mergeCanonicalTopics(ctx *fiber.Ctx) error {
+	var params dto.CanonicalTopicParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	var req dto.MergeCanonicalTopicsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return err
+	}
+
+	res, err := c.topicSvc.MergeCanonicalTopics(ctx.Context(), middlewares.GetAuditActor(ctx), &params, &req)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *TopicController) listTopicBatches(ctx *fiber.Ctx) error {
+	var query dto.GetTopicBatchesQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	res, err := c.topicSvc.ListTopicBatches(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendResponse(ctx, fiber.StatusOK, res)
+}
+
+func (c *TopicController) rollbackTopicBatch(ctx *fiber.Ctx) error {
+	var params dto.TopicBatchParam
+	if err := ctx.ParamsParser(&params); err != nil {
+		return err
+	}
+
+	res, err := c.topicSvc.RollbackTopicBatch(ctx.Context(), middlewares.GetAuditActor(ctx), &params)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// lockAliases makes ingestion, renames and merges take turns, so a mention
+// can't be attributed to a topic that is being merged away. Reads go on.
+func lockAliases(ctx context.Context, tx *sqlx.Tx) error {
+	_, err := tx.ExecContext(ctx, "LOCK TABLE topic_aliases IN SHARE ROW EXCLUSIVE MODE")
+	return err
+}
+
+func (r *topicRepository) FindCanonicalTopicByID(ctx context.Context, id uuid.UUID) (*entity.CanonicalTopic, error) {
+	var topic entity.CanonicalTopic
+	err := r.db.GetContext(ctx, &topic, `
+		SELECT id, name, created_at, updated_at
+		FROM canonical_topics
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrTopicNotFound.WithDetails(map[string]any{
+				"id": id,
+			}).WithLocation("topicRepository.FindCanonicalTopicByID")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("topicRepository.FindCanonicalTopicByID").WithError(err)
+	}
+
+	return &topic, nil
+}
+
+func (r *topicRepository) FindCanonicalTopicByAlias(ctx context.Context, alias string) (*entity.CanonicalTopic, error) {
+	var topic entity.CanonicalTopic
+	err := r.db.GetContext(ctx, &topic, `
+		SELECT ct.id, ct.name, ct.created_at, ct.updated_at
+		FROM canonical_topics ct
+		JOIN topic_aliases a ON a.canonical_topic_id = ct.id
+		WHERE a.alias = $1
+	`, alias)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrTopicNotFound.WithDetails(map[string]any{
+				"alias": alias,
+			}).WithLocation("topicRepository.FindCanonicalTopicByAlias")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("topicRepository.FindCanonicalTopicByAlias").WithError(err)
+	}
+
+	return &topic, nil
+}
+
+// ListCanonicalTopics orders topics by name
+func (r *topicRepository) ListCanonicalTopics(ctx context.Context, filter *entity.GetCanonicalTopicsFilter) ([]entity.CanonicalTopic, int64, error) {
+	offset := min(max(filter.Offset, 0), 10000)
+	limit := min(max(filter.Limit, 10), 100)
+
+	where := ""
+	var args []any
+	if filter.Search != "" {
+		where = ` WHERE ct.name ILIKE $1 OR EXISTS (
+			SELECT 1 FROM topic_aliases a WHERE a.canonical_topic_id = ct.id AND a.alias ILIKE $1
+		)`
+		args = append(args, "%"+filter.Search+"%")
+	}
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM canonical_topics ct"+where, args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("topicRepository.ListCanonicalTopics.Count").WithError(err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT ct.id, ct.name, ct.created_at, ct.updated_at
+		FROM canonical_topics ct%s
+		ORDER BY ct.name ASC, ct.id ASC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	var topics []entity.CanonicalTopic
+	err = r.db.SelectContext(ctx, &topics, query, args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("topicRepository.ListCanonicalTopics.Select").WithError(err)
+	}
+
+	if topics == nil {
+		topics = []entity.CanonicalTopic{}
+	}
+
+	return topics, total, nil
+}
+
+// ListCanonicalTopicNames returns the names of the limit topics mentioned
+// most, most mentioned first
+func (r *topicRepository) ListCanonicalTopicNames(ctx context.Context, limit int) ([]string, error) {
+	query := `
+		SELECT ct.name
+		FROM canonical_topics ct
+		LEFT JOIN topics t ON t.canonical_topic_id = ct.id
+		GROUP BY ct.id, ct.name
+		ORDER BY COALESCE(SUM(t.count), 0) DESC, ct.name ASC
+		LIMIT $1
+	`
+
+	var names []string
+	err := r.db.SelectContext(ctx, &names, query, limit)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("topicRepository.ListCanonicalTopicNames").WithError(err)
+	}
+
+	if names == nil {
+		names = []string{}
+	}
+
+	return names, nil
+}
+
+// GetTopicAliases returns the aliases of the given canonical topics in
+// alphabetical order
+func (r *topicRepository) GetTopicAliases(ctx context.Context, canonicalTopicIDs []uuid.UUID) ([]entity.TopicAlias, error) {
+	var aliases []entity.TopicAlias
+	err := r.db.SelectContext(ctx, &aliases, `
+		SELECT alias, canonical_topic_id, created_at
+		FROM topic_aliases
+		WHERE canonical_topic_id = ANY($1)
+		ORDER BY alias ASC
+	`, uuidStrings(canonicalTopicIDs))
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("topicRepository.GetTopicAliases").WithError(err)
+	}
+
+	if aliases == nil {
+		aliases = []entity.TopicAlias{}
+	}
+
+	return aliases, nil
+}
+
+// RenameCanonicalTopic sets topic's name and makes alias, the new name
+// normalized, point to it. The old name stays an alias so incoming titles
+// keep matching.
+func (r *topicRepository) RenameCanonicalTopic(ctx context.Context, topic *entity.CanonicalTopic, alias string) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RenameCanonicalTopic.Begin").WithError(err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := lockAliases(ctx, tx); err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RenameCanonicalTopic.Lock").WithError(err)
+	}
+
+	var owner uuid.UUID
+	err = tx.GetContext(ctx, &owner, "SELECT canonical_topic_id FROM topic_aliases WHERE alias = $1", alias)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RenameCanonicalTopic.Alias").WithError(err)
+	}
+	if err == nil && owner != topic.ID {
+		return errx.ErrTopicNameTaken.WithDetails(map[string]any{
+			"name":    topic.Name,
+			"topicId": owner,
+		}).WithLocation("topicRepository.RenameCanonicalTopic")
+	}
+
+	result, err := tx.NamedExecContext(ctx, `
+		UPDATE canonical_topics
+		SET name = :name, updated_at = :updated_at
+		WHERE id = :id
+	`, topic)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RenameCanonicalTopic.Update").WithError(err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RenameCanonicalTopic.RowsAffected").WithError(err)
+	}
+	if rows == 0 {
+		return errx.ErrTopicNotFound.WithDetails(map[string]any{
+			"id": topic.ID,
+		}).WithLocation("topicRepository.RenameCanonicalTopic")
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO topic_aliases (alias, canonical_topic_id, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (alias) DO NOTHING
+	`, alias, topic.ID, topic.UpdatedAt)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RenameCanonicalTopic.Insert").WithError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RenameCanonicalTopic.Commit").WithError(err)
+	}
+
+	return nil
+}
+
+// MergeCanonicalTopics moves the mentions and aliases of the source topics
+// to the target and deletes the sources. Mentions keep their titles, so the
+// original wording stays on record.
+func (r *topicRepository) MergeCanonicalTopics(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, now time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.MergeCanonicalTopics.Begin").WithError(err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := lockAliases(ctx, tx); err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.MergeCanonicalTopics.Lock").WithError(err)
+	}
+
+	ids := uuidStrings(append([]uuid.UUID{targetID}, sourceIDs...))
+
+	var found []uuid.UUID
+	err = tx.SelectContext(ctx, &found, "SELECT id FROM canonical_topics WHERE id = ANY($1) FOR UPDATE", ids)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.MergeCanonicalTopics.Select").WithError(err)
+	}
+	if len(found) != len(ids) {
+		return errx.ErrTopicNotFound.WithDetails(map[string]any{
+			"ids": ids,
+		}).WithLocation("topicRepository.MergeCanonicalTopics")
+	}
+
+	sources := ids[1:]
+	steps := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{"Topics", "UPDATE topics SET canonical_topic_id = $1 WHERE canonical_topic_id = ANY($2)", []any{targetID, sources}},
+		{"Aliases", "UPDATE topic_aliases SET canonical_topic_id = $1 WHERE canonical_topic_id = ANY($2)", []any{targetID, sources}},
+		{"Delete", "DELETE FROM canonical_topics WHERE id = ANY($1)", []any{sources}},
+		{"Touch", "UPDATE canonical_topics SET updated_at = $1 WHERE id = $2", []any{now, targetID}},
+	}
+	for _, step := range steps {
+		if _, err := tx.ExecContext(ctx, step.query, step.args...); err != nil {
+			return errx.ErrInternalServer.WithLocation("topicRepository.MergeCanonicalTopics." + step.name).WithError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.MergeCanonicalTopics.Commit").WithError(err)
+	}
+
+	return nil
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	res := make([]string, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, id.String())
+	}
+
+	return res
+}
@@ -12,8 +12,10 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -42,45 +44,211 @@ func (m *MockTopicRepository) EXPECT() *MockTopicRepositoryMockRecorder {
 }
 
 // BulkCreate mocks base method.
-func (m *MockTopicRepository) BulkCreate(ctx context.Context, topics []entity.Topic) error {
+func (m *MockTopicRepository) BulkCreate(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkCreate", ctx, topics)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "BulkCreate", ctx, batch, mentions)
+	ret0, _ := ret[0].(*entity.TopicBatch)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // BulkCreate indicates an expected call of BulkCreate.
-func (mr *MockTopicRepositoryMockRecorder) BulkCreate(ctx, topics any) *gomock.Call {
+func (mr *MockTopicRepositoryMockRecorder) BulkCreate(ctx, batch, mentions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockTopicRepository)(nil).BulkCreate), ctx, batch, mentions)
+}
+
+// FindCanonicalTopicByAlias mocks base method.
+func (m *MockTopicRepository) FindCanonicalTopicByAlias(ctx context.Context, alias string) (*entity.CanonicalTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCanonicalTopicByAlias", ctx, alias)
+	ret0, _ := ret[0].(*entity.CanonicalTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCanonicalTopicByAlias indicates an expected call of FindCanonicalTopicByAlias.
+func (mr *MockTopicRepositoryMockRecorder) FindCanonicalTopicByAlias(ctx, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCanonicalTopicByAlias", reflect.TypeOf((*MockTopicRepository)(nil).FindCanonicalTopicByAlias), ctx, alias)
+}
+
+// FindCanonicalTopicByID mocks base method.
+func (m *MockTopicRepository) FindCanonicalTopicByID(ctx context.Context, id uuid.UUID) (*entity.CanonicalTopic, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCanonicalTopicByID", ctx, id)
+	ret0, _ := ret[0].(*entity.CanonicalTopic)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCanonicalTopicByID indicates an expected call of FindCanonicalTopicByID.
+func (mr *MockTopicRepositoryMockRecorder) FindCanonicalTopicByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockTopicRepository)(nil).BulkCreate), ctx, topics)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCanonicalTopicByID", reflect.TypeOf((*MockTopicRepository)(nil).FindCanonicalTopicByID), ctx, id)
+}
+
+// FindTopicBatchByID mocks base method.
+func (m *MockTopicRepository) FindTopicBatchByID(ctx context.Context, id uuid.UUID) (*entity.TopicBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTopicBatchByID", ctx, id)
+	ret0, _ := ret[0].(*entity.TopicBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTopicBatchByID indicates an expected call of FindTopicBatchByID.
+func (mr *MockTopicRepositoryMockRecorder) FindTopicBatchByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTopicBatchByID", reflect.TypeOf((*MockTopicRepository)(nil).FindTopicBatchByID), ctx, id)
 }
 
 // GetHotTopics mocks base method.
-func (m *MockTopicRepository) GetHotTopics(ctx context.Context) ([]entity.Topic, error) {
+func (m *MockTopicRepository) GetHotTopics(ctx context.Context, filter *entity.HotTopicsFilter) ([]entity.HotTopic, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHotTopics", ctx)
-	ret0, _ := ret[0].([]entity.Topic)
+	ret := m.ctrl.Call(m, "GetHotTopics", ctx, filter)
+	ret0, _ := ret[0].([]entity.HotTopic)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetHotTopics indicates an expected call of GetHotTopics.
-func (mr *MockTopicRepositoryMockRecorder) GetHotTopics(ctx any) *gomock.Call {
+func (mr *MockTopicRepositoryMockRecorder) GetHotTopics(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotTopics", reflect.TypeOf((*MockTopicRepository)(nil).GetHotTopics), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotTopics", reflect.TypeOf((*MockTopicRepository)(nil).GetHotTopics), ctx, filter)
+}
+
+// GetTopicAliases mocks base method.
+func (m *MockTopicRepository) GetTopicAliases(ctx context.Context, canonicalTopicIDs []uuid.UUID) ([]entity.TopicAlias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopicAliases", ctx, canonicalTopicIDs)
+	ret0, _ := ret[0].([]entity.TopicAlias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopicAliases indicates an expected call of GetTopicAliases.
+func (mr *MockTopicRepositoryMockRecorder) GetTopicAliases(ctx, canonicalTopicIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopicAliases", reflect.TypeOf((*MockTopicRepository)(nil).GetTopicAliases), ctx, canonicalTopicIDs)
+}
+
+// GetTopicSeries mocks base method.
+func (m *MockTopicRepository) GetTopicSeries(ctx context.Context, filter *entity.TopicSeriesFilter) ([]entity.TopicSeriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTopicSeries", ctx, filter)
+	ret0, _ := ret[0].([]entity.TopicSeriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTopicSeries indicates an expected call of GetTopicSeries.
+func (mr *MockTopicRepositoryMockRecorder) GetTopicSeries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopicSeries", reflect.TypeOf((*MockTopicRepository)(nil).GetTopicSeries), ctx, filter)
 }
 
 // GetTopicsCount mocks base method.
-func (m *MockTopicRepository) GetTopicsCount(ctx context.Context) (int, error) {
+func (m *MockTopicRepository) GetTopicsCount(ctx context.Context, filter *entity.TopicsCountFilter) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTopicsCount", ctx)
+	ret := m.ctrl.Call(m, "GetTopicsCount", ctx, filter)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTopicsCount indicates an expected call of GetTopicsCount.
-func (mr *MockTopicRepositoryMockRecorder) GetTopicsCount(ctx any) *gomock.Call {
+func (mr *MockTopicRepositoryMockRecorder) GetTopicsCount(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopicsCount", reflect.TypeOf((*MockTopicRepository)(nil).GetTopicsCount), ctx, filter)
+}
+
+// ListCanonicalTopicNames mocks base method.
+func (m *MockTopicRepository) ListCanonicalTopicNames(ctx context.Context, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCanonicalTopicNames", ctx, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCanonicalTopicNames indicates an expected call of ListCanonicalTopicNames.
+func (mr *MockTopicRepositoryMockRecorder) ListCanonicalTopicNames(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCanonicalTopicNames", reflect.TypeOf((*MockTopicRepository)(nil).ListCanonicalTopicNames), ctx, limit)
+}
+
+// ListCanonicalTopics mocks base method.
+func (m *MockTopicRepository) ListCanonicalTopics(ctx context.Context, filter *entity.GetCanonicalTopicsFilter) ([]entity.CanonicalTopic, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCanonicalTopics", ctx, filter)
+	ret0, _ := ret[0].([]entity.CanonicalTopic)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListCanonicalTopics indicates an expected call of ListCanonicalTopics.
+func (mr *MockTopicRepositoryMockRecorder) ListCanonicalTopics(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCanonicalTopics", reflect.TypeOf((*MockTopicRepository)(nil).ListCanonicalTopics), ctx, filter)
+}
+
+// ListTopicBatches mocks base method.
+func (m *MockTopicRepository) ListTopicBatches(ctx context.Context, filter *entity.GetTopicBatchesFilter) ([]entity.TopicBatch, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTopicBatches", ctx, filter)
+	ret0, _ := ret[0].([]entity.TopicBatch)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTopicBatches indicates an expected call of ListTopicBatches.
+func (mr *MockTopicRepositoryMockRecorder) ListTopicBatches(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTopicBatches", reflect.TypeOf((*MockTopicRepository)(nil).ListTopicBatches), ctx, filter)
+}
+
+// MergeCanonicalTopics mocks base method.
+func (m *MockTopicRepository) MergeCanonicalTopics(ctx context.Context, targetID uuid.UUID, sourceIDs []uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeCanonicalTopics", ctx, targetID, sourceIDs, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MergeCanonicalTopics indicates an expected call of MergeCanonicalTopics.
+func (mr *MockTopicRepositoryMockRecorder) MergeCanonicalTopics(ctx, targetID, sourceIDs, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeCanonicalTopics", reflect.TypeOf((*MockTopicRepository)(nil).MergeCanonicalTopics), ctx, targetID, sourceIDs, now)
+}
+
+// RenameCanonicalTopic mocks base method.
+func (m *MockTopicRepository) RenameCanonicalTopic(ctx context.Context, topic *entity.CanonicalTopic, alias string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameCanonicalTopic", ctx, topic, alias)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameCanonicalTopic indicates an expected call of RenameCanonicalTopic.
+func (mr *MockTopicRepositoryMockRecorder) RenameCanonicalTopic(ctx, topic, alias any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameCanonicalTopic", reflect.TypeOf((*MockTopicRepository)(nil).RenameCanonicalTopic), ctx, topic, alias)
+}
+
+// RollbackTopicBatch mocks base method.
+func (m *MockTopicRepository) RollbackTopicBatch(ctx context.Context, id uuid.UUID, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTopicBatch", ctx, id, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTopicBatch indicates an expected call of RollbackTopicBatch.
+func (mr *MockTopicRepositoryMockRecorder) RollbackTopicBatch(ctx, id, now any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTopicsCount", reflect.TypeOf((*MockTopicRepository)(nil).GetTopicsCount), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTopicBatch", reflect.TypeOf((*MockTopicRepository)(nil).RollbackTopicBatch), ctx, id, now)
 }
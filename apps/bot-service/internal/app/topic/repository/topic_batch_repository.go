@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/google/uuid"
+)
+
+const selectTopicBatchColumns = `
+	SELECT id, batch_id, source, payload_hash, topic_count, mention_count, actor_type, actor_id, created_at, rolled_back_at
+	FROM topic_batches
+`
+
+func (r *topicRepository) FindTopicBatchByID(ctx context.Context, id uuid.UUID) (*entity.TopicBatch, error) {
+	var batch entity.TopicBatch
+	err := r.db.GetContext(ctx, &batch, selectTopicBatchColumns+" WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errx.ErrTopicBatchNotFound.WithDetails(map[string]any{
+				"id": id,
+			}).WithLocation("topicRepository.FindTopicBatchByID")
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("topicRepository.FindTopicBatchByID").WithError(err)
+	}
+
+	return &batch, nil
+}
+
+// ListTopicBatches orders batches newest first
+func (r *topicRepository) ListTopicBatches(ctx context.Context, filter *entity.GetTopicBatchesFilter) ([]entity.TopicBatch, int64, error) {
+	offset := min(max(filter.Offset, 0), 10000)
+	limit := min(max(filter.Limit, 10), 100)
+
+	where := ""
+	var args []any
+	if filter.Source != "" {
+		where = " WHERE source = $1"
+		args = append(args, filter.Source)
+	}
+
+	var total int64
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM topic_batches"+where, args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("topicRepository.ListTopicBatches.Count").WithError(err)
+	}
+
+	query := fmt.Sprintf(selectTopicBatchColumns+"%s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", where, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	var batches []entity.TopicBatch
+	err = r.db.SelectContext(ctx, &batches, query, args...)
+	if err != nil {
+		return nil, 0, errx.ErrInternalServer.WithLocation("topicRepository.ListTopicBatches.Select").WithError(err)
+	}
+
+	if batches == nil {
+		batches = []entity.TopicBatch{}
+	}
+
+	return batches, total, nil
+}
+
+// RollbackTopicBatch deletes the topic rows batch id recorded and marks it as
+// rolled back. Canonical topics and aliases it created are kept.
+func (r *topicRepository) RollbackTopicBatch(ctx context.Context, id uuid.UUID, now time.Time) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RollbackTopicBatch.Begin").WithError(err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	var rolledBackAt *time.Time
+	err = tx.GetContext(ctx, &rolledBackAt, "SELECT rolled_back_at FROM topic_batches WHERE id = $1 FOR UPDATE", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return errx.ErrTopicBatchNotFound.WithDetails(map[string]any{
+				"id": id,
+			}).WithLocation("topicRepository.RollbackTopicBatch")
+		}
+
+		return errx.ErrInternalServer.WithLocation("topicRepository.RollbackTopicBatch.Select").WithError(err)
+	}
+
+	if rolledBackAt != nil {
+		return errx.ErrTopicBatchRolledBack.WithDetails(map[string]any{
+			"id": id,
+		}).WithLocation("topicRepository.RollbackTopicBatch")
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM topics WHERE topic_batch_id = $1", id); err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RollbackTopicBatch.Delete").WithError(err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE topic_batches SET rolled_back_at = $1 WHERE id = $2", now, id); err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RollbackTopicBatch.Update").WithError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errx.ErrInternalServer.WithLocation("topicRepository.RollbackTopicBatch.Commit").WithError(err)
+	}
+
+	return nil
+}
@@ -2,67 +2,277 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/report"
+	"github.com/google/uuid"
 )
 
-func (r *topicRepository) BulkCreate(ctx context.Context, topics []entity.Topic) error {
-	query := `
-		INSERT INTO topics (title, count)
-		VALUES (:title, :count)
-	`
+// BulkCreate records mentions as batch, under the canonical topics their
+// aliases belong to, in one transaction. An alias without one gets a new
+// canonical topic named after the first mention's title. Every row is
+// stamped with the batch's CreatedAt, which is in UTC. The batch's
+// MessageIDs are marked as extracted in the same transaction. When batch's
+// source already sent its batch ID nothing is recorded, and the stored batch
+// is returned with true.
+func (r *topicRepository) BulkCreate(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.Begin").WithError(err)
+	}
+	defer tx.Rollback() // No-op once committed
 
-	_, err := r.db.NamedExecContext(
-		ctx,
-		query,
-		topics,
-	)
+	if err := lockAliases(ctx, tx); err != nil {
+		return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.Lock").WithError(err)
+	}
+
+	var stored entity.TopicBatch
+	err = tx.GetContext(ctx, &stored, selectTopicBatchColumns+" WHERE source = $1 AND batch_id = $2", batch.Source, batch.BatchID)
+	if err == nil {
+		return &stored, true, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.FindBatch").WithError(err)
+	}
+
+	aliases := make([]string, 0, len(mentions))
+	for i := range mentions {
+		aliases = append(aliases, mentions[i].Alias)
+	}
+
+	var existingAliases []entity.TopicAlias
+	err = tx.SelectContext(ctx, &existingAliases, `
+		SELECT alias, canonical_topic_id, created_at
+		FROM topic_aliases
+		WHERE alias = ANY($1)
+	`, aliases)
 	if err != nil {
-		return errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate").WithError(err)
+		return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.Select").WithError(err)
 	}
 
-	return nil
+	canonicalTopicIDs := make(map[string]uuid.UUID, len(mentions))
+	for _, alias := range existingAliases {
+		canonicalTopicIDs[alias.Alias] = alias.CanonicalTopicID
+	}
+
+	batchTopicIDs := make(map[uuid.UUID]bool, len(mentions))
+
+	var newTopics []entity.CanonicalTopic
+	var newAliases []entity.TopicAlias
+	topics := make([]entity.Topic, 0, len(mentions))
+	for _, mention := range mentions {
+		canonicalTopicID, ok := canonicalTopicIDs[mention.Alias]
+		if !ok {
+			canonicalTopicID = mention.NewCanonicalTopicID
+			canonicalTopicIDs[mention.Alias] = canonicalTopicID
+			newTopics = append(newTopics, entity.CanonicalTopic{
+				ID:        canonicalTopicID,
+				Name:      mention.Title,
+				CreatedAt: batch.CreatedAt,
+				UpdatedAt: batch.CreatedAt,
+			})
+			newAliases = append(newAliases, entity.TopicAlias{
+				Alias:            mention.Alias,
+				CanonicalTopicID: canonicalTopicID,
+				CreatedAt:        batch.CreatedAt,
+			})
+		}
+
+		batchTopicIDs[canonicalTopicID] = true
+		batch.MentionCount += mention.Count
+
+		topics = append(topics, entity.Topic{
+			CanonicalTopicID: canonicalTopicID,
+			TopicBatchID:     &batch.ID,
+			Title:            mention.Title,
+			Count:            mention.Count,
+			CreatedAt:        batch.CreatedAt,
+		})
+	}
+	batch.TopicCount = len(batchTopicIDs)
+
+	_, err = tx.NamedExecContext(ctx, `
+		INSERT INTO topic_batches (id, batch_id, source, payload_hash, topic_count, mention_count, actor_type, actor_id, created_at)
+		VALUES (:id, :batch_id, :source, :payload_hash, :topic_count, :mention_count, :actor_type, :actor_id, :created_at)
+	`, batch)
+	if err != nil {
+		return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.InsertBatch").WithError(err)
+	}
+
+	if len(newTopics) > 0 {
+		_, err := tx.NamedExecContext(ctx, `
+			INSERT INTO canonical_topics (id, name, created_at, updated_at)
+			VALUES (:id, :name, :created_at, :updated_at)
+		`, newTopics)
+		if err != nil {
+			return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.InsertCanonical").WithError(err)
+		}
+
+		_, err = tx.NamedExecContext(ctx, `
+			INSERT INTO topic_aliases (alias, canonical_topic_id, created_at)
+			VALUES (:alias, :canonical_topic_id, :created_at)
+		`, newAliases)
+		if err != nil {
+			return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.InsertAliases").WithError(err)
+		}
+	}
+
+	if len(topics) > 0 {
+		_, err = tx.NamedExecContext(ctx, `
+			INSERT INTO topics (canonical_topic_id, topic_batch_id, title, count, created_at)
+			VALUES (:canonical_topic_id, :topic_batch_id, :title, :count, :created_at)
+		`, topics)
+		if err != nil {
+			return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.Insert").WithError(err)
+		}
+	}
+
+	if len(batch.MessageIDs) > 0 {
+		messageIDs := make([]string, 0, len(batch.MessageIDs))
+		for _, id := range batch.MessageIDs {
+			messageIDs = append(messageIDs, id.String())
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE messages
+			SET topics_extracted_at = $1
+			WHERE id = ANY($2)
+		`, batch.CreatedAt, messageIDs)
+		if err != nil {
+			return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.MarkMessages").WithError(err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, false, errx.ErrInternalServer.WithLocation("topicRepository.BulkCreate.Commit").WithError(err)
+	}
+
+	return batch, false, nil
 }
 
-func (r *topicRepository) GetHotTopics(ctx context.Context) ([]entity.Topic, error) {
+// GetHotTopics returns the filter.Limit canonical topics mentioned most in
+// the window, with how often they were mentioned in the previous one
+func (r *topicRepository) GetHotTopics(ctx context.Context, filter *entity.HotTopicsFilter) ([]entity.HotTopic, error) {
 	query := `
+		WITH current_topics AS (
+			SELECT
+				MAX(t.id) AS id,
+				t.canonical_topic_id,
+				MAX(t.created_at) AS created_at,
+				ct.name AS title,
+				SUM(t.count) AS count
+			FROM topics t
+			JOIN canonical_topics ct ON ct.id = t.canonical_topic_id
+			WHERE t.created_at >= $1 AND t.created_at < $2
+			GROUP BY t.canonical_topic_id, ct.name
+			ORDER BY count DESC, title ASC
+			LIMIT $4
+		)
 		SELECT
-			MAX(id) AS id,
-			MAX(created_at) AS created_at,
-			title,
-			SUM(count) AS count
-		FROM topics
-		WHERE created_at >= CURRENT_DATE - 30 * INTERVAL '1 day'
-		GROUP BY title
-		ORDER BY count DESC
-		LIMIT 5
+			c.id,
+			c.canonical_topic_id,
+			c.created_at,
+			c.title,
+			c.count,
+			COALESCE(SUM(p.count), 0) AS previous_count
+		FROM current_topics c
+		LEFT JOIN topics p ON p.canonical_topic_id = c.canonical_topic_id AND p.created_at >= $3 AND p.created_at < $1
+		GROUP BY c.id, c.canonical_topic_id, c.created_at, c.title, c.count
+		ORDER BY c.count DESC, c.title ASC
 	`
 
-	var results []entity.Topic
-	err := r.db.SelectContext(ctx, &results, query)
+	var results []entity.HotTopic
+	err := r.db.SelectContext(
+		ctx,
+		&results,
+		query,
+		filter.From.UTC(),
+		filter.To.UTC(),
+		filter.PreviousFrom.UTC(),
+		filter.Limit,
+	)
 	if err != nil {
 		return nil, errx.ErrInternalServer.WithLocation("topicRepository.GetHotTopics").WithError(err)
 	}
 
 	if results == nil {
-		results = []entity.Topic{}
+		results = []entity.HotTopic{}
 	}
 
 	return results, nil
 }
 
-func (r *topicRepository) GetTopicsCount(ctx context.Context) (int, error) {
+func (r *topicRepository) GetTopicsCount(ctx context.Context, filter *entity.TopicsCountFilter) (int, error) {
 	query := `
-		SELECT COUNT(DISTINCT title)
+		SELECT COUNT(DISTINCT canonical_topic_id)
 		FROM topics
 	`
+	var args []any
+	if filter.From != nil {
+		query += " WHERE created_at >= $1 AND created_at < $2"
+		args = append(args, filter.From.UTC(), filter.To.UTC())
+	}
 
 	var count int
-	err := r.db.GetContext(ctx, &count, query)
+	err := r.db.GetContext(ctx, &count, query, args...)
 	if err != nil {
 		return 0, errx.ErrInternalServer.WithLocation("topicRepository.GetTopicsCount").WithError(err)
 	}
 
 	return count, nil
 }
+
+// GetTopicSeries returns one row per bucket from filter.From up to filter.To,
+// including buckets without mentions, the same way as
+// feedbackRepository.GetSatisfactionTrend
+func (r *topicRepository) GetTopicSeries(ctx context.Context, filter *entity.TopicSeriesFilter) ([]entity.TopicSeriesRow, error) {
+	query := `
+		WITH buckets AS (
+			SELECT generate_series(
+				date_trunc($1, $2::timestamp),
+				$3::timestamp - INTERVAL '1 day',
+				('1 ' || $1)::interval
+			) AS bucket
+		),
+		local_topics AS (
+			SELECT
+				date_trunc($1, created_at AT TIME ZONE 'UTC' AT TIME ZONE $4) AS bucket,
+				count
+			FROM topics
+			WHERE canonical_topic_id = $7 AND created_at >= $5 AND created_at < $6
+		)
+		SELECT
+			b.bucket AS date,
+			COALESCE(SUM(lt.count), 0) AS topic_count
+		FROM buckets b
+		LEFT JOIN local_topics lt ON lt.bucket = b.bucket
+		GROUP BY b.bucket
+		ORDER BY b.bucket ASC
+	`
+
+	var results []entity.TopicSeriesRow
+	err := r.db.SelectContext(
+		ctx,
+		&results,
+		query,
+		filter.Granularity,
+		report.WallClock(filter.From),
+		report.WallClock(filter.To),
+		filter.Timezone,
+		filter.From.UTC(),
+		filter.To.UTC(),
+		filter.CanonicalTopicID,
+	)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("topicRepository.GetTopicSeries").WithError(err)
+	}
+
+	if results == nil {
+		results = []entity.TopicSeriesRow{}
+	}
+
+	return results, nil
+}
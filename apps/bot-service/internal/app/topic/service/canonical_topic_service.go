@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/google/uuid"
+)
+
+func (s *TopicService) ListCanonicalTopics(ctx context.Context, query *dto.GetCanonicalTopicsQuery) (*dto.GetCanonicalTopicsResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	limit := min(max(query.Limit, 10), 100)
+	page := max(query.Page, 1)
+
+	filter := entity.GetCanonicalTopicsFilter{
+		Offset: (page - 1) * limit,
+		Limit:  limit,
+		Search: query.Search,
+	}
+
+	topics, total, err := s.topicRepo.ListCanonicalTopics(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases, err := s.getAliases(ctx, topics...)
+	if err != nil {
+		return nil, err
+	}
+
+	topicResponses := make([]dto.CanonicalTopicResponse, 0, len(topics))
+	for i := range topics {
+		topicResponses = append(topicResponses, dto.ToCanonicalTopicResponse(&topics[i], aliases[topics[i].ID]))
+	}
+
+	res := &dto.GetCanonicalTopicsResponse{
+		Topics: topicResponses,
+	}
+	res.Meta.Pagination = dto.NewPaginationResponse(total, page, limit)
+
+	return res, nil
+}
+
+// RenameCanonicalTopic changes the name the dashboard shows. Titles matching
+// the old name keep being attributed to the topic.
+func (s *TopicService) RenameCanonicalTopic(ctx context.Context, actor entity.AuditActor, param *dto.CanonicalTopicParam, req *dto.RenameCanonicalTopicRequest) (*dto.CanonicalTopicResponse, error) {
+	if err := s.validator.Validate(param); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return nil, errx.ErrTopicNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("TopicService.RenameCanonicalTopic").WithError(err)
+	}
+
+	topic, err := s.topicRepo.FindCanonicalTopicByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.toCanonicalTopicResponse(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	name, alias := normalizeTopicTitle(req.Name)
+	if alias == "" {
+		return nil, errx.ErrTopicNameBlank.WithDetails(map[string]any{
+			"name": req.Name,
+		}).WithLocation("TopicService.RenameCanonicalTopic")
+	}
+
+	topic.Name = name
+	topic.UpdatedAt = time.Now().UTC()
+
+	if err := s.topicRepo.RenameCanonicalTopic(ctx, topic, alias); err != nil {
+		return nil, err
+	}
+
+	res, err := s.toCanonicalTopicResponse(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	targetID := topic.ID.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionTopicRename,
+		TargetType: entity.AuditTargetTopic,
+		TargetID:   &targetID,
+		Before:     before,
+		After:      res,
+	})
+
+	return res, nil
+}
+
+// MergeCanonicalTopics folds the source topics into the one in param. Their
+// mentions count towards it from then on, history included, and their
+// aliases attribute new titles to it.
+func (s *TopicService) MergeCanonicalTopics(ctx context.Context, actor entity.AuditActor, param *dto.CanonicalTopicParam, req *dto.MergeCanonicalTopicsRequest) (*dto.CanonicalTopicResponse, error) {
+	if err := s.validator.Validate(param); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	targetID, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return nil, errx.ErrTopicNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("TopicService.MergeCanonicalTopics").WithError(err)
+	}
+
+	sourceIDs := make([]uuid.UUID, 0, len(req.SourceIDs))
+	for _, rawID := range req.SourceIDs {
+		sourceID, err := s.uuidPkg.Parse(rawID)
+		if err != nil {
+			return nil, errx.ErrTopicNotFound.WithDetails(map[string]any{
+				"id": rawID,
+			}).WithLocation("TopicService.MergeCanonicalTopics").WithError(err)
+		}
+
+		if sourceID == targetID {
+			return nil, errx.ErrTopicMergeIntoItself.WithDetails(map[string]any{
+				"id": rawID,
+			}).WithLocation("TopicService.MergeCanonicalTopics")
+		}
+
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+
+	// Keep snapshots so the audit log shows what was merged away
+	target, err := s.topicRepo.FindCanonicalTopicByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]entity.CanonicalTopic, 0, len(sourceIDs))
+	for _, sourceID := range sourceIDs {
+		source, err := s.topicRepo.FindCanonicalTopicByID(ctx, sourceID)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, *source)
+	}
+
+	aliases, err := s.getAliases(ctx, append([]entity.CanonicalTopic{*target}, sources...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	before := make([]dto.CanonicalTopicResponse, 0, len(sources)+1)
+	before = append(before, dto.ToCanonicalTopicResponse(target, aliases[target.ID]))
+	for i := range sources {
+		before = append(before, dto.ToCanonicalTopicResponse(&sources[i], aliases[sources[i].ID]))
+	}
+
+	target.UpdatedAt = time.Now().UTC()
+	if err := s.topicRepo.MergeCanonicalTopics(ctx, targetID, sourceIDs, target.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	res, err := s.toCanonicalTopicResponse(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
+	targetIDStr := targetID.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionTopicMerge,
+		TargetType: entity.AuditTargetTopic,
+		TargetID:   &targetIDStr,
+		Before:     before,
+		After:      res,
+		Metadata: map[string]any{
+			"sourceIds": req.SourceIDs,
+		},
+	})
+
+	return res, nil
+}
+
+func (s *TopicService) toCanonicalTopicResponse(ctx context.Context, topic *entity.CanonicalTopic) (*dto.CanonicalTopicResponse, error) {
+	aliases, err := s.getAliases(ctx, *topic)
+	if err != nil {
+		return nil, err
+	}
+
+	res := dto.ToCanonicalTopicResponse(topic, aliases[topic.ID])
+	return &res, nil
+}
+
+// getAliases groups the aliases of topics by canonical topic
+func (s *TopicService) getAliases(ctx context.Context, topics ...entity.CanonicalTopic) (map[uuid.UUID][]string, error) {
+	res := make(map[uuid.UUID][]string, len(topics))
+	if len(topics) == 0 {
+		return res, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(topics))
+	for i := range topics {
+		ids = append(ids, topics[i].ID)
+	}
+
+	aliases, err := s.topicRepo.GetTopicAliases(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alias := range aliases {
+		res[alias.CanonicalTopicID] = append(res[alias.CanonicalTopicID], alias.Alias)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
+	topicRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/repository/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTopicService_ListCanonicalTopics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	leave := entity.CanonicalTopic{ID: uuid.New(), Name: "Cuti Tahunan", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	payroll := entity.CanonicalTopic{ID: uuid.New(), Name: "Payroll", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+
+	tests := []struct {
+		name    string
+		query   *dto.GetCanonicalTopicsQuery
+		setup   func()
+		wantErr bool
+		check   func(*testing.T, *dto.GetCanonicalTopicsResponse)
+	}{
+		{
+			name:  "success - topics with their aliases",
+			query: &dto.GetCanonicalTopicsQuery{Page: 2, Limit: 20, Search: "cuti"},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().ListCanonicalTopics(ctx, &entity.GetCanonicalTopicsFilter{
+					Offset: 20,
+					Limit:  20,
+					Search: "cuti",
+				}).Return([]entity.CanonicalTopic{leave, payroll}, int64(22), nil)
+				mockTopicRepo.EXPECT().GetTopicAliases(ctx, []uuid.UUID{leave.ID, payroll.ID}).Return([]entity.TopicAlias{
+					{Alias: "cuti tahunan", CanonicalTopicID: leave.ID},
+					{Alias: "pengajuan cuti", CanonicalTopicID: leave.ID},
+					{Alias: "payroll", CanonicalTopicID: payroll.ID},
+				}, nil)
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetCanonicalTopicsResponse) {
+				assert.Len(t, res.Topics, 2)
+				assert.Equal(t, "Cuti Tahunan", res.Topics[0].Name)
+				assert.Equal(t, []string{"cuti tahunan", "pengajuan cuti"}, res.Topics[0].Aliases)
+				assert.Equal(t, []string{"payroll"}, res.Topics[1].Aliases)
+				assert.Equal(t, int64(22), res.Meta.Pagination.TotalData)
+				assert.Equal(t, 2, res.Meta.Pagination.TotalPage)
+			},
+		},
+		{
+			name:  "success - empty catalogue",
+			query: &dto.GetCanonicalTopicsQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().ListCanonicalTopics(ctx, gomock.Any()).Return([]entity.CanonicalTopic{}, int64(0), nil)
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetCanonicalTopicsResponse) {
+				assert.Empty(t, res.Topics)
+			},
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetCanonicalTopicsQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().ListCanonicalTopics(ctx, gomock.Any()).Return(nil, int64(0), errx.ErrInternalServer)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.ListCanonicalTopics(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestTopicService_RenameCanonicalTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	actor := entity.AuditActor{Type: entity.AuditActorAdmin}
+	param := &dto.CanonicalTopicParam{ID: testID.String()}
+
+	tests := []struct {
+		name    string
+		req     *dto.RenameCanonicalTopicRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success - renamed and audited",
+			req:  &dto.RenameCanonicalTopicRequest{Name: " Cuti  Tahunan "},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, testID).Return(&entity.CanonicalTopic{ID: testID, Name: "cuti"}, nil)
+				mockTopicRepo.EXPECT().GetTopicAliases(ctx, []uuid.UUID{testID}).Return([]entity.TopicAlias{
+					{Alias: "cuti", CanonicalTopicID: testID},
+				}, nil)
+				mockTopicRepo.EXPECT().RenameCanonicalTopic(ctx, gomock.Any(), "cuti tahunan").DoAndReturn(func(ctx context.Context, topic *entity.CanonicalTopic, alias string) error {
+					assert.Equal(t, "Cuti Tahunan", topic.Name)
+					assert.False(t, topic.UpdatedAt.IsZero())
+					return nil
+				})
+				mockTopicRepo.EXPECT().GetTopicAliases(ctx, []uuid.UUID{testID}).Return([]entity.TopicAlias{
+					{Alias: "cuti", CanonicalTopicID: testID},
+					{Alias: "cuti tahunan", CanonicalTopicID: testID},
+				}, nil)
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionTopicRename, req.Action)
+					assert.Equal(t, entity.AuditTargetTopic, req.TargetType)
+					assert.Equal(t, "cuti", req.Before.(*dto.CanonicalTopicResponse).Name)
+					assert.Equal(t, "Cuti Tahunan", req.After.(*dto.CanonicalTopicResponse).Name)
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "blank name",
+			req:  &dto.RenameCanonicalTopicRequest{Name: "   "},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, testID).Return(&entity.CanonicalTopic{ID: testID, Name: "cuti"}, nil)
+				mockTopicRepo.EXPECT().GetTopicAliases(ctx, gomock.Any()).Return([]entity.TopicAlias{}, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrTopicNameBlank,
+		},
+		{
+			name: "name taken by another topic",
+			req:  &dto.RenameCanonicalTopicRequest{Name: "Payroll"},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, testID).Return(&entity.CanonicalTopic{ID: testID, Name: "cuti"}, nil)
+				mockTopicRepo.EXPECT().GetTopicAliases(ctx, gomock.Any()).Return([]entity.TopicAlias{}, nil)
+				mockTopicRepo.EXPECT().RenameCanonicalTopic(ctx, gomock.Any(), "payroll").Return(errx.ErrTopicNameTaken)
+			},
+			wantErr: true,
+			errType: errx.ErrTopicNameTaken,
+		},
+		{
+			name: "topic not found",
+			req:  &dto.RenameCanonicalTopicRequest{Name: "Payroll"},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, testID).Return(nil, errx.ErrTopicNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrTopicNotFound,
+		},
+		{
+			name: "validation error",
+			req:  &dto.RenameCanonicalTopicRequest{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.name": validator.ValidationError{
+						Message: "name is a required field",
+					},
+				})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.RenameCanonicalTopic(ctx, actor, param, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Cuti Tahunan", result.Name)
+				assert.Equal(t, []string{"cuti", "cuti tahunan"}, result.Aliases)
+			}
+		})
+	}
+}
+
+func TestTopicService_MergeCanonicalTopics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	targetID := uuid.New()
+	sourceID := uuid.New()
+	actor := entity.AuditActor{Type: entity.AuditActorAdmin}
+	param := &dto.CanonicalTopicParam{ID: targetID.String()}
+	target := entity.CanonicalTopic{ID: targetID, Name: "Cuti Tahunan"}
+	source := entity.CanonicalTopic{ID: sourceID, Name: "Pengajuan cuti"}
+
+	tests := []struct {
+		name    string
+		req     *dto.MergeCanonicalTopicsRequest
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success - source folded into target and audited",
+			req:  &dto.MergeCanonicalTopicsRequest{SourceIDs: []string{sourceID.String()}},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(targetID.String()).Return(targetID, nil)
+				mockUUID.EXPECT().Parse(sourceID.String()).Return(sourceID, nil)
+				targetCopy := target
+				sourceCopy := source
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, targetID).Return(&targetCopy, nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, sourceID).Return(&sourceCopy, nil)
+				mockTopicRepo.EXPECT().GetTopicAliases(ctx, []uuid.UUID{targetID, sourceID}).Return([]entity.TopicAlias{
+					{Alias: "cuti tahunan", CanonicalTopicID: targetID},
+					{Alias: "pengajuan cuti", CanonicalTopicID: sourceID},
+				}, nil)
+				mockTopicRepo.EXPECT().MergeCanonicalTopics(ctx, targetID, []uuid.UUID{sourceID}, gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetTopicAliases(ctx, []uuid.UUID{targetID}).Return([]entity.TopicAlias{
+					{Alias: "cuti tahunan", CanonicalTopicID: targetID},
+					{Alias: "pengajuan cuti", CanonicalTopicID: targetID},
+				}, nil)
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionTopicMerge, req.Action)
+					assert.Equal(t, targetID.String(), *req.TargetID)
+					before := req.Before.([]dto.CanonicalTopicResponse)
+					assert.Len(t, before, 2)
+					assert.Equal(t, "Pengajuan cuti", before[1].Name)
+					assert.Equal(t, []string{sourceID.String()}, req.Metadata["sourceIds"])
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "merge into itself",
+			req:  &dto.MergeCanonicalTopicsRequest{SourceIDs: []string{targetID.String()}},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(targetID.String()).Return(targetID, nil).Times(2)
+			},
+			wantErr: true,
+			errType: errx.ErrTopicMergeIntoItself,
+		},
+		{
+			name: "source not found",
+			req:  &dto.MergeCanonicalTopicsRequest{SourceIDs: []string{sourceID.String()}},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(targetID.String()).Return(targetID, nil)
+				mockUUID.EXPECT().Parse(sourceID.String()).Return(sourceID, nil)
+				targetCopy := target
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, targetID).Return(&targetCopy, nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, sourceID).Return(nil, errx.ErrTopicNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrTopicNotFound,
+		},
+		{
+			name: "repository error",
+			req:  &dto.MergeCanonicalTopicsRequest{SourceIDs: []string{sourceID.String()}},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().Parse(targetID.String()).Return(targetID, nil)
+				mockUUID.EXPECT().Parse(sourceID.String()).Return(sourceID, nil)
+				targetCopy := target
+				sourceCopy := source
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, targetID).Return(&targetCopy, nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByID(ctx, sourceID).Return(&sourceCopy, nil)
+				mockTopicRepo.EXPECT().GetTopicAliases(ctx, gomock.Any()).Return([]entity.TopicAlias{}, nil)
+				mockTopicRepo.EXPECT().MergeCanonicalTopics(ctx, targetID, []uuid.UUID{sourceID}, gomock.Any()).Return(errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+		{
+			name: "validation error",
+			req:  &dto.MergeCanonicalTopicsRequest{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.sourceIds": validator.ValidationError{
+						Message: "sourceIds is a required field",
+					},
+				})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.MergeCanonicalTopics(ctx, actor, param, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, targetID.String(), result.ID)
+				assert.Equal(t, []string{"cuti tahunan", "pengajuan cuti"}, result.Aliases)
+			}
+		})
+	}
+}
@@ -2,17 +2,39 @@ package service
 
 import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/topicclassifier"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
 )
 
 type TopicService struct {
 	topicRepo contracts.TopicRepository
 	validator validator.CustomValidatorInterface
+	uuidPkg   uuid.UUIDInterface
+	auditSvc  contracts.AuditService
 }
 
-func NewTopicService(topicRepo contracts.TopicRepository, validatorService validator.CustomValidatorInterface) *TopicService {
+func NewTopicService(topicRepo contracts.TopicRepository, validatorService validator.CustomValidatorInterface, uuidService uuid.UUIDInterface, auditService contracts.AuditService) *TopicService {
 	return &TopicService{
 		topicRepo: topicRepo,
 		validator: validatorService,
+		uuidPkg:   uuidService,
+		auditSvc:  auditService,
+	}
+}
+
+type TopicExtractionService struct {
+	topicRepo        contracts.TopicRepository
+	conversationRepo contracts.ConversationRepository
+	classifier       topicclassifier.TopicClassifierInterface
+	uuidPkg          uuid.UUIDInterface
+}
+
+func NewTopicExtractionService(topicRepo contracts.TopicRepository, conversationRepo contracts.ConversationRepository, classifier topicclassifier.TopicClassifierInterface, uuidService uuid.UUIDInterface) *TopicExtractionService {
+	return &TopicExtractionService{
+		topicRepo:        topicRepo,
+		conversationRepo: conversationRepo,
+		classifier:       classifier,
+		uuidPkg:          uuidService,
 	}
 }
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+)
+
+func (s *TopicService) ListTopicBatches(ctx context.Context, query *dto.GetTopicBatchesQuery) (*dto.GetTopicBatchesResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	limit := min(max(query.Limit, 10), 100)
+	page := max(query.Page, 1)
+
+	filter := entity.GetTopicBatchesFilter{
+		Offset: (page - 1) * limit,
+		Limit:  limit,
+		Source: query.Source,
+	}
+
+	batches, total, err := s.topicRepo.ListTopicBatches(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	batchResponses := make([]dto.TopicBatchResponse, 0, len(batches))
+	for i := range batches {
+		batchResponses = append(batchResponses, dto.ToTopicBatchResponse(&batches[i]))
+	}
+
+	res := &dto.GetTopicBatchesResponse{
+		Batches: batchResponses,
+	}
+	res.Meta.Pagination = dto.NewPaginationResponse(total, page, limit)
+
+	return res, nil
+}
+
+// RollbackTopicBatch removes the counts a batch recorded. The batch is kept,
+// so the source can't send it again.
+func (s *TopicService) RollbackTopicBatch(ctx context.Context, actor entity.AuditActor, param *dto.TopicBatchParam) (*dto.TopicBatchResponse, error) {
+	if err := s.validator.Validate(param); err != nil {
+		return nil, err
+	}
+
+	id, err := s.uuidPkg.Parse(param.ID)
+	if err != nil {
+		return nil, errx.ErrTopicBatchNotFound.WithDetails(map[string]any{
+			"id": param.ID,
+		}).WithLocation("TopicService.RollbackTopicBatch").WithError(err)
+	}
+
+	batch, err := s.topicRepo.FindTopicBatchByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := dto.ToTopicBatchResponse(batch)
+
+	now := time.Now().UTC()
+	if err := s.topicRepo.RollbackTopicBatch(ctx, id, now); err != nil {
+		return nil, err
+	}
+
+	batch.RolledBackAt = &now
+	res := dto.ToTopicBatchResponse(batch)
+
+	targetID := batch.ID.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionTopicBatchRollback,
+		TargetType: entity.AuditTargetTopicBatch,
+		TargetID:   &targetID,
+		Before:     before,
+		After:      res,
+	})
+
+	return &res, nil
+}
+
+// topicBatchHash fingerprints the topics a batch counts, regardless of order
+// or how their titles are spelled
+func topicBatchHash(mentions []entity.TopicMention) string {
+	lines := make([]string, 0, len(mentions))
+	for _, mention := range mentions {
+		lines = append(lines, fmt.Sprintf("%s\x00%d", mention.Alias, mention.Count))
+	}
+	slices.Sort(lines)
+
+	hash := sha256.New()
+	for _, line := range lines {
+		hash.Write([]byte(line + "\n"))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
+	topicRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/repository/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTopicService_ListTopicBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	rolledBackAt := time.Date(2025, 12, 20, 8, 0, 0, 0, time.UTC)
+	batches := []entity.TopicBatch{
+		{ID: uuid.New(), BatchID: "run-2", Source: "dify", TopicCount: 2, MentionCount: 8, ActorType: entity.AuditActorAPIKey},
+		{ID: uuid.New(), BatchID: "run-1", Source: "dify", TopicCount: 1, MentionCount: 3, ActorType: entity.AuditActorAPIKey, RolledBackAt: &rolledBackAt},
+	}
+
+	tests := []struct {
+		name    string
+		query   *dto.GetTopicBatchesQuery
+		setup   func()
+		wantErr bool
+		check   func(*testing.T, *dto.GetTopicBatchesResponse)
+	}{
+		{
+			name:  "success - filtered by source",
+			query: &dto.GetTopicBatchesQuery{Page: 2, Limit: 10, Source: "dify"},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().ListTopicBatches(ctx, &entity.GetTopicBatchesFilter{
+					Offset: 10,
+					Limit:  10,
+					Source: "dify",
+				}).Return(batches, int64(12), nil)
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.GetTopicBatchesResponse) {
+				assert.Len(t, res.Batches, 2)
+				assert.Equal(t, "run-2", res.Batches[0].BatchID)
+				assert.Nil(t, res.Batches[0].RolledBackAt)
+				assert.Equal(t, "2025-12-20T08:00:00Z", *res.Batches[1].RolledBackAt)
+				assert.Equal(t, int64(12), res.Meta.Pagination.TotalData)
+			},
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetTopicBatchesQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().ListTopicBatches(ctx, gomock.Any()).Return(nil, int64(0), errx.ErrInternalServer)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.ListTopicBatches(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				tt.check(t, result)
+			}
+		})
+	}
+}
+
+func TestTopicService_RollbackTopicBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	actor := entity.AuditActor{Type: entity.AuditActorAdmin}
+	param := &dto.TopicBatchParam{ID: testID.String()}
+
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+		errType error
+	}{
+		{
+			name: "success - rolled back and audited",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockTopicRepo.EXPECT().FindTopicBatchByID(ctx, testID).Return(&entity.TopicBatch{ID: testID, BatchID: "run-1", Source: "dify"}, nil)
+				mockTopicRepo.EXPECT().RollbackTopicBatch(ctx, testID, gomock.Any()).Return(nil)
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionTopicBatchRollback, req.Action)
+					assert.Equal(t, entity.AuditTargetTopicBatch, req.TargetType)
+					assert.Equal(t, testID.String(), *req.TargetID)
+					assert.Nil(t, req.Before.(dto.TopicBatchResponse).RolledBackAt)
+					assert.NotNil(t, req.After.(dto.TopicBatchResponse).RolledBackAt)
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name: "already rolled back",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockTopicRepo.EXPECT().FindTopicBatchByID(ctx, testID).Return(&entity.TopicBatch{ID: testID}, nil)
+				mockTopicRepo.EXPECT().RollbackTopicBatch(ctx, testID, gomock.Any()).Return(errx.ErrTopicBatchRolledBack)
+			},
+			wantErr: true,
+			errType: errx.ErrTopicBatchRolledBack,
+		},
+		{
+			name: "batch not found",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockTopicRepo.EXPECT().FindTopicBatchByID(ctx, testID).Return(nil, errx.ErrTopicBatchNotFound)
+			},
+			wantErr: true,
+			errType: errx.ErrTopicBatchNotFound,
+		},
+		{
+			name: "invalid ID",
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().Parse(testID.String()).Return(uuid.Nil, errors.New("invalid uuid"))
+			},
+			wantErr: true,
+			errType: errx.ErrTopicBatchNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.RollbackTopicBatch(ctx, actor, param)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testID.String(), result.ID)
+				assert.NotNil(t, result.RolledBackAt)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
+	"github.com/google/uuid"
+)
+
+const (
+	topicExtractionBatchSize = 50
+	// Questions younger than this may still be waiting for their answer, which
+	// tells them apart from ratings and comments
+	topicExtractionSettleDelay = 5 * time.Minute
+	// The classifier is shown this many existing topics to choose from
+	topicExtractionMaxTopics = 200
+	// A question the classifier fails on this many times is given up on
+	topicExtractionMaxAttempts = 5
+)
+
+// Run classifies new questions every interval until ctx is done
+func (s *TopicExtractionService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.extractPending(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *TopicExtractionService) extractPending(ctx context.Context) {
+	for ctx.Err() == nil {
+		extracted, err := s.ExtractNext(ctx)
+		if err != nil {
+			log.Error(log.CustomLogInfo{
+				"error": err.Error(),
+			}, "[TopicExtraction] Failed to extract topics")
+			return
+		}
+
+		if !extracted {
+			return
+		}
+	}
+}
+
+// ExtractNext classifies the oldest batch of questions nobody has looked at
+// and reports whether there was one. Messages the bot didn't answer are
+// marked as seen without being classified. A batch the classifier fails on is
+// left for the next run, until a message in it has failed
+// topicExtractionMaxAttempts times and is marked as seen without topics. The
+// topics are recorded under a batch ID made from the message IDs, and the
+// messages are marked in the same transaction.
+func (s *TopicExtractionService) ExtractNext(ctx context.Context) (bool, error) {
+	now := time.Now().UTC()
+
+	questions, err := s.conversationRepo.ListPendingQuestions(ctx, now.Add(-topicExtractionSettleDelay), topicExtractionBatchSize)
+	if err != nil {
+		return false, err
+	}
+
+	if len(questions) == 0 {
+		return false, nil
+	}
+
+	contents := make([]string, 0, len(questions))
+	ids := make([]uuid.UUID, 0, len(questions))
+	for _, question := range questions {
+		ids = append(ids, question.ID)
+		if question.Answered {
+			contents = append(contents, question.Content)
+		}
+	}
+
+	var mentions []entity.TopicMention
+	if len(contents) > 0 {
+		names, err := s.topicRepo.ListCanonicalTopicNames(ctx, topicExtractionMaxTopics)
+		if err != nil {
+			return false, err
+		}
+
+		titles, err := s.classifier.Classify(ctx, names, contents)
+		if err != nil {
+			return s.recordFailure(ctx, questions, ids, now, err)
+		}
+
+		mentions, err = s.toMentions(titles)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if len(mentions) == 0 {
+		if err := s.conversationRepo.MarkTopicsExtracted(ctx, ids, now); err != nil {
+			return false, err
+		}
+	} else if err := s.recordTopics(ctx, ids, mentions, now); err != nil {
+		return false, err
+	}
+
+	log.Info(log.CustomLogInfo{
+		"messages":  len(questions),
+		"questions": len(contents),
+	}, "[TopicExtraction] Extracted topics")
+
+	return true, nil
+}
+
+// recordFailure counts a failed attempt at classifying questions. The
+// questions that have now failed topicExtractionMaxAttempts times are marked
+// as seen so the rest can go on without them.
+func (s *TopicExtractionService) recordFailure(ctx context.Context, questions []entity.PendingQuestion, ids []uuid.UUID, now time.Time, classifyErr error) (bool, error) {
+	if err := s.conversationRepo.RecordTopicExtractionFailure(ctx, ids); err != nil {
+		return false, err
+	}
+
+	var exhausted []uuid.UUID
+	for _, question := range questions {
+		if question.Attempts+1 >= topicExtractionMaxAttempts {
+			exhausted = append(exhausted, question.ID)
+		}
+	}
+
+	if len(exhausted) == 0 {
+		return false, errx.ErrInternalServer.WithLocation("TopicExtractionService.ExtractNext.Classify").WithError(classifyErr)
+	}
+
+	if err := s.conversationRepo.MarkTopicsExtracted(ctx, exhausted, now); err != nil {
+		return false, err
+	}
+
+	log.Error(log.CustomLogInfo{
+		"error":    classifyErr.Error(),
+		"messages": len(exhausted),
+	}, "[TopicExtraction] Skipped messages that failed too many times")
+
+	return true, nil
+}
+
+// toMentions counts each topic once, under the first spelling the classifier
+// used
+func (s *TopicExtractionService) toMentions(titles []string) ([]entity.TopicMention, error) {
+	var items []dto.TopicRequest
+	indexes := make(map[string]int, len(titles))
+	for _, title := range titles {
+		_, alias := normalizeTopicTitle(title)
+		if alias == "" {
+			continue
+		}
+
+		if i, ok := indexes[alias]; ok {
+			items[i].Count++
+			continue
+		}
+
+		indexes[alias] = len(items)
+		items = append(items, dto.TopicRequest{Title: title, Count: 1})
+	}
+
+	mentions, err := toTopicMentions(s.uuidPkg.NewV7, items)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("TopicExtractionService.toMentions").WithError(err)
+	}
+
+	return mentions, nil
+}
+
+func (s *TopicExtractionService) recordTopics(ctx context.Context, messageIDs []uuid.UUID, mentions []entity.TopicMention, now time.Time) error {
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("TopicExtractionService.recordTopics").WithError(err)
+	}
+
+	batch := &entity.TopicBatch{
+		ID:          id,
+		BatchID:     extractionBatchID(messageIDs),
+		Source:      entity.TopicSourceExtraction,
+		PayloadHash: topicBatchHash(mentions),
+		ActorType:   entity.AuditActorSystem,
+		CreatedAt:   now,
+		MessageIDs:  messageIDs,
+	}
+
+	// Gemini may classify a retried batch differently; the first answer stands
+	_, replayed, err := s.topicRepo.BulkCreate(ctx, batch, mentions)
+	if err != nil {
+		return err
+	}
+
+	if replayed {
+		log.Info(log.CustomLogInfo{
+			"batch_id": batch.BatchID,
+		}, "[TopicExtraction] Batch already recorded")
+	}
+
+	return nil
+}
+
+// extractionBatchID fingerprints the messages in a batch, so only a batch of
+// exactly the same messages counts as already recorded
+func extractionBatchID(messageIDs []uuid.UUID) string {
+	hash := sha256.New()
+	for _, id := range messageIDs {
+		hash.Write([]byte(id.String() + "\n"))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	conversationRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/repository/mock"
+	topicRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/repository/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/topicclassifier"
+	mockClassifier "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/topicclassifier/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTopicExtractionService_ExtractNext(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
+	mockConversationRepo := conversationRepoMock.NewMockConversationRepository(ctrl)
+	mockClassifier := mockClassifier.NewMockTopicClassifierInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+
+	service := NewTopicExtractionService(mockTopicRepo, mockConversationRepo, mockClassifier, mockUUID)
+	ctx := context.Background()
+
+	questions := []entity.PendingQuestion{
+		{ID: uuid.New(), Content: "cara ajukan cuti?", Answered: true},
+		{ID: uuid.New(), Content: "5", Answered: false},
+		{ID: uuid.New(), Content: "sisa cuti saya berapa?", Answered: true},
+		{ID: uuid.New(), Content: "terima kasih", Answered: true},
+	}
+	questionIDs := []uuid.UUID{questions[0].ID, questions[1].ID, questions[2].ID, questions[3].ID}
+	leaveID := uuid.New()
+	batchID := uuid.New()
+
+	tests := []struct {
+		name          string
+		setup         func()
+		wantExtracted bool
+		wantErr       bool
+	}{
+		{
+			name: "success - answered questions counted per topic",
+			setup: func() {
+				mockConversationRepo.EXPECT().ListPendingQuestions(ctx, gomock.Any(), topicExtractionBatchSize).DoAndReturn(func(ctx context.Context, sentBefore time.Time, limit int) ([]entity.PendingQuestion, error) {
+					assert.WithinDuration(t, time.Now().Add(-topicExtractionSettleDelay), sentBefore, time.Minute)
+					return questions, nil
+				})
+				mockTopicRepo.EXPECT().ListCanonicalTopicNames(ctx, topicExtractionMaxTopics).Return([]string{"Cuti Tahunan"}, nil)
+				mockClassifier.EXPECT().Classify(ctx, []string{"Cuti Tahunan"}, []string{"cara ajukan cuti?", "sisa cuti saya berapa?", "terima kasih"}).
+					Return([]string{"Cuti Tahunan", "cuti  tahunan", ""}, nil)
+				mockUUID.EXPECT().NewV7().Return(leaveID, nil)
+				mockUUID.EXPECT().NewV7().Return(batchID, nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), []entity.TopicMention{
+					{Title: "Cuti Tahunan", Alias: "cuti tahunan", Count: 2, NewCanonicalTopicID: leaveID},
+				}).DoAndReturn(func(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error) {
+					assert.Equal(t, batchID, batch.ID)
+					assert.Equal(t, extractionBatchID(questionIDs), batch.BatchID)
+					assert.Equal(t, entity.TopicSourceExtraction, batch.Source)
+					assert.Equal(t, entity.AuditActorSystem, batch.ActorType)
+					// Marked along with the topics
+					assert.Equal(t, questionIDs, batch.MessageIDs)
+					return batch, false, nil
+				})
+			},
+			wantExtracted: true,
+		},
+		{
+			name: "success - batch another run recorded isn't counted again",
+			setup: func() {
+				mockConversationRepo.EXPECT().ListPendingQuestions(ctx, gomock.Any(), gomock.Any()).Return(questions, nil)
+				mockTopicRepo.EXPECT().ListCanonicalTopicNames(ctx, gomock.Any()).Return([]string{"Cuti Tahunan"}, nil)
+				mockClassifier.EXPECT().Classify(ctx, gomock.Any(), gomock.Any()).Return([]string{"Cuti", "Cuti", "Salam"}, nil)
+				mockUUID.EXPECT().NewV7().Return(uuid.New(), nil).Times(3)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), gomock.Any()).Return(&entity.TopicBatch{ID: batchID}, true, nil)
+			},
+			wantExtracted: true,
+		},
+		{
+			name: "success - no answered questions skips the classifier",
+			setup: func() {
+				mockConversationRepo.EXPECT().ListPendingQuestions(ctx, gomock.Any(), gomock.Any()).Return([]entity.PendingQuestion{questions[1]}, nil)
+				mockConversationRepo.EXPECT().MarkTopicsExtracted(ctx, []uuid.UUID{questions[1].ID}, gomock.Any()).Return(nil)
+			},
+			wantExtracted: true,
+		},
+		{
+			name: "success - no topics found",
+			setup: func() {
+				mockConversationRepo.EXPECT().ListPendingQuestions(ctx, gomock.Any(), gomock.Any()).Return([]entity.PendingQuestion{questions[3]}, nil)
+				mockTopicRepo.EXPECT().ListCanonicalTopicNames(ctx, gomock.Any()).Return([]string{}, nil)
+				mockClassifier.EXPECT().Classify(ctx, []string{}, []string{"terima kasih"}).Return([]string{""}, nil)
+				mockConversationRepo.EXPECT().MarkTopicsExtracted(ctx, []uuid.UUID{questions[3].ID}, gomock.Any()).Return(nil)
+			},
+			wantExtracted: true,
+		},
+		{
+			name: "nothing pending",
+			setup: func() {
+				mockConversationRepo.EXPECT().ListPendingQuestions(ctx, gomock.Any(), gomock.Any()).Return([]entity.PendingQuestion{}, nil)
+			},
+			wantExtracted: false,
+		},
+		{
+			name: "classifier error leaves the batch pending",
+			setup: func() {
+				mockConversationRepo.EXPECT().ListPendingQuestions(ctx, gomock.Any(), gomock.Any()).Return(questions, nil)
+				mockTopicRepo.EXPECT().ListCanonicalTopicNames(ctx, gomock.Any()).Return([]string{}, nil)
+				mockClassifier.EXPECT().Classify(ctx, gomock.Any(), gomock.Any()).Return(nil, errors.New("quota exceeded"))
+				mockConversationRepo.EXPECT().RecordTopicExtractionFailure(ctx, questionIDs).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success - questions that keep failing are marked as seen",
+			setup: func() {
+				failing := questions[2]
+				failing.Attempts = topicExtractionMaxAttempts - 1
+				mockConversationRepo.EXPECT().ListPendingQuestions(ctx, gomock.Any(), gomock.Any()).Return([]entity.PendingQuestion{questions[0], failing}, nil)
+				mockTopicRepo.EXPECT().ListCanonicalTopicNames(ctx, gomock.Any()).Return([]string{}, nil)
+				mockClassifier.EXPECT().Classify(ctx, gomock.Any(), gomock.Any()).Return(nil, topicclassifier.ErrInvalidResponse)
+				mockConversationRepo.EXPECT().RecordTopicExtractionFailure(ctx, []uuid.UUID{questions[0].ID, questions[2].ID}).Return(nil)
+				// The other question is tried again without it
+				mockConversationRepo.EXPECT().MarkTopicsExtracted(ctx, []uuid.UUID{questions[2].ID}, gomock.Any()).Return(nil)
+			},
+			wantExtracted: true,
+		},
+		{
+			name: "repository error leaves the batch pending",
+			setup: func() {
+				mockConversationRepo.EXPECT().ListPendingQuestions(ctx, gomock.Any(), gomock.Any()).Return(questions, nil)
+				mockTopicRepo.EXPECT().ListCanonicalTopicNames(ctx, gomock.Any()).Return([]string{}, nil)
+				mockClassifier.EXPECT().Classify(ctx, gomock.Any(), gomock.Any()).Return([]string{"Cuti", "", ""}, nil)
+				mockUUID.EXPECT().NewV7().Return(leaveID, nil)
+				mockUUID.EXPECT().NewV7().Return(batchID, nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), gomock.Any()).Return(nil, false, errx.ErrInternalServer)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			extracted, err := service.ExtractNext(ctx)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.False(t, extracted)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantExtracted, extracted)
+			}
+		})
+	}
+}
+
+func TestExtractionBatchID(t *testing.T) {
+	ids := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	assert.Equal(t, extractionBatchID(ids), extractionBatchID(slices.Clone(ids)))
+	// A batch that grew while an earlier one was pending is a new batch
+	assert.NotEqual(t, extractionBatchID(ids[:2]), extractionBatchID(ids))
+	assert.LessOrEqual(t, len(extractionBatchID(ids)), 100)
+}
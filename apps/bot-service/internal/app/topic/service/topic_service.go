@@ -2,51 +2,210 @@ package service
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/report"
+	"github.com/google/uuid"
 )
 
-func (s *TopicService) BulkCreate(ctx context.Context, req *dto.BulkCreateTopicsRequest) error {
+// BulkCreate records each title under the canonical topic its normalized
+// form is an alias of. Titles nobody has used before start a topic of their
+// own, which admins can merge later. The batch's source is actor, so one
+// API key can't replay or collide with another's batches. A batch actor
+// already sent is returned as it was stored, unless its topics differ.
+func (s *TopicService) BulkCreate(ctx context.Context, actor entity.AuditActor, req *dto.BulkCreateTopicsRequest) (*dto.BulkCreateTopicsResponse, error) {
 	if err := s.validator.Validate(req); err != nil {
-		return err
+		return nil, err
 	}
 
-	topics := make([]entity.Topic, 0, len(req.Topics))
-	for _, item := range req.Topics {
-		topics = append(topics, entity.Topic{
-			Title: item.Title,
-			Count: item.Count,
-		})
+	mentions, err := toTopicMentions(s.uuidPkg.NewV7, req.Topics)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("TopicService.BulkCreate").WithError(err)
+	}
+
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("TopicService.BulkCreate").WithError(err)
 	}
 
-	if err := s.topicRepo.BulkCreate(ctx, topics); err != nil {
-		return err
+	batchID := req.BatchID
+	if batchID == "" {
+		batchID = id.String()
+	}
+
+	batch := &entity.TopicBatch{
+		ID:          id,
+		BatchID:     batchID,
+		Source:      topicBatchSource(actor),
+		PayloadHash: topicBatchHash(mentions),
+		ActorType:   actor.Type,
+		ActorID:     actor.ID,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	stored, replayed, err := s.topicRepo.BulkCreate(ctx, batch, mentions)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	if replayed && stored.PayloadHash != batch.PayloadHash {
+		return nil, errx.ErrTopicBatchConflict.WithDetails(map[string]any{
+			"batchId": batch.BatchID,
+			"source":  batch.Source,
+		}).WithLocation("TopicService.BulkCreate")
+	}
+
+	res := &dto.BulkCreateTopicsResponse{
+		Batch:    dto.ToTopicBatchResponse(stored),
+		Replayed: replayed,
+	}
+
+	return res, nil
 }
 
-func (s *TopicService) GetHotTopics(ctx context.Context) (*dto.GetHotTopicsResponse, error) {
-	hotTopics, err := s.topicRepo.GetHotTopics(ctx)
+// topicBatchSource names the actor that sent a batch, such as
+// "api_key:<id>"
+func topicBatchSource(actor entity.AuditActor) string {
+	if actor.ID == nil {
+		return actor.Type
+	}
+
+	return actor.Type + ":" + *actor.ID
+}
+
+// toTopicMentions normalizes each title and gives every distinct alias an id
+// from newID, used for the canonical topic it starts if it turns out to be new
+func toTopicMentions(newID func() (uuid.UUID, error), items []dto.TopicRequest) ([]entity.TopicMention, error) {
+	newCanonicalTopicIDs := make(map[string]uuid.UUID, len(items))
+	mentions := make([]entity.TopicMention, 0, len(items))
+	for _, item := range items {
+		title, alias := normalizeTopicTitle(item.Title)
+		// A blank title names no topic
+		if alias == "" {
+			continue
+		}
+
+		id, ok := newCanonicalTopicIDs[alias]
+		if !ok {
+			var err error
+			id, err = newID()
+			if err != nil {
+				return nil, err
+			}
+			newCanonicalTopicIDs[alias] = id
+		}
+
+		mentions = append(mentions, entity.TopicMention{
+			Title:               title,
+			Alias:               alias,
+			Count:               item.Count,
+			NewCanonicalTopicID: id,
+		})
+	}
+
+	return mentions, nil
+}
+
+// normalizeTopicTitle collapses whitespace in title, and lowercases the
+// result into the alias it is looked up by
+func normalizeTopicTitle(title string) (string, string) {
+	normalized := strings.Join(strings.Fields(title), " ")
+	return normalized, strings.ToLower(normalized)
+}
+
+// Hot topics cover this many days and list this many titles by default
+const (
+	defaultHotTopicsWindow = 30
+	defaultHotTopicsLimit  = 5
+)
+
+func (s *TopicService) GetHotTopics(ctx context.Context, query *dto.GetHotTopicsQuery) (*dto.GetHotTopicsResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	window := query.Window
+	if window == 0 {
+		window = defaultHotTopicsWindow
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultHotTopicsLimit
+	}
+
+	loc, err := report.LoadLocation(query.Timezone, "TopicService.GetHotTopics")
 	if err != nil {
 		return nil, err
 	}
 
-	hotTopicsData := make([]dto.TopicResponse, 0, len(hotTopics))
-	for _, topic := range hotTopics {
-		hotTopicsData = append(hotTopicsData, dto.ToTopicResponse(&topic))
+	from, to, err := report.ParseRange(query.From, query.To, loc, report.LastDays(window), "TopicService.GetHotTopics")
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.HotTopicsFilter{
+		From:         from,
+		To:           to,
+		PreviousFrom: report.PreviousStart(from, to),
+		Limit:        limit,
 	}
 
+	hotTopics, err := s.topicRepo.GetHotTopics(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	hotTopicsData := make([]dto.HotTopicResponse, 0, len(hotTopics))
+	for i := range hotTopics {
+		hotTopicsData = append(hotTopicsData, dto.ToHotTopicResponse(&hotTopics[i]))
+	}
+
+	fromDate, toDate := report.Dates(from, to)
+	previousFromDate, previousToDate := report.Dates(filter.PreviousFrom, from)
 	res := &dto.GetHotTopicsResponse{
-		Topics: hotTopicsData,
+		From:         fromDate,
+		To:           toDate,
+		PreviousFrom: previousFromDate,
+		PreviousTo:   previousToDate,
+		Timezone:     loc.String(),
+		Topics:       hotTopicsData,
 	}
 
 	return res, nil
 }
 
-func (s *TopicService) GetTopicsCount(ctx context.Context) (*dto.GetTopicsCountResponse, error) {
-	count, err := s.topicRepo.GetTopicsCount(ctx)
+func (s *TopicService) GetTopicsCount(ctx context.Context, query *dto.GetTopicsCountQuery) (*dto.GetTopicsCountResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	filter := entity.TopicsCountFilter{}
+	if query.Window != 0 || query.From != nil || query.To != nil {
+		window := query.Window
+		if window == 0 {
+			window = defaultHotTopicsWindow
+		}
+
+		loc, err := report.LoadLocation(query.Timezone, "TopicService.GetTopicsCount")
+		if err != nil {
+			return nil, err
+		}
+
+		from, to, err := report.ParseRange(query.From, query.To, loc, report.LastDays(window), "TopicService.GetTopicsCount")
+		if err != nil {
+			return nil, err
+		}
+
+		filter.From = &from
+		filter.To = &to
+	}
+
+	count, err := s.topicRepo.GetTopicsCount(ctx, &filter)
 	if err != nil {
 		return nil, err
 	}
@@ -57,3 +216,66 @@ func (s *TopicService) GetTopicsCount(ctx context.Context) (*dto.GetTopicsCountR
 
 	return res, nil
 }
+
+func (s *TopicService) GetTopicSeries(ctx context.Context, query *dto.GetTopicSeriesQuery) (*dto.GetTopicSeriesResponse, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	granularity := query.Granularity
+	if granularity == "" {
+		granularity = entity.TrendGranularityDay
+	}
+
+	loc, err := report.LoadLocation(query.Timezone, "TopicService.GetTopicSeries")
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := report.ParseRange(query.From, query.To, loc, func(lastDay time.Time) time.Time {
+		return report.TrendStart(granularity, lastDay)
+	}, "TopicService.GetTopicSeries")
+	if err != nil {
+		return nil, err
+	}
+
+	_, alias := normalizeTopicTitle(query.Title)
+	topic, err := s.topicRepo.FindCanonicalTopicByAlias(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.TopicSeriesFilter{
+		CanonicalTopicID: topic.ID,
+		Granularity:      granularity,
+		Timezone:         loc.String(),
+		From:             from,
+		To:               to,
+	}
+
+	results, err := s.topicRepo.GetTopicSeries(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+
+	series := make([]dto.TopicSeriesData, 0, len(results))
+	for _, result := range results {
+		// The bucket is a wall clock time in loc
+		date := time.Date(result.Date.Year(), result.Date.Month(), result.Date.Day(), 0, 0, 0, 0, loc)
+
+		series = append(series, dto.TopicSeriesData{
+			Date:  date.Format(time.RFC3339),
+			Count: result.Count,
+		})
+	}
+
+	res := &dto.GetTopicSeriesResponse{
+		TopicID:     topic.ID.String(),
+		Title:       topic.Name,
+		Granularity: granularity,
+		Timezone:    loc.String(),
+		Series:      series,
+	}
+
+	return res, nil
+}
@@ -9,9 +9,12 @@ import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
 	topicRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/repository/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
 	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -22,20 +25,39 @@ func TestTopicService_BulkCreate(t *testing.T) {
 
 	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewTopicService(mockTopicRepo, mockValidator)
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
 	ctx := context.Background()
 
+	testID1 := uuid.New()
+	testID2 := uuid.New()
+	batchID := uuid.New()
+	apiKeyID := uuid.NewString()
+	actor := entity.AuditActor{Type: entity.AuditActorAPIKey, ID: &apiKeyID}
+
+	// Stands in for the repository, filling in what it would have counted
+	recordBatch := func(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error) {
+		stored := *batch
+		for _, mention := range mentions {
+			stored.MentionCount += mention.Count
+		}
+		return &stored, false, nil
+	}
+
 	tests := []struct {
-		name         string
-		req          *dto.BulkCreateTopicsRequest
-		setup        func()
-		wantErr      bool
-		errType      error
+		name    string
+		req     *dto.BulkCreateTopicsRequest
+		setup   func()
+		wantErr bool
+		errType error
+		check   func(*testing.T, *dto.BulkCreateTopicsResponse)
 	}{
 		{
 			name: "success - create topics",
 			req: &dto.BulkCreateTopicsRequest{
+				BatchID: "run-1",
 				Topics: []dto.TopicRequest{
 					{Title: "Billing", Count: 5},
 					{Title: "Support", Count: 3},
@@ -43,44 +65,137 @@ func TestTopicService_BulkCreate(t *testing.T) {
 			},
 			setup: func() {
 				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, topics []entity.Topic) error {
-					assert.Len(t, topics, 2)
-					assert.Equal(t, "Billing", topics[0].Title)
-					assert.Equal(t, 5, topics[0].Count)
-					assert.Equal(t, "Support", topics[1].Title)
-					assert.Equal(t, 3, topics[1].Count)
-					return nil
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUUID.EXPECT().NewV7().Return(testID2, nil)
+				mockUUID.EXPECT().NewV7().Return(batchID, nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error) {
+					assert.Equal(t, batchID, batch.ID)
+					assert.Equal(t, "run-1", batch.BatchID)
+					// Tied to the key that sent it
+					assert.Equal(t, "api_key:"+apiKeyID, batch.Source)
+					assert.Equal(t, entity.AuditActorAPIKey, batch.ActorType)
+					assert.Equal(t, &apiKeyID, batch.ActorID)
+					assert.Len(t, batch.PayloadHash, 64)
+					// Stored without a zone, so it has to be UTC already
+					assert.Equal(t, time.UTC, batch.CreatedAt.Location())
+					assert.Equal(t, []entity.TopicMention{
+						{Title: "Billing", Alias: "billing", Count: 5, NewCanonicalTopicID: testID1},
+						{Title: "Support", Alias: "support", Count: 3, NewCanonicalTopicID: testID2},
+					}, mentions)
+					return recordBatch(ctx, batch, mentions)
+				})
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.BulkCreateTopicsResponse) {
+				assert.False(t, res.Replayed)
+				assert.Equal(t, batchID.String(), res.Batch.ID)
+				assert.Equal(t, "run-1", res.Batch.BatchID)
+				assert.Equal(t, 8, res.Batch.MentionCount)
+				assert.Nil(t, res.Batch.RolledBackAt)
+			},
+		},
+		{
+			name: "success - titles differing in case and spacing share an alias",
+			req: &dto.BulkCreateTopicsRequest{
+				BatchID: "run-2",
+				Topics: []dto.TopicRequest{
+					{Title: " Cuti  Tahunan ", Count: 5},
+					{Title: "cuti tahunan", Count: 3},
+					{Title: "Support", Count: 2},
+				},
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUUID.EXPECT().NewV7().Return(testID2, nil)
+				mockUUID.EXPECT().NewV7().Return(batchID, nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error) {
+					assert.Len(t, mentions, 3)
+					assert.Equal(t, "Cuti Tahunan", mentions[0].Title)
+					assert.Equal(t, "cuti tahunan", mentions[0].Alias)
+					assert.Equal(t, "cuti tahunan", mentions[1].Title)
+					assert.Equal(t, "cuti tahunan", mentions[1].Alias)
+					assert.Equal(t, testID1, mentions[0].NewCanonicalTopicID)
+					assert.Equal(t, testID1, mentions[1].NewCanonicalTopicID)
+					assert.Equal(t, testID2, mentions[2].NewCanonicalTopicID)
+					return recordBatch(ctx, batch, mentions)
 				})
 			},
-			wantErr:      false,
+			wantErr: false,
 		},
 		{
-			name: "success - single topic",
+			name: "success - blank titles are skipped",
 			req: &dto.BulkCreateTopicsRequest{
+				BatchID: "run-3",
 				Topics: []dto.TopicRequest{
-					{Title: "General Inquiry", Count: 10},
+					{Title: "   ", Count: 5},
 				},
 			},
 			setup: func() {
 				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(batchID, nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), []entity.TopicMention{}).DoAndReturn(recordBatch)
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.BulkCreateTopicsResponse) {
+				assert.Equal(t, 0, res.Batch.MentionCount)
 			},
-			wantErr:      false,
 		},
 		{
-			name: "success - allow duplicates",
+			name: "success - replayed batch returns the stored one",
 			req: &dto.BulkCreateTopicsRequest{
+				BatchID: "run-1",
 				Topics: []dto.TopicRequest{
+					{Title: "support", Count: 3},
 					{Title: "Billing", Count: 5},
-					{Title: "Billing", Count: 3},
-					{Title: "Support", Count: 2},
 				},
 			},
 			setup: func() {
 				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil).Times(2)
+				mockUUID.EXPECT().NewV7().Return(uuid.New(), nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error) {
+					// Same topics as the first call, in another order and spelling
+					stored := &entity.TopicBatch{
+						ID:           batchID,
+						BatchID:      "run-1",
+						Source:       "api_key:" + apiKeyID,
+						PayloadHash:  batch.PayloadHash,
+						TopicCount:   2,
+						MentionCount: 8,
+						ActorType:    entity.AuditActorAPIKey,
+					}
+					return stored, true, nil
+				})
+			},
+			wantErr: false,
+			check: func(t *testing.T, res *dto.BulkCreateTopicsResponse) {
+				assert.True(t, res.Replayed)
+				assert.Equal(t, batchID.String(), res.Batch.ID)
+				assert.Equal(t, 2, res.Batch.TopicCount)
+			},
+		},
+		{
+			name: "batch ID reused with different topics",
+			req: &dto.BulkCreateTopicsRequest{
+				BatchID: "run-1",
+				Topics: []dto.TopicRequest{
+					{Title: "Billing", Count: 6},
+				},
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUUID.EXPECT().NewV7().Return(uuid.New(), nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), gomock.Any()).Return(&entity.TopicBatch{
+					ID:          batchID,
+					BatchID:     "run-1",
+					Source:      "api_key:" + apiKeyID,
+					PayloadHash: topicBatchHash([]entity.TopicMention{{Alias: "billing", Count: 5}}),
+				}, true, nil)
 			},
-			wantErr:      false,
+			wantErr: true,
+			errType: errx.ErrTopicBatchConflict,
 		},
 		{
 			name: "validation error - empty topics",
@@ -97,20 +212,26 @@ func TestTopicService_BulkCreate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "validation error - invalid title",
+			name: "success - a missing batch ID starts a new batch",
 			req: &dto.BulkCreateTopicsRequest{
 				Topics: []dto.TopicRequest{
-					{Title: "", Count: 5},
+					{Title: "Billing", Count: 5},
 				},
 			},
 			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
-					"body.topics[0].title": validator.ValidationError{
-						Message: "title is required",
-					},
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUUID.EXPECT().NewV7().Return(batchID, nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, batch *entity.TopicBatch, mentions []entity.TopicMention) (*entity.TopicBatch, bool, error) {
+					assert.Equal(t, batchID.String(), batch.BatchID)
+					return recordBatch(ctx, batch, mentions)
 				})
 			},
-			wantErr: true,
+			wantErr: false,
+			check: func(t *testing.T, res *dto.BulkCreateTopicsResponse) {
+				assert.False(t, res.Replayed)
+				assert.Equal(t, batchID.String(), res.Batch.BatchID)
+			},
 		},
 		{
 			name: "validation error - invalid count",
@@ -142,16 +263,34 @@ func TestTopicService_BulkCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "uuid error",
+			req: &dto.BulkCreateTopicsRequest{
+				BatchID: "run-1",
+				Topics: []dto.TopicRequest{
+					{Title: "Billing", Count: 5},
+				},
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(uuid.Nil, errors.New("uuid error"))
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
 		{
 			name: "repository error",
 			req: &dto.BulkCreateTopicsRequest{
+				BatchID: "run-1",
 				Topics: []dto.TopicRequest{
 					{Title: "Billing", Count: 5},
 				},
 			},
 			setup: func() {
 				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any()).Return(errx.ErrInternalServer)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUUID.EXPECT().NewV7().Return(batchID, nil)
+				mockTopicRepo.EXPECT().BulkCreate(ctx, gomock.Any(), gomock.Any()).Return(nil, false, errx.ErrInternalServer)
 			},
 			wantErr: true,
 			errType: errx.ErrInternalServer,
@@ -161,15 +300,20 @@ func TestTopicService_BulkCreate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			err := service.BulkCreate(ctx, tt.req)
+			result, err := service.BulkCreate(ctx, actor, tt.req)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				assert.Nil(t, result)
 				if tt.errType != nil {
 					assert.ErrorIs(t, err, tt.errType)
 				}
 			} else {
 				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				if tt.check != nil {
+					tt.check(t, result)
+				}
 			}
 		})
 	}
@@ -181,57 +325,156 @@ func TestTopicService_GetHotTopics(t *testing.T) {
 
 	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewTopicService(mockTopicRepo, mockValidator)
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
 	ctx := context.Background()
 
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	assert.NoError(t, err)
+
 	testDate1 := time.Now().AddDate(0, 0, -2)
 	testDate2 := time.Now().AddDate(0, 0, -1)
 	testDate3 := time.Now()
 
-	testHotTopics := []entity.Topic{
-		{ID: 1, Title: "Billing", Count: 18, CreatedAt: testDate1},
-		{ID: 2, Title: "Support", Count: 7, CreatedAt: testDate2},
-		{ID: 3, Title: "Feedback", Count: 5, CreatedAt: testDate3},
-		{ID: 4, Title: "Technical Issue", Count: 3, CreatedAt: testDate1},
-		{ID: 5, Title: "General Inquiry", Count: 2, CreatedAt: testDate2},
+	bpjsID := uuid.New()
+	testHotTopics := []entity.HotTopic{
+		{Topic: entity.Topic{ID: 1, CanonicalTopicID: bpjsID, Title: "BPJS", Count: 18, CreatedAt: testDate1}, PreviousCount: 10},
+		{Topic: entity.Topic{ID: 2, Title: "Payroll", Count: 7, CreatedAt: testDate2}, PreviousCount: 9},
+		{Topic: entity.Topic{ID: 3, Title: "Leave", Count: 5, CreatedAt: testDate3}, PreviousCount: 5},
 	}
 
+	from := "2025-12-01"
+	to := "2025-12-10"
+	invalidDate := "2025-12-32"
+
 	tests := []struct {
 		name      string
+		query     *dto.GetHotTopicsQuery
 		setup     func()
 		wantErr   bool
 		wantCount int
 		errType   error
+		check     func(*testing.T, *dto.GetHotTopicsResponse)
 	}{
 		{
-			name: "success with hot topics data",
+			name:  "success - defaults to the top 5 over the last 30 days",
+			query: &dto.GetHotTopicsQuery{},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetHotTopics(ctx).Return(testHotTopics, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetHotTopics(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.HotTopicsFilter) ([]entity.HotTopic, error) {
+					now := time.Now().In(jakarta)
+					tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, jakarta)
+					assert.Equal(t, 5, filter.Limit)
+					assert.True(t, tomorrow.Equal(filter.To))
+					assert.True(t, tomorrow.AddDate(0, 0, -30).Equal(filter.From))
+					assert.True(t, tomorrow.AddDate(0, 0, -60).Equal(filter.PreviousFrom))
+					return testHotTopics, nil
+				})
 			},
 			wantErr:   false,
-			wantCount: 5,
+			wantCount: 3,
+			check: func(t *testing.T, res *dto.GetHotTopicsResponse) {
+				assert.Equal(t, "Asia/Jakarta", res.Timezone)
+				assert.Equal(t, bpjsID.String(), res.Topics[0].TopicID)
+				assert.Equal(t, "BPJS", res.Topics[0].Title)
+				assert.Equal(t, 18, res.Topics[0].Count)
+				assert.Equal(t, 10, res.Topics[0].PreviousCount)
+				assert.Equal(t, entity.TopicTrendRising, res.Topics[0].Trend)
+				assert.Equal(t, entity.TopicTrendFalling, res.Topics[1].Trend)
+				assert.Equal(t, entity.TopicTrendSteady, res.Topics[2].Trend)
+			},
 		},
 		{
-			name: "success with empty results",
+			name: "success - 7 day window up to a date",
+			query: &dto.GetHotTopicsQuery{
+				Window: 7,
+				To:     &to,
+				Limit:  10,
+			},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetHotTopics(ctx).Return([]entity.Topic{}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetHotTopics(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.HotTopicsFilter) ([]entity.HotTopic, error) {
+					assert.Equal(t, 10, filter.Limit)
+					return []entity.HotTopic{}, nil
+				})
 			},
 			wantErr:   false,
 			wantCount: 0,
+			check: func(t *testing.T, res *dto.GetHotTopicsResponse) {
+				assert.Equal(t, "2025-12-04", res.From)
+				assert.Equal(t, "2025-12-10", res.To)
+				assert.Equal(t, "2025-11-27", res.PreviousFrom)
+				assert.Equal(t, "2025-12-03", res.PreviousTo)
+			},
 		},
 		{
-			name: "repository error",
+			name: "success - custom range in the requested zone",
+			query: &dto.GetHotTopicsQuery{
+				From:     &from,
+				To:       &to,
+				Timezone: "Asia/Makassar",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetHotTopics(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.HotTopicsFilter) ([]entity.HotTopic, error) {
+					assert.Equal(t, "2025-12-01T00:00:00+08:00", filter.From.Format(time.RFC3339))
+					// "to" is inclusive
+					assert.Equal(t, "2025-12-11T00:00:00+08:00", filter.To.Format(time.RFC3339))
+					assert.Equal(t, "2025-11-21T00:00:00+08:00", filter.PreviousFrom.Format(time.RFC3339))
+					return []entity.HotTopic{}, nil
+				})
+			},
+			wantErr:   false,
+			wantCount: 0,
+			check: func(t *testing.T, res *dto.GetHotTopicsResponse) {
+				assert.Equal(t, "Asia/Makassar", res.Timezone)
+				assert.Equal(t, "2025-11-30", res.PreviousTo)
+			},
+		},
+		{
+			name: "invalid date",
+			query: &dto.GetHotTopicsQuery{
+				From: &invalidDate,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidDateFormat,
+		},
+		{
+			name: "validation error - window with a custom range",
+			query: &dto.GetHotTopicsQuery{
+				Window: 7,
+				From:   &from,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"query.window": validator.ValidationError{
+						Message: "Window is an excluded field",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetHotTopicsQuery{},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetHotTopics(ctx).Return(nil, errx.ErrInternalServer)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetHotTopics(ctx, gomock.Any()).Return(nil, errx.ErrInternalServer)
 			},
 			wantErr: true,
 			errType: errx.ErrInternalServer,
 		},
 		{
-			name: "database connection error",
+			name:  "database connection error",
+			query: &dto.GetHotTopicsQuery{},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetHotTopics(ctx).Return(nil, errors.New("database connection failed"))
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetHotTopics(ctx, gomock.Any()).Return(nil, errors.New("database connection failed"))
 			},
 			wantErr: true,
 		},
@@ -240,7 +483,7 @@ func TestTopicService_GetHotTopics(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			result, err := service.GetHotTopics(ctx)
+			result, err := service.GetHotTopics(ctx, tt.query)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -252,14 +495,8 @@ func TestTopicService_GetHotTopics(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
 				assert.Len(t, result.Topics, tt.wantCount)
-
-				if tt.wantCount > 0 {
-					for _, topic := range result.Topics {
-						assert.NotZero(t, topic.ID)
-						assert.NotEmpty(t, topic.Title)
-						assert.GreaterOrEqual(t, topic.Count, 0)
-						assert.NotEmpty(t, topic.CreatedAt)
-					}
+				if tt.check != nil {
+					tt.check(t, result)
 				}
 			}
 		})
@@ -272,62 +509,196 @@ func TestTopicService_GetTopicsCount(t *testing.T) {
 
 	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewTopicService(mockTopicRepo, mockValidator)
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
 	ctx := context.Background()
 
+	to := "2025-12-10"
+
 	tests := []struct {
 		name      string
+		query     *dto.GetTopicsCountQuery
 		setup     func()
 		wantErr   bool
 		wantCount int
 		errType   error
 	}{
 		{
-			name: "success with positive count",
+			name:  "success - all time",
+			query: &dto.GetTopicsCountQuery{},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetTopicsCount(ctx).Return(42, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetTopicsCount(ctx, &entity.TopicsCountFilter{}).Return(42, nil)
 			},
 			wantErr:   false,
 			wantCount: 42,
 		},
 		{
-			name: "success with zero count",
+			name: "success - 90 day window",
+			query: &dto.GetTopicsCountQuery{
+				Window: 90,
+				To:     &to,
+			},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetTopicsCount(ctx).Return(0, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetTopicsCount(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.TopicsCountFilter) (int, error) {
+					assert.Equal(t, "2025-09-12", filter.From.Format(time.DateOnly))
+					assert.Equal(t, "2025-12-11", filter.To.Format(time.DateOnly))
+					return 7, nil
+				})
 			},
 			wantErr:   false,
-			wantCount: 0,
+			wantCount: 7,
 		},
 		{
-			name: "success with large count",
+			name:  "repository error",
+			query: &dto.GetTopicsCountQuery{},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetTopicsCount(ctx).Return(1000, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetTopicsCount(ctx, gomock.Any()).Return(0, errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+		{
+			name:  "database connection error",
+			query: &dto.GetTopicsCountQuery{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().GetTopicsCount(ctx, gomock.Any()).Return(0, errors.New("database connection failed"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.GetTopicsCount(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+				assert.Equal(t, tt.wantCount, result.TotalTopics)
+			}
+		})
+	}
+}
+
+func TestTopicService_GetTopicSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTopicRepo := topicRepoMock.NewMockTopicRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewTopicService(mockTopicRepo, mockValidator, mockUUID, mockAudit)
+	ctx := context.Background()
+
+	// Buckets come back as wall clock times in the requested zone
+	testDate := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	from := "2025-12-01"
+	to := "2025-12-31"
+	farFrom := "2020-01-01"
+	bpjs := &entity.CanonicalTopic{ID: uuid.New(), Name: "BPJS Kesehatan"}
+
+	tests := []struct {
+		name      string
+		query     *dto.GetTopicSeriesQuery
+		setup     func()
+		wantErr   bool
+		wantCount int
+		errType   error
+	}{
+		{
+			name: "success - weekly buckets in the requested zone",
+			query: &dto.GetTopicSeriesQuery{
+				Title:       " BPJS ",
+				From:        &from,
+				To:          &to,
+				Granularity: entity.TrendGranularityWeek,
+				Timezone:    "Asia/Makassar",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByAlias(ctx, "bpjs").Return(bpjs, nil)
+				mockTopicRepo.EXPECT().GetTopicSeries(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.TopicSeriesFilter) ([]entity.TopicSeriesRow, error) {
+					assert.Equal(t, bpjs.ID, filter.CanonicalTopicID)
+					assert.Equal(t, entity.TrendGranularityWeek, filter.Granularity)
+					assert.Equal(t, "Asia/Makassar", filter.Timezone)
+					assert.Equal(t, "2025-12-01T00:00:00+08:00", filter.From.Format(time.RFC3339))
+					assert.Equal(t, "2026-01-01T00:00:00+08:00", filter.To.Format(time.RFC3339))
+					return []entity.TopicSeriesRow{
+						{Date: testDate, Count: 4},
+						{Date: testDate.AddDate(0, 0, 7), Count: 0},
+					}, nil
+				})
 			},
 			wantErr:   false,
-			wantCount: 1000,
+			wantCount: 2,
 		},
 		{
-			name: "repository error",
+			name: "range longer than two years",
+			query: &dto.GetTopicSeriesQuery{
+				Title: "BPJS",
+				From:  &farFrom,
+				To:    &to,
+			},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetTopicsCount(ctx).Return(0, errx.ErrInternalServer)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
 			},
 			wantErr: true,
-			errType: errx.ErrInternalServer,
+			errType: errx.ErrInvalidDateRange,
 		},
 		{
-			name: "database connection error",
+			name:  "validation error - missing title",
+			query: &dto.GetTopicSeriesQuery{},
 			setup: func() {
-				mockTopicRepo.EXPECT().GetTopicsCount(ctx).Return(0, errors.New("database connection failed"))
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"query.title": validator.ValidationError{
+						Message: "title is a required field",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name:  "unknown topic",
+			query: &dto.GetTopicSeriesQuery{Title: "Parking"},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByAlias(ctx, "parking").Return(nil, errx.ErrTopicNotFound)
 			},
 			wantErr: true,
+			errType: errx.ErrTopicNotFound,
+		},
+		{
+			name:  "repository error",
+			query: &dto.GetTopicSeriesQuery{Title: "BPJS"},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTopicRepo.EXPECT().FindCanonicalTopicByAlias(ctx, "bpjs").Return(bpjs, nil)
+				mockTopicRepo.EXPECT().GetTopicSeries(ctx, gomock.Any()).Return(nil, errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			result, err := service.GetTopicsCount(ctx)
+			result, err := service.GetTopicSeries(ctx, tt.query)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -338,7 +709,13 @@ func TestTopicService_GetTopicsCount(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
-				assert.Equal(t, tt.wantCount, result.TotalTopics)
+				assert.Len(t, result.Series, tt.wantCount)
+				assert.Equal(t, bpjs.ID.String(), result.TopicID)
+				assert.Equal(t, "BPJS Kesehatan", result.Title)
+				if tt.wantCount > 0 {
+					assert.Equal(t, "2025-12-01T00:00:00+08:00", result.Series[0].Date)
+					assert.Equal(t, 4, result.Series[0].Count)
+				}
 			}
 		})
 	}
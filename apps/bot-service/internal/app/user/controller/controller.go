@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/service"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
 	"github.com/gofiber/fiber/v2"
@@ -15,15 +16,20 @@ func InitUserController(router fiber.Router, userSvc *service.UserService, middl
 		userSvc: userSvc,
 	}
 
-	userRouter := router.Group("/users")
+	userRouter := router.Group("/users", middleware.RequireAuth())
 
-	// TODO: Add middleware for authentication and authorization
-	userRouter.Post("/", controller.create)
-	userRouter.Post("/import-csv", controller.importCSV)
-	userRouter.Get("/", controller.list)
-	userRouter.Get("/metrics", controller.getMetrics)
-	userRouter.Get("/phone-numbers", controller.getAllPhoneNumbers)
-	userRouter.Patch("/:id", controller.update)
-	userRouter.Delete("/:id", controller.delete)
-	userRouter.Get("/:id", controller.getByID)
+	// Viewers only see aggregate numbers, not personal data
+	canRead := middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin, entity.AdminRoleViewer)
+	canManage := middleware.RequireRole(entity.AdminRoleSuperadmin, entity.AdminRoleHCAdmin)
+
+	userRouter.Post("/", canManage, controller.create)
+	userRouter.Post("/import", canManage, controller.importFile)
+	userRouter.Post("/import-csv", canManage, controller.importFile) // Kept for older dashboard builds
+	userRouter.Get("/", canManage, controller.list)
+	userRouter.Get("/export", canManage, controller.export)
+	userRouter.Get("/metrics", canRead, controller.getMetrics)
+	userRouter.Get("/phone-numbers", canManage, controller.getAllPhoneNumbers)
+	userRouter.Patch("/:id", canManage, controller.update)
+	userRouter.Delete("/:id", canManage, controller.delete)
+	userRouter.Get("/:id", canManage, controller.getByID)
 }
@@ -1,12 +1,15 @@
 package controller
 
 import (
+	"mime"
 	"net/http"
 	"slices"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -16,7 +19,7 @@ func (c *UserController) create(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	res, err := c.userSvc.Create(ctx.Context(), &req)
+	res, err := c.userSvc.Create(ctx.Context(), middlewares.GetAuditActor(ctx), &req)
 	if err != nil {
 		return err
 	}
@@ -43,6 +46,9 @@ func (c *UserController) list(ctx *fiber.Ctx) error {
 	if err := ctx.QueryParser(&query); err != nil {
 		return err
 	}
+	if query.Cursor == "" {
+		query.Cursor = ctx.Get("X-Cursor")
+	}
 
 	res, err := c.userSvc.List(ctx.Context(), &query)
 	if err != nil {
@@ -52,6 +58,20 @@ func (c *UserController) list(ctx *fiber.Ctx) error {
 	return response.SendResponse(ctx, fiber.StatusOK, res)
 }
 
+func (c *UserController) export(ctx *fiber.Ctx) error {
+	var query dto.ExportUsersQuery
+	if err := ctx.QueryParser(&query); err != nil {
+		return err
+	}
+
+	file, err := c.userSvc.Export(ctx.Context(), &query)
+	if err != nil {
+		return err
+	}
+
+	return response.SendFile(ctx, file)
+}
+
 func (c *UserController) update(ctx *fiber.Ctx) error {
 	var params dto.UpdateUserParam
 	if err := ctx.ParamsParser(&params); err != nil {
@@ -63,7 +83,7 @@ func (c *UserController) update(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	if err := c.userSvc.Update(ctx.Context(), &params, &req); err != nil {
+	if err := c.userSvc.Update(ctx.Context(), middlewares.GetAuditActor(ctx), &params, &req); err != nil {
 		return err
 	}
 
@@ -76,7 +96,7 @@ func (c *UserController) delete(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	if err := c.userSvc.Delete(ctx.Context(), &params); err != nil {
+	if err := c.userSvc.Delete(ctx.Context(), middlewares.GetAuditActor(ctx), &params); err != nil {
 		return err
 	}
 
@@ -101,10 +121,13 @@ func (c *UserController) getMetrics(ctx *fiber.Ctx) error {
 	return response.SendResponse(ctx, fiber.StatusOK, res)
 }
 
-func (c *UserController) importCSV(ctx *fiber.Ctx) error {
-	var req dto.ImportUsersFromCSVRequest
-	var err error
+func (c *UserController) importFile(ctx *fiber.Ctx) error {
+	var req dto.ImportUsersRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return err
+	}
 
+	var err error
 	req.File, err = ctx.FormFile("file")
 	if err != nil {
 		return err
@@ -129,11 +152,15 @@ func (c *UserController) importCSV(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	mimeType := http.DetectContentType(buffer[:n])
-	allowedMimeTypes := []string{"text/csv", "application/vnd.ms-excel", "text/plain"}
-	if !slices.Contains(allowedMimeTypes, mimeType) {
-		return errx.ErrInvalidFileExtension.WithLocation("userController.ImportCSV").WithDetails(map[string]any{
-			"expected": "text/csv or application/vnd.ms-excel",
+	// The content has to match the format picked from the file name
+	mimeType, _, _ := mime.ParseMediaType(http.DetectContentType(buffer[:n]))
+	allowedMimeTypes := map[string][]string{
+		tabular.FormatCSV:  {"text/csv", "application/vnd.ms-excel", "text/plain"},
+		tabular.FormatXLSX: {"application/zip"}, // xlsx files are zip archives
+	}
+	if !slices.Contains(allowedMimeTypes[tabular.DetectFormat(req.File)], mimeType) {
+		return errx.ErrInvalidFileExtension.WithLocation("userController.Import").WithDetails(map[string]any{
+			"expected": "csv or xlsx",
 			"got":      mimeType,
 		})
 	}
@@ -141,16 +168,17 @@ func (c *UserController) importCSV(ctx *fiber.Ctx) error {
 	// check file size (max 5MB)
 	const maxFileSize = 5 * 1024 * 1024 // 5MB
 	if req.File.Size > maxFileSize {
-		return errx.ErrFileSizeLimitExceeded.WithLocation("userController.ImportCSV").WithDetails(map[string]any{
+		return errx.ErrFileSizeLimitExceeded.WithLocation("userController.Import").WithDetails(map[string]any{
 			"maxSize": maxFileSize,
 			"got":     req.File.Size,
 		})
 	}
 
-	err = c.userSvc.ImportFromCSV(ctx.Context(), &req)
+	res, err := c.userSvc.Import(ctx.Context(), middlewares.GetAuditActor(ctx), &req)
 	if err != nil {
 		return err
 	}
 
-	return response.SendResponse(ctx, fiber.StatusOK, nil)
+	// The import runs in the background; GET /import-jobs/:id reports on it
+	return response.SendResponse(ctx, fiber.StatusAccepted, res)
 }
@@ -43,17 +43,17 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 }
 
 // BulkCreate mocks base method.
-func (m *MockUserRepository) BulkCreate(ctx context.Context, users []entity.User) error {
+func (m *MockUserRepository) BulkCreate(ctx context.Context, users []entity.User, importJobID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkCreate", ctx, users)
+	ret := m.ctrl.Call(m, "BulkCreate", ctx, users, importJobID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // BulkCreate indicates an expected call of BulkCreate.
-func (mr *MockUserRepositoryMockRecorder) BulkCreate(ctx, users any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) BulkCreate(ctx, users, importJobID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockUserRepository)(nil).BulkCreate), ctx, users)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockUserRepository)(nil).BulkCreate), ctx, users, importJobID)
 }
 
 // Create mocks base method.
@@ -84,6 +84,20 @@ func (mr *MockUserRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepository)(nil).Delete), ctx, id)
 }
 
+// Each mocks base method.
+func (m *MockUserRepository) Each(ctx context.Context, filter *entity.GetUsersFilter, fn func(*entity.User) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockUserRepositoryMockRecorder) Each(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockUserRepository)(nil).Each), ctx, filter, fn)
+}
+
 // FindByID mocks base method.
 func (m *MockUserRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +128,21 @@ func (mr *MockUserRepositoryMockRecorder) FindByPhoneNumber(ctx, phoneNumber any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPhoneNumber", reflect.TypeOf((*MockUserRepository)(nil).FindByPhoneNumber), ctx, phoneNumber)
 }
 
+// FindExistingPhoneNumbers mocks base method.
+func (m *MockUserRepository) FindExistingPhoneNumbers(ctx context.Context, phoneNumbers []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExistingPhoneNumbers", ctx, phoneNumbers)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExistingPhoneNumbers indicates an expected call of FindExistingPhoneNumbers.
+func (mr *MockUserRepositoryMockRecorder) FindExistingPhoneNumbers(ctx, phoneNumbers any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExistingPhoneNumbers", reflect.TypeOf((*MockUserRepository)(nil).FindExistingPhoneNumbers), ctx, phoneNumbers)
+}
+
 // GetAllPhoneNumbers mocks base method.
 func (m *MockUserRepository) GetAllPhoneNumbers(ctx context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -160,6 +189,22 @@ func (mr *MockUserRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserRepository)(nil).List), ctx, filter)
 }
 
+// ListByCursor mocks base method.
+func (m *MockUserRepository) ListByCursor(ctx context.Context, filter *entity.GetUsersFilter) ([]entity.User, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCursor", ctx, filter)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByCursor indicates an expected call of ListByCursor.
+func (mr *MockUserRepositoryMockRecorder) ListByCursor(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCursor", reflect.TypeOf((*MockUserRepository)(nil).ListByCursor), ctx, filter)
+}
+
 // Update mocks base method.
 func (m *MockUserRepository) Update(ctx context.Context, user *entity.User) error {
 	m.ctrl.T.Helper()
@@ -173,3 +218,18 @@ func (mr *MockUserRepositoryMockRecorder) Update(ctx, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserRepository)(nil).Update), ctx, user)
 }
+
+// Upsert mocks base method.
+func (m *MockUserRepository) Upsert(ctx context.Context, users []entity.User, opts entity.UpsertUsersOptions) (*entity.UpsertUsersResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, users, opts)
+	ret0, _ := ret[0].(*entity.UpsertUsersResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockUserRepositoryMockRecorder) Upsert(ctx, users, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockUserRepository)(nil).Upsert), ctx, users, opts)
+}
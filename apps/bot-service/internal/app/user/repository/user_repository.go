@@ -3,17 +3,25 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/pg"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
 )
 
+// Users are written this many at a time. A multi-row insert takes 8 bind
+// parameters per user, and Postgres allows at most 65535 per statement.
+const userWriteChunkSize = 1000
+
 func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 	query := `
 		INSERT INTO users (id, phone_number, name, job_title, gender, date_of_birth, created_at, updated_at)
@@ -50,22 +58,20 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 	return nil
 }
 
-func (r *userRepository) BulkCreate(ctx context.Context, users []entity.User) error {
+// BulkCreate inserts users in a single transaction, and saves their phone
+// numbers on the import job importJobID as its committed result.
+func (r *userRepository) BulkCreate(ctx context.Context, users []entity.User, importJobID uuid.UUID) error {
 	if len(users) == 0 {
 		return nil
 	}
 
-	query := `
-		INSERT INTO users (id, phone_number, name, job_title, gender, date_of_birth, created_at, updated_at)
-		VALUES (:id, :phone_number, :name, :job_title, :gender, :date_of_birth, :created_at, :updated_at)
-	`
-
-	_, err := r.db.NamedExecContext(
-		ctx,
-		query,
-		users,
-	)
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
+		return errx.ErrInternalServer.WithLocation("userRepository.BulkCreate.Begin").WithError(err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	if err := insertUsers(ctx, tx, users); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			pgErrors := []pg.PgError{
@@ -84,12 +90,236 @@ func (r *userRepository) BulkCreate(ctx context.Context, users []entity.User) er
 		return errx.ErrInternalServer.WithLocation("userRepository.BulkCreate").WithError(err)
 	}
 
+	result := &entity.UpsertUsersResult{
+		Created:     make([]string, 0, len(users)),
+		Updated:     []string{},
+		Unchanged:   []string{},
+		Deactivated: []string{},
+	}
+	for i := range users {
+		result.Created = append(result.Created, users[i].PhoneNumber)
+	}
+
+	if err := saveImportCommit(ctx, tx, importJobID, result); err != nil {
+		return errx.ErrInternalServer.WithLocation("userRepository.BulkCreate.SaveImportCommit").WithError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errx.ErrInternalServer.WithLocation("userRepository.BulkCreate.Commit").WithError(err)
+	}
+
+	return nil
+}
+
+// Upsert matches users on phone number in a single transaction. New phone
+// numbers are inserted with the IDs set on users, existing users get their
+// profile updated and are reactivated, and users whose profile already matches
+// are left alone. The result is saved on opts.ImportJobID in the same
+// transaction.
+func (r *userRepository) Upsert(ctx context.Context, users []entity.User, opts entity.UpsertUsersOptions) (*entity.UpsertUsersResult, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("userRepository.Upsert.Begin").WithError(err)
+	}
+	defer tx.Rollback() // No-op once committed
+
+	phoneNumbers := make([]string, 0, len(users))
+	for i := range users {
+		phoneNumbers = append(phoneNumbers, users[i].PhoneNumber)
+	}
+
+	var existingUsers []entity.User
+	err = tx.SelectContext(ctx, &existingUsers, `
+		SELECT id, phone_number, name, job_title, gender, date_of_birth, deactivated_at, created_at, updated_at
+		FROM users
+		WHERE phone_number = ANY($1)
+		FOR UPDATE
+	`, phoneNumbers)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("userRepository.Upsert.Select").WithError(err)
+	}
+
+	existing := make(map[string]*entity.User, len(existingUsers))
+	for i := range existingUsers {
+		existing[existingUsers[i].PhoneNumber] = &existingUsers[i]
+	}
+
+	result := &entity.UpsertUsersResult{
+		Created:     []string{},
+		Updated:     []string{},
+		Unchanged:   []string{},
+		Deactivated: []string{},
+	}
+
+	var newUsers []entity.User
+	var changedUsers []entity.User
+	for i := range users {
+		user := users[i]
+
+		current, ok := existing[user.PhoneNumber]
+		if !ok {
+			newUsers = append(newUsers, user)
+			result.Created = append(result.Created, user.PhoneNumber)
+			continue
+		}
+
+		if sameUserProfile(current, &user) && current.DeactivatedAt == nil {
+			result.Unchanged = append(result.Unchanged, user.PhoneNumber)
+			continue
+		}
+
+		user.ID = current.ID
+		changedUsers = append(changedUsers, user)
+		result.Updated = append(result.Updated, user.PhoneNumber)
+	}
+
+	if err := updateUsers(ctx, tx, changedUsers, opts.Now); err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("userRepository.Upsert.Update").WithError(err)
+	}
+
+	if len(newUsers) > 0 {
+		if err := insertUsers(ctx, tx, newUsers); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) {
+				pgErrors := []pg.PgError{
+					{
+						Code:           pg.UniqueViolation,
+						ConstraintName: "users_phone_number_key",
+						Err:            errx.ErrUserPhoneExists.WithLocation("userRepository.Upsert.Insert"),
+					},
+				}
+
+				if customPgErr := pg.HandlePgError(pgErr, pgErrors); customPgErr != nil {
+					return nil, customPgErr
+				}
+			}
+
+			return nil, errx.ErrInternalServer.WithLocation("userRepository.Upsert.Insert").WithError(err)
+		}
+	}
+
+	if opts.DeactivateMissing {
+		err := tx.SelectContext(ctx, &result.Deactivated, `
+			UPDATE users
+			SET deactivated_at = $1, updated_at = $1
+			WHERE deactivated_at IS NULL AND NOT (phone_number = ANY($2))
+			RETURNING phone_number
+		`, opts.Now, opts.PresentPhoneNumbers)
+		if err != nil {
+			return nil, errx.ErrInternalServer.WithLocation("userRepository.Upsert.Deactivate").WithError(err)
+		}
+
+		if result.Deactivated == nil {
+			result.Deactivated = []string{}
+		}
+	}
+
+	if err := saveImportCommit(ctx, tx, opts.ImportJobID, result); err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("userRepository.Upsert.SaveImportCommit").WithError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("userRepository.Upsert.Commit").WithError(err)
+	}
+
+	return result, nil
+}
+
+// saveImportCommit saves result as the committed result of the import job
+// importJobID, if there is one.
+func saveImportCommit(ctx context.Context, tx *sqlx.Tx, importJobID uuid.UUID, result *entity.UpsertUsersResult) error {
+	if importJobID == uuid.Nil {
+		return nil
+	}
+
+	committedResult, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE import_jobs
+		SET committed_result = $1
+		WHERE id = $2
+	`, committedResult, importJobID)
+
+	return err
+}
+
+// insertUsers inserts users userWriteChunkSize at a time.
+func insertUsers(ctx context.Context, tx *sqlx.Tx, users []entity.User) error {
+	for chunk := range slices.Chunk(users, userWriteChunkSize) {
+		_, err := tx.NamedExecContext(ctx, `
+			INSERT INTO users (id, phone_number, name, job_title, gender, date_of_birth, created_at, updated_at)
+			VALUES (:id, :phone_number, :name, :job_title, :gender, :date_of_birth, :created_at, :updated_at)
+		`, chunk)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// updateUsers sets the profile of users, matched on ID, and reactivates them.
+// Each chunk is a single statement that passes the columns as arrays.
+func updateUsers(ctx context.Context, tx *sqlx.Tx, users []entity.User, updatedAt time.Time) error {
+	for chunk := range slices.Chunk(users, userWriteChunkSize) {
+		ids := make([]string, 0, len(chunk))
+		names := make([]string, 0, len(chunk))
+		jobTitles := make([]*string, 0, len(chunk))
+		genders := make([]*string, 0, len(chunk))
+		datesOfBirth := make([]*time.Time, 0, len(chunk))
+		for i := range chunk {
+			ids = append(ids, chunk[i].ID.String())
+			names = append(names, chunk[i].Name)
+			jobTitles = append(jobTitles, chunk[i].JobTitle)
+			genders = append(genders, chunk[i].Gender)
+			datesOfBirth = append(datesOfBirth, chunk[i].DateOfBirth)
+		}
+
+		_, err := tx.ExecContext(ctx, `
+			UPDATE users AS u
+			SET name = v.name, job_title = v.job_title, gender = v.gender, date_of_birth = v.date_of_birth,
+				deactivated_at = NULL, updated_at = $1
+			FROM unnest($2::text[], $3::text[], $4::text[], $5::text[], $6::date[])
+				AS v(id, name, job_title, gender, date_of_birth)
+			WHERE u.id = v.id
+		`, updatedAt, ids, names, jobTitles, genders, datesOfBirth)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sameUserProfile(a, b *entity.User) bool {
+	return a.Name == b.Name &&
+		equalOptionalString(a.JobTitle, b.JobTitle) &&
+		equalOptionalString(a.Gender, b.Gender) &&
+		equalOptionalDate(a.DateOfBirth, b.DateOfBirth)
+}
+
+func equalOptionalString(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func equalOptionalDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Format(time.DateOnly) == b.Format(time.DateOnly)
+}
+
 func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
 	query := `
-		SELECT id, phone_number, name, job_title, gender, date_of_birth, created_at, updated_at
+		SELECT id, phone_number, name, job_title, gender, date_of_birth, deactivated_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
 	`
@@ -112,7 +342,7 @@ func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Us
 
 func (r *userRepository) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*entity.User, error) {
 	query := `
-		SELECT id, phone_number, name, job_title, gender, date_of_birth, created_at, updated_at
+		SELECT id, phone_number, name, job_title, gender, date_of_birth, deactivated_at, created_at, updated_at
 		FROM users
 		WHERE phone_number = $1
 	`
@@ -138,30 +368,24 @@ func (r *userRepository) List(ctx context.Context, filter *entity.GetUsersFilter
 	limit := min(max(filter.Limit, 10), 100)
 
 	var qb strings.Builder
-	var whereClauses strings.Builder
-	var args []any
+	whereClauses, args := buildUsersWhere(filter)
 
 	qb.WriteString(`
-		SELECT id, phone_number, name, job_title, gender, date_of_birth, created_at, updated_at
+		SELECT id, phone_number, name, job_title, gender, date_of_birth, deactivated_at, created_at, updated_at
 		FROM users
 	`)
 
-	if filter.Search != "" {
-		whereClauses.WriteString(fmt.Sprintf(" AND (phone_number ILIKE $%d OR name ILIKE $%d)", len(args)+1, len(args)+1))
-		args = append(args, "%"+filter.Search+"%")
-	}
-
 	var total int64
-	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM users WHERE 1=1"+whereClauses.String(), args...)
+	err := r.db.GetContext(ctx, &total, "SELECT COUNT(*) FROM users WHERE 1=1"+whereClauses, args...)
 	if err != nil {
 		return nil, 0, errx.ErrInternalServer.WithLocation("userRepository.List.Count").WithError(err)
 	}
 
-	if whereClauses.Len() > 0 {
+	if whereClauses != "" {
 		qb.WriteString(" WHERE 1=1")
-		qb.WriteString(whereClauses.String())
+		qb.WriteString(whereClauses)
 	}
-	qb.WriteString(" ORDER BY created_at DESC")
+	qb.WriteString(" ORDER BY created_at DESC, id DESC")
 	qb.WriteString(fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2))
 
 	args = append(args, limit, offset)
@@ -179,6 +403,110 @@ func (r *userRepository) List(ctx context.Context, filter *entity.GetUsersFilter
 	return users, total, nil
 }
 
+// ListByCursor returns the page of users after filter.After, or the first
+// page when it's nil, and whether there are more after it. Offset is ignored.
+func (r *userRepository) ListByCursor(ctx context.Context, filter *entity.GetUsersFilter) ([]entity.User, bool, error) {
+	limit := min(max(filter.Limit, 10), 100)
+
+	var qb strings.Builder
+	whereClauses, args := buildUsersWhere(filter)
+
+	if filter.After != nil {
+		whereClauses += fmt.Sprintf(" AND (created_at, id) < ($%d, $%d)", len(args)+1, len(args)+2)
+		args = append(args, filter.After.CreatedAt, filter.After.ID)
+	}
+
+	qb.WriteString(`
+		SELECT id, phone_number, name, job_title, gender, date_of_birth, deactivated_at, created_at, updated_at
+		FROM users
+	`)
+
+	if whereClauses != "" {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses)
+	}
+	qb.WriteString(" ORDER BY created_at DESC, id DESC")
+	// One extra row tells whether there's another page
+	qb.WriteString(fmt.Sprintf(" LIMIT $%d", len(args)+1))
+
+	args = append(args, limit+1)
+
+	var users []entity.User
+	err := r.db.SelectContext(ctx, &users, qb.String(), args...)
+	if err != nil {
+		return nil, false, errx.ErrInternalServer.WithLocation("userRepository.ListByCursor").WithError(err)
+	}
+
+	hasMore := len(users) > limit
+	if hasMore {
+		users = users[:limit]
+	}
+
+	if users == nil {
+		users = []entity.User{}
+	}
+
+	return users, hasMore, nil
+}
+
+// Each calls fn for every user matching filter, newest first, reading rows off
+// the cursor one at a time. Offset and Limit are ignored. It stops at the
+// first error fn returns.
+func (r *userRepository) Each(ctx context.Context, filter *entity.GetUsersFilter, fn func(user *entity.User) error) error {
+	var qb strings.Builder
+	whereClauses, args := buildUsersWhere(filter)
+
+	qb.WriteString(`
+		SELECT id, phone_number, name, job_title, gender, date_of_birth, deactivated_at, created_at, updated_at
+		FROM users
+	`)
+
+	if whereClauses != "" {
+		qb.WriteString(" WHERE 1=1")
+		qb.WriteString(whereClauses)
+	}
+	qb.WriteString(" ORDER BY created_at DESC, id DESC")
+
+	rows, err := r.db.QueryxContext(ctx, qb.String(), args...)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("userRepository.Each.Query").WithError(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var user entity.User
+		if err := rows.StructScan(&user); err != nil {
+			return errx.ErrInternalServer.WithLocation("userRepository.Each.Scan").WithError(err)
+		}
+
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return errx.ErrInternalServer.WithLocation("userRepository.Each.Rows").WithError(err)
+	}
+
+	return nil
+}
+
+func buildUsersWhere(filter *entity.GetUsersFilter) (string, []any) {
+	var whereClauses strings.Builder
+	var args []any
+
+	if filter.Search != "" {
+		whereClauses.WriteString(fmt.Sprintf(" AND (phone_number ILIKE $%d OR name ILIKE $%d)", len(args)+1, len(args)+1))
+		args = append(args, "%"+filter.Search+"%")
+	}
+
+	if filter.ActiveOnly {
+		whereClauses.WriteString(" AND deactivated_at IS NULL")
+	}
+
+	return whereClauses.String(), args
+}
+
 func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 	query := `
 		UPDATE users
@@ -253,6 +581,7 @@ func (r *userRepository) GetAllPhoneNumbers(ctx context.Context) ([]string, erro
 	query := `
 		SELECT phone_number
 		FROM users
+		WHERE deactivated_at IS NULL
 		ORDER BY created_at DESC
 	`
 
@@ -269,8 +598,34 @@ func (r *userRepository) GetAllPhoneNumbers(ctx context.Context) ([]string, erro
 	return phoneNumbers, nil
 }
 
+// FindExistingPhoneNumbers returns which of phoneNumbers already belong to a
+// user.
+func (r *userRepository) FindExistingPhoneNumbers(ctx context.Context, phoneNumbers []string) ([]string, error) {
+	if len(phoneNumbers) == 0 {
+		return []string{}, nil
+	}
+
+	query := `
+		SELECT phone_number
+		FROM users
+		WHERE phone_number = ANY($1)
+	`
+
+	var existing []string
+	err := r.db.SelectContext(ctx, &existing, query, phoneNumbers)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("userRepository.FindExistingPhoneNumbers").WithError(err)
+	}
+
+	if existing == nil {
+		existing = []string{}
+	}
+
+	return existing, nil
+}
+
 func (r *userRepository) GetTotalUsers(ctx context.Context) (int, error) {
-	query := `SELECT COUNT(*) FROM users`
+	query := `SELECT COUNT(*) FROM users WHERE deactivated_at IS NULL`
 
 	var total int
 	err := r.db.GetContext(ctx, &total, query)
@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts (interfaces: UserService)
+//
+// Generated by this command:
+//
+//	mockgen -destination=../../internal/app/user/service/mock/mock_user_service.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts UserService
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	entity "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserService is a mock of UserService interface.
+type MockUserService struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceMockRecorder is the mock recorder for MockUserService.
+type MockUserServiceMockRecorder struct {
+	mock *MockUserService
+}
+
+// NewMockUserService creates a new mock instance.
+func NewMockUserService(ctrl *gomock.Controller) *MockUserService {
+	mock := &MockUserService{ctrl: ctrl}
+	mock.recorder = &MockUserServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserService) EXPECT() *MockUserServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockUserService) Create(ctx context.Context, actor entity.AuditActor, req *dto.CreateUserRequest) (*dto.CreateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, actor, req)
+	ret0, _ := ret[0].(*dto.CreateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserServiceMockRecorder) Create(ctx, actor, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserService)(nil).Create), ctx, actor, req)
+}
+
+// Delete mocks base method.
+func (m *MockUserService) Delete(ctx context.Context, actor entity.AuditActor, param *dto.DeleteUserParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, actor, param)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserServiceMockRecorder) Delete(ctx, actor, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserService)(nil).Delete), ctx, actor, param)
+}
+
+// Export mocks base method.
+func (m *MockUserService) Export(ctx context.Context, query *dto.ExportUsersQuery) (*dto.ExportFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, query)
+	ret0, _ := ret[0].(*dto.ExportFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockUserServiceMockRecorder) Export(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockUserService)(nil).Export), ctx, query)
+}
+
+// GetAllPhoneNumbers mocks base method.
+func (m *MockUserService) GetAllPhoneNumbers(ctx context.Context) (*dto.GetAllPhoneNumbersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPhoneNumbers", ctx)
+	ret0, _ := ret[0].(*dto.GetAllPhoneNumbersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllPhoneNumbers indicates an expected call of GetAllPhoneNumbers.
+func (mr *MockUserServiceMockRecorder) GetAllPhoneNumbers(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPhoneNumbers", reflect.TypeOf((*MockUserService)(nil).GetAllPhoneNumbers), ctx)
+}
+
+// GetByID mocks base method.
+func (m *MockUserService) GetByID(ctx context.Context, param *dto.GetUserByIDParam) (*dto.GetUserByIDResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, param)
+	ret0, _ := ret[0].(*dto.GetUserByIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockUserServiceMockRecorder) GetByID(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockUserService)(nil).GetByID), ctx, param)
+}
+
+// GetByPhoneNumber mocks base method.
+func (m *MockUserService) GetByPhoneNumber(ctx context.Context, param *dto.GetUserByPhoneNumberParam) (*dto.GetUserByPhoneNumberResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByPhoneNumber", ctx, param)
+	ret0, _ := ret[0].(*dto.GetUserByPhoneNumberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByPhoneNumber indicates an expected call of GetByPhoneNumber.
+func (mr *MockUserServiceMockRecorder) GetByPhoneNumber(ctx, param any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByPhoneNumber", reflect.TypeOf((*MockUserService)(nil).GetByPhoneNumber), ctx, param)
+}
+
+// GetMetrics mocks base method.
+func (m *MockUserService) GetMetrics(ctx context.Context) (*dto.GetUserMetricsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMetrics", ctx)
+	ret0, _ := ret[0].(*dto.GetUserMetricsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMetrics indicates an expected call of GetMetrics.
+func (mr *MockUserServiceMockRecorder) GetMetrics(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockUserService)(nil).GetMetrics), ctx)
+}
+
+// Import mocks base method.
+func (m *MockUserService) Import(ctx context.Context, actor entity.AuditActor, req *dto.ImportUsersRequest) (*dto.CreateImportJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, actor, req)
+	ret0, _ := ret[0].(*dto.CreateImportJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserServiceMockRecorder) Import(ctx, actor, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserService)(nil).Import), ctx, actor, req)
+}
+
+// List mocks base method.
+func (m *MockUserService) List(ctx context.Context, query *dto.GetUsersQuery) (*dto.GetUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, query)
+	ret0, _ := ret[0].(*dto.GetUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUserServiceMockRecorder) List(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUserService)(nil).List), ctx, query)
+}
+
+// RunImportJob mocks base method.
+func (m *MockUserService) RunImportJob(ctx context.Context, job *entity.ImportJob, onProgress func(int, int)) (*dto.ImportUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunImportJob", ctx, job, onProgress)
+	ret0, _ := ret[0].(*dto.ImportUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunImportJob indicates an expected call of RunImportJob.
+func (mr *MockUserServiceMockRecorder) RunImportJob(ctx, job, onProgress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunImportJob", reflect.TypeOf((*MockUserService)(nil).RunImportJob), ctx, job, onProgress)
+}
+
+// Update mocks base method.
+func (m *MockUserService) Update(ctx context.Context, actor entity.AuditActor, param *dto.UpdateUserParam, req *dto.UpdateUserRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, actor, param, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockUserServiceMockRecorder) Update(ctx, actor, param, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockUserService)(nil).Update), ctx, actor, param, req)
+}
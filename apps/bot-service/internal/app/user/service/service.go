@@ -2,23 +2,27 @@ package service
 
 import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
-	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/csv"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
 )
 
 type UserService struct {
-	userRepo  contracts.UserRepository
-	validator validator.CustomValidatorInterface
-	uuidPkg   uuid.UUIDInterface
-	csvPkg    csv.CustomCSVInterface
+	userRepo      contracts.UserRepository
+	importJobRepo contracts.ImportJobRepository
+	validator     validator.CustomValidatorInterface
+	uuidPkg       uuid.UUIDInterface
+	tabularPkg    tabular.CustomTabularInterface
+	auditSvc      contracts.AuditService
 }
 
-func NewUserService(userRepo contracts.UserRepository, validatorService validator.CustomValidatorInterface, uuidService uuid.UUIDInterface, csvService csv.CustomCSVInterface) *UserService {
+func NewUserService(userRepo contracts.UserRepository, importJobRepo contracts.ImportJobRepository, validatorService validator.CustomValidatorInterface, uuidService uuid.UUIDInterface, tabularService tabular.CustomTabularInterface, auditService contracts.AuditService) *UserService {
 	return &UserService{
-		userRepo:  userRepo,
-		validator: validatorService,
-		uuidPkg:   uuidService,
-		csvPkg:    csvService,
+		userRepo:      userRepo,
+		importJobRepo: importJobRepo,
+		validator:     validatorService,
+		uuidPkg:       uuidService,
+		tabularPkg:    tabularService,
+		auditSvc:      auditService,
 	}
 }
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
+)
+
+// Export returns every active user matching query as a CSV or Excel file laid
+// out like an import file, so it can be edited and imported again. Deactivated
+// users are left out, since an upsert import would reactivate them. Users are
+// written as they're read from the database rather than loaded all at once.
+func (s *UserService) Export(ctx context.Context, query *dto.ExportUsersQuery) (*dto.ExportFile, error) {
+	if err := s.validator.Validate(query); err != nil {
+		return nil, err
+	}
+
+	format := query.Format
+	if format == "" {
+		format = tabular.FormatCSV
+	}
+
+	filter := entity.GetUsersFilter{
+		Search:     query.Search,
+		ActiveOnly: true,
+	}
+
+	res := &dto.ExportFile{
+		FileName:    fmt.Sprintf("users-%s.%s", time.Now().Format("20060102"), format),
+		ContentType: tabular.ContentType(format),
+		Write: func(ctx context.Context, w io.Writer) error {
+			return s.writeUsers(ctx, format, &filter, w)
+		},
+	}
+
+	return res, nil
+}
+
+func (s *UserService) writeUsers(ctx context.Context, format string, filter *entity.GetUsersFilter, w io.Writer) error {
+	writer, err := s.tabularPkg.NewWriter(format, w)
+	if err != nil {
+		return errx.ErrInternalServer.WithLocation("UserService.Export.NewWriter").WithError(err)
+	}
+
+	if err := writer.WriteRow(userImportColumns); err != nil {
+		writer.Discard()
+		return errx.ErrInternalServer.WithLocation("UserService.Export.WriteHeader").WithError(err)
+	}
+
+	err = s.userRepo.Each(ctx, filter, func(user *entity.User) error {
+		if err := writer.WriteRow(userExportRecord(user)); err != nil {
+			return errx.ErrInternalServer.WithLocation("UserService.Export.WriteRow").WithError(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		writer.Discard()
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return errx.ErrInternalServer.WithLocation("UserService.Export.Close").WithError(err)
+	}
+
+	return nil
+}
+
+// userExportRecord lays a user out in the columns of an import file
+func userExportRecord(user *entity.User) []string {
+	record := make([]string, len(userImportColumns))
+	for i, column := range userImportColumns {
+		switch column {
+		case importColumnPhoneNumber:
+			record[i] = user.PhoneNumber
+		case importColumnName:
+			record[i] = user.Name
+		case importColumnJobTitle:
+			if user.JobTitle != nil {
+				record[i] = *user.JobTitle
+			}
+		case importColumnGender:
+			if user.Gender != nil {
+				record[i] = *user.Gender
+			}
+		case importColumnDateOfBirth:
+			if user.DateOfBirth != nil {
+				record[i] = user.DateOfBirth.Format(time.DateOnly)
+			}
+		}
+	}
+
+	return record
+}
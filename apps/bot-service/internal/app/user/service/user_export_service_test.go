@@ -0,0 +1,232 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
+	importJobRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/repository/mock"
+	userRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/repository/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
+	mockTabular "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserService_Export(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
+	ctx := context.Background()
+
+	jobTitle := "Engineer"
+	gender := "female"
+	dateOfBirth := time.Date(1990, 1, 31, 0, 0, 0, 0, time.UTC)
+	testUsers := []entity.User{
+		{
+			PhoneNumber: "+6281234567890",
+			Name:        "Siti, S.T.",
+			JobTitle:    &jobTitle,
+			Gender:      &gender,
+			DateOfBirth: &dateOfBirth,
+		},
+		{
+			PhoneNumber: "+6281234567891",
+			Name:        "Budi",
+		},
+	}
+
+	// The real CSV writer, so the test checks the file that's written
+	newCSVWriter := func(format string, w io.Writer) (tabular.Writer, error) {
+		return tabular.Tabular.NewWriter(format, w)
+	}
+
+	tests := []struct {
+		name         string
+		query        *dto.ExportUsersQuery
+		setup        func()
+		wantErr      bool
+		errType      error
+		wantWriteErr error
+		wantFile     string
+	}{
+		{
+			name: "success - defaults to csv in the import layout",
+			query: &dto.ExportUsersQuery{
+				Search: "+62812",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTabular.EXPECT().NewWriter(tabular.FormatCSV, gomock.Any()).DoAndReturn(newCSVWriter)
+				mockUserRepo.EXPECT().Each(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetUsersFilter, fn func(user *entity.User) error) error {
+					assert.Equal(t, "+62812", filter.Search)
+					assert.True(t, filter.ActiveOnly)
+					for i := range testUsers {
+						if err := fn(&testUsers[i]); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			},
+			wantErr: false,
+			wantFile: "phone_number,name,job_title,gender,date_of_birth\n" +
+				"+6281234567890,\"Siti, S.T.\",Engineer,female,1990-01-31\n" +
+				"+6281234567891,Budi,,,\n",
+		},
+		{
+			name: "repository error stops the export",
+			query: &dto.ExportUsersQuery{
+				Format: tabular.FormatCSV,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockTabular.EXPECT().NewWriter(tabular.FormatCSV, gomock.Any()).DoAndReturn(newCSVWriter)
+				mockUserRepo.EXPECT().Each(ctx, gomock.Any(), gomock.Any()).Return(errx.ErrInternalServer)
+			},
+			wantErr:      false,
+			wantWriteErr: errx.ErrInternalServer,
+		},
+		{
+			name: "validation error",
+			query: &dto.ExportUsersQuery{
+				Format: "pdf",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"query.format": validator.ValidationError{
+						Message: "format must be one of [csv xlsx]",
+					},
+				})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.Export(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Regexp(t, `^users-\d{8}\.csv$`, result.FileName)
+			assert.Equal(t, "text/csv; charset=utf-8", result.ContentType)
+
+			var buf bytes.Buffer
+			err = result.Write(ctx, &buf)
+
+			if tt.wantWriteErr != nil {
+				assert.ErrorIs(t, err, tt.wantWriteErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantFile, buf.String())
+			}
+		})
+	}
+}
+
+func TestUserService_Export_RoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
+	ctx := context.Background()
+
+	deactivatedAt := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	storedUsers := []entity.User{
+		{PhoneNumber: "+6281234567890", Name: "Siti"},
+		{PhoneNumber: "+6281234567891", Name: "Budi", DeactivatedAt: &deactivatedAt},
+	}
+
+	mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).AnyTimes()
+	mockTabular.EXPECT().NewWriter(tabular.FormatCSV, gomock.Any()).DoAndReturn(func(format string, w io.Writer) (tabular.Writer, error) {
+		return tabular.Tabular.NewWriter(format, w)
+	})
+	mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).DoAndReturn(func(format string, r io.Reader) ([][]string, error) {
+		return tabular.Tabular.Parse(format, r)
+	})
+	mockUserRepo.EXPECT().Each(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetUsersFilter, fn func(user *entity.User) error) error {
+		for i := range storedUsers {
+			if filter.ActiveOnly && storedUsers[i].DeactivatedAt != nil {
+				continue
+			}
+			if err := fn(&storedUsers[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	mockUUID.EXPECT().NewV7().Return(uuid.New(), nil)
+	mockUserRepo.EXPECT().Upsert(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, users []entity.User, opts entity.UpsertUsersOptions) (*entity.UpsertUsersResult, error) {
+		// The deactivated user isn't in the file, so isn't reactivated
+		assert.Len(t, users, 1)
+		assert.Equal(t, "+6281234567890", users[0].PhoneNumber)
+		assert.Equal(t, []string{"+6281234567890"}, opts.PresentPhoneNumbers)
+		return &entity.UpsertUsersResult{Unchanged: []string{"+6281234567890"}}, nil
+	})
+	mockAudit.EXPECT().Record(ctx, gomock.Any())
+
+	file, err := service.Export(ctx, &dto.ExportUsersQuery{})
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, file.Write(ctx, &buf))
+
+	options, err := json.Marshal(dto.ImportUsersOptions{Mode: entity.UserImportModeUpsert})
+	assert.NoError(t, err)
+
+	result, err := service.RunImportJob(ctx, &entity.ImportJob{
+		ID:         uuid.New(),
+		Type:       entity.ImportJobTypeUsers,
+		FileName:   file.FileName,
+		FileFormat: tabular.FormatCSV,
+		FileData:   buf.Bytes(),
+		Options:    options,
+		ActorType:  entity.AuditActorAdmin,
+	}, func(totalRows int, processedRows int) {})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.ImportUsersResponse{
+		Mode:      entity.UserImportModeUpsert,
+		Committed: true,
+		TotalRows: 1,
+		ValidRows: 1,
+		Unchanged: 1,
+		Errors:    []dto.ImportRowError{},
+	}, result)
+}
@@ -0,0 +1,547 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
+)
+
+// Columns of an import file, matched by header name in any order
+const (
+	importColumnPhoneNumber = "phone_number"
+	importColumnName        = "name"
+	importColumnJobTitle    = "job_title"
+	importColumnGender      = "gender"
+	importColumnDateOfBirth = "date_of_birth"
+)
+
+var userImportColumns = []string{
+	importColumnPhoneNumber,
+	importColumnName,
+	importColumnJobTitle,
+	importColumnGender,
+	importColumnDateOfBirth,
+}
+
+var requiredUserImportColumns = []string{importColumnPhoneNumber, importColumnName}
+
+// importValidatedFields maps the CreateUserRequest fields a row is validated
+// against to the column and error reported when one is invalid, in column order
+var importValidatedFields = []struct {
+	name   string
+	column string
+	err    *errx.RequestError
+}{
+	{name: "phoneNumber", column: importColumnPhoneNumber, err: errx.ErrInvalidPhoneNumber},
+	{name: "name", column: importColumnName, err: errx.ErrInvalidName},
+	{name: "jobTitle", column: importColumnJobTitle, err: errx.ErrInvalidJobTitle},
+	{name: "gender", column: importColumnGender, err: errx.ErrInvalidGender},
+}
+
+// How often a running import reports how many rows it has checked
+const importProgressInterval = 500
+
+// Import queues a CSV or Excel file for a background import and returns the
+// job, which reports the outcome once RunImportJob has run it.
+func (s *UserService) Import(ctx context.Context, actor entity.AuditActor, req *dto.ImportUsersRequest) (*dto.CreateImportJobResponse, error) {
+	if err := s.validator.Validate(req); err != nil {
+		return nil, err
+	}
+
+	format := tabular.DetectFormat(req.File)
+	if format == "" {
+		return nil, errx.ErrInvalidFileExtension.WithDetails(map[string]any{
+			"expected": "csv or xlsx",
+			"got":      req.File.Filename,
+		}).WithLocation("UserService.Import")
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("UserService.Import").WithError(err)
+	}
+	defer file.Close()
+
+	fileData, err := io.ReadAll(file)
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("UserService.Import").WithError(err)
+	}
+
+	options, err := json.Marshal(dto.ImportUsersOptions{
+		Mode:              req.Mode,
+		DryRun:            req.DryRun,
+		AllowPartial:      req.AllowPartial,
+		DeactivateMissing: req.DeactivateMissing,
+	})
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("UserService.Import").WithError(err)
+	}
+
+	id, err := s.uuidPkg.NewV7()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("UserService.Import").WithError(err)
+	}
+
+	now := time.Now().UTC()
+	job := &entity.ImportJob{
+		ID:         id,
+		Type:       entity.ImportJobTypeUsers,
+		Status:     entity.ImportJobStatusPending,
+		FileName:   req.File.Filename,
+		FileFormat: format,
+		FileData:   fileData,
+		Options:    options,
+		ActorType:  actor.Type,
+		ActorID:    actor.ID,
+		IPAddress:  optionalString(actor.IPAddress),
+		UserAgent:  optionalString(actor.UserAgent),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := s.importJobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	res := &dto.CreateImportJobResponse{
+		ID:     id.String(),
+		Status: job.Status,
+	}
+
+	return res, nil
+}
+
+// RunImportJob checks every row of a queued users import and reports all the
+// problems it finds. The valid rows are only imported when there are no
+// problems, or when the AllowPartial option is set, and never when DryRun is
+// set. In upsert mode rows are matched on phone number, so the whole HR roster
+// can be re-uploaded. onProgress is called as rows are checked.
+func (s *UserService) RunImportJob(ctx context.Context, job *entity.ImportJob, onProgress func(totalRows int, processedRows int)) (*dto.ImportUsersResponse, error) {
+	var opts dto.ImportUsersOptions
+	if err := json.Unmarshal(job.Options, &opts); err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("UserService.RunImportJob").WithError(err)
+	}
+
+	records, err := s.tabularPkg.Parse(job.FileFormat, bytes.NewReader(job.FileData))
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("UserService.RunImportJob").WithError(err)
+	}
+
+	// Validate file is not empty
+	if len(records) == 0 {
+		return nil, errx.ErrEmptyCSVFile.WithLocation("UserService.RunImportJob")
+	}
+
+	columns, err := mapImportColumns(records[0])
+	if err != nil {
+		return nil, err
+	}
+
+	dataRows := 0
+	for _, record := range records[1:] {
+		if !isBlankRecord(record) {
+			dataRows++
+		}
+	}
+
+	// Validate header row is followed by data
+	if dataRows == 0 {
+		return nil, errx.ErrCSVNoData.WithLocation("UserService.RunImportJob")
+	}
+
+	onProgress(dataRows, 0)
+
+	mode := opts.Mode
+	if mode == "" {
+		mode = entity.UserImportModeCreate
+	}
+
+	// A run that wrote the users but was cut short before the job completed
+	// left behind what it wrote
+	var committed *entity.UpsertUsersResult
+	var createdByJob []string
+	if job.CommittedResult != nil {
+		committed = &entity.UpsertUsersResult{}
+		if err := json.Unmarshal(job.CommittedResult, committed); err != nil {
+			return nil, errx.ErrInternalServer.WithLocation("UserService.RunImportJob").WithError(err)
+		}
+		createdByJob = committed.Created
+	}
+
+	users, rowErrors, err := s.parseImportRows(ctx, columns, records[1:], mode, createdByJob, func(processedRows int) {
+		onProgress(dataRows, processedRows)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invalidRows := make(map[int]struct{}, len(rowErrors))
+	for _, rowErr := range rowErrors {
+		invalidRows[rowErr.Row] = struct{}{}
+	}
+
+	res := &dto.ImportUsersResponse{
+		Mode:        mode,
+		DryRun:      opts.DryRun,
+		TotalRows:   dataRows,
+		ValidRows:   len(users),
+		InvalidRows: len(invalidRows),
+		Errors:      rowErrors,
+	}
+
+	if opts.DryRun || len(users) == 0 || (len(rowErrors) > 0 && !opts.AllowPartial) {
+		return res, nil
+	}
+
+	// Report the earlier write rather than making it again. It was audited
+	// straight after it was made.
+	if committed != nil {
+		setImportCounts(res, committed)
+		return res, nil
+	}
+
+	for i := range users {
+		id, err := s.uuidPkg.NewV7()
+		if err != nil {
+			return nil, errx.ErrInternalServer.WithLocation("UserService.RunImportJob").WithError(err)
+		}
+		users[i].ID = id
+	}
+
+	metadata := map[string]any{
+		"mode":        mode,
+		"skipped":     res.InvalidRows,
+		"fileName":    job.FileName,
+		"importJobId": job.ID.String(),
+	}
+
+	if mode == entity.UserImportModeUpsert {
+		// Rows that failed validation still count as present, so a typo in
+		// the file doesn't deactivate the user
+		presentPhoneNumbers := make([]string, 0, len(records)-1)
+		for _, record := range records[1:] {
+			if phoneNumber := columns.value(record, importColumnPhoneNumber); phoneNumber != "" {
+				presentPhoneNumbers = append(presentPhoneNumbers, phoneNumber)
+			}
+		}
+
+		result, err := s.userRepo.Upsert(ctx, users, entity.UpsertUsersOptions{
+			DeactivateMissing:   opts.DeactivateMissing,
+			PresentPhoneNumbers: presentPhoneNumbers,
+			Now:                 time.Now().UTC(),
+			ImportJobID:         job.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		setImportCounts(res, result)
+
+		metadata["imported"] = res.Imported
+		metadata["created"] = result.Created
+		metadata["updated"] = result.Updated
+		metadata["unchanged"] = res.Unchanged
+		metadata["deactivated"] = result.Deactivated
+	} else {
+		if err := s.userRepo.BulkCreate(ctx, users, job.ID); err != nil {
+			return nil, err
+		}
+
+		phoneNumbers := make([]string, 0, len(users))
+		for i := range users {
+			phoneNumbers = append(phoneNumbers, users[i].PhoneNumber)
+		}
+
+		setImportCounts(res, &entity.UpsertUsersResult{Created: phoneNumbers})
+
+		metadata["imported"] = res.Imported
+		metadata["phoneNumbers"] = phoneNumbers
+	}
+
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      importJobActor(job),
+		Action:     entity.AuditActionUserImport,
+		TargetType: entity.AuditTargetUser,
+		Metadata:   metadata,
+	})
+
+	return res, nil
+}
+
+// setImportCounts fills in res from the users an import wrote
+func setImportCounts(res *dto.ImportUsersResponse, result *entity.UpsertUsersResult) {
+	res.Committed = true
+	res.Created = len(result.Created)
+	res.Updated = len(result.Updated)
+	res.Unchanged = len(result.Unchanged)
+	res.Deactivated = len(result.Deactivated)
+	res.Imported = res.Created + res.Updated
+}
+
+// parseImportRows turns the data rows of an import file into users without
+// IDs, along with every problem found in the rows that were left out. Phone numbers that
+// appear more than once in the file are problems too, and so are phone numbers
+// that already belong to a user unless mode is upsert, or the user is in
+// createdByJob. onProgress is called every importProgressInterval rows.
+func (s *UserService) parseImportRows(ctx context.Context, columns importColumns, records [][]string, mode string, createdByJob []string, onProgress func(processedRows int)) ([]entity.User, []dto.ImportRowError, error) {
+	rowErrors := []dto.ImportRowError{}
+	addError := func(row int, column string, value string, err *errx.RequestError) {
+		rowErrors = append(rowErrors, dto.ImportRowError{
+			Row:     row,
+			Column:  column,
+			Value:   value,
+			Code:    err.ErrorCode,
+			Message: err.Message,
+		})
+	}
+
+	type importRow struct {
+		row  int
+		user entity.User
+	}
+
+	rows := make([]importRow, 0, len(records))
+	processedRows := 0
+	firstRowByPhone := make(map[string]int, len(records))
+	now := time.Now().UTC()
+
+	for idx, record := range records {
+		// Row numbers count the header as row 1
+		row := idx + 2
+		errorCount := len(rowErrors)
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		processedRows++
+		if processedRows%importProgressInterval == 0 {
+			onProgress(processedRows)
+		}
+
+		// Validate record has no cells past the header
+		if len(record) > columns.count && !isBlankRecord(record[columns.count:]) {
+			addError(row, "", strings.Join(record, ","), errx.ErrInvalidCSVRow)
+			continue
+		}
+
+		phoneNumber := columns.value(record, importColumnPhoneNumber)
+		name := columns.value(record, importColumnName)
+
+		// Validate required fields
+		if phoneNumber == "" {
+			addError(row, "phone_number", phoneNumber, errx.ErrMissingPhoneNumber)
+		}
+		if name == "" {
+			addError(row, "name", name, errx.ErrMissingName)
+		}
+
+		var jobTitle *string
+		if jobTitleValue := columns.value(record, importColumnJobTitle); jobTitleValue != "" {
+			jobTitle = &jobTitleValue
+		}
+
+		var gender *string
+		if genderValue := columns.value(record, importColumnGender); genderValue != "" {
+			gender = &genderValue
+		}
+
+		// Validate the row with the same rules as creating a single user
+		if err := s.validator.Validate(&dto.CreateUserRequest{
+			PhoneNumber: phoneNumber,
+			Name:        name,
+			JobTitle:    jobTitle,
+			Gender:      gender,
+		}); err != nil {
+			fields := err["body"].Fields
+			for _, field := range importValidatedFields {
+				value := columns.value(record, field.column)
+				// Missing values are reported above
+				if _, ok := fields[field.name]; !ok || value == "" {
+					continue
+				}
+				addError(row, field.column, value, field.err)
+			}
+		}
+
+		if phoneNumber != "" {
+			if firstRow, ok := firstRowByPhone[phoneNumber]; ok {
+				rowErrors = append(rowErrors, dto.ImportRowError{
+					Row:     row,
+					Column:  "phone_number",
+					Value:   phoneNumber,
+					Code:    errx.ErrDuplicatePhoneInFile.ErrorCode,
+					Message: fmt.Sprintf("%s First seen on row %d.", errx.ErrDuplicatePhoneInFile.Message, firstRow),
+				})
+			} else {
+				firstRowByPhone[phoneNumber] = row
+			}
+		}
+
+		var dateOfBirth *time.Time
+		if dateOfBirthValue := columns.value(record, importColumnDateOfBirth); dateOfBirthValue != "" {
+			parsedDate, err := time.Parse(time.DateOnly, dateOfBirthValue)
+			if err != nil {
+				addError(row, "date_of_birth", dateOfBirthValue, errx.ErrInvalidDateFormat)
+			}
+			dateOfBirth = &parsedDate
+		}
+
+		if len(rowErrors) > errorCount {
+			continue
+		}
+
+		rows = append(rows, importRow{
+			row: row,
+			user: entity.User{
+				PhoneNumber: phoneNumber,
+				Name:        name,
+				JobTitle:    jobTitle,
+				Gender:      gender,
+				DateOfBirth: dateOfBirth,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			},
+		})
+	}
+
+	existing := map[string]struct{}{}
+	if mode != entity.UserImportModeUpsert {
+		phoneNumbers := make([]string, 0, len(rows))
+		for _, r := range rows {
+			phoneNumbers = append(phoneNumbers, r.user.PhoneNumber)
+		}
+
+		existingPhoneNumbers, err := s.userRepo.FindExistingPhoneNumbers(ctx, phoneNumbers)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, phoneNumber := range existingPhoneNumbers {
+			existing[phoneNumber] = struct{}{}
+		}
+		for _, phoneNumber := range createdByJob {
+			delete(existing, phoneNumber)
+		}
+	}
+
+	users := make([]entity.User, 0, len(rows))
+	for _, r := range rows {
+		if _, ok := existing[r.user.PhoneNumber]; ok {
+			addError(r.row, "phone_number", r.user.PhoneNumber, errx.ErrUserPhoneExists)
+			continue
+		}
+
+		users = append(users, r.user)
+	}
+
+	// Report problems in file order
+	slices.SortStableFunc(rowErrors, func(a, b dto.ImportRowError) int {
+		return a.Row - b.Row
+	})
+
+	return users, rowErrors, nil
+}
+
+// importColumns maps the columns of an import file to their position
+type importColumns struct {
+	positions map[string]int
+	count     int
+}
+
+// value returns the trimmed cell of column in record, or "" when the file has
+// no such column or the row is cut short.
+func (c importColumns) value(record []string, column string) string {
+	idx, ok := c.positions[column]
+	if !ok || idx >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[idx])
+}
+
+// mapImportColumns finds the import columns in a header row. Header names are
+// matched case-insensitively, with spaces or dashes instead of underscores.
+// Unknown columns are ignored, so HR exports can be uploaded as they are.
+func mapImportColumns(header []string) (importColumns, error) {
+	columns := importColumns{
+		positions: make(map[string]int, len(userImportColumns)),
+		count:     len(header),
+	}
+
+	for idx, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff") // Excel writes a BOM at the start of UTF-8 CSVs
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+
+		if !slices.Contains(userImportColumns, name) {
+			continue
+		}
+
+		if _, ok := columns.positions[name]; ok {
+			return importColumns{}, errx.ErrInvalidCSVStructure.WithDetails(map[string]any{
+				"duplicate": name,
+			}).WithLocation("UserService.Import")
+		}
+		columns.positions[name] = idx
+	}
+
+	var missing []string
+	for _, name := range requiredUserImportColumns {
+		if _, ok := columns.positions[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return importColumns{}, errx.ErrInvalidCSVStructure.WithDetails(map[string]any{
+			"missing": missing,
+		}).WithLocation("UserService.Import")
+	}
+
+	return columns, nil
+}
+
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// importJobActor returns who uploaded the file of job
+func importJobActor(job *entity.ImportJob) entity.AuditActor {
+	actor := entity.AuditActor{
+		Type: job.ActorType,
+		ID:   job.ActorID,
+	}
+	if job.IPAddress != nil {
+		actor.IPAddress = *job.IPAddress
+	}
+	if job.UserAgent != nil {
+		actor.UserAgent = *job.UserAgent
+	}
+
+	return actor
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,651 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
+	importJobRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/repository/mock"
+	userRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/repository/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
+	mockTabular "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular/mock"
+	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserService_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
+	ctx := context.Background()
+
+	testID := uuid.New()
+	actorID := uuid.New().String()
+	actor := entity.AuditActor{
+		Type:      entity.AuditActorAdmin,
+		ID:        &actorID,
+		IPAddress: "10.0.0.1",
+	}
+	fileContent := "phone_number,name\n+1234567890,John Doe\n"
+
+	tests := []struct {
+		name     string
+		filename string
+		req      *dto.ImportUsersRequest
+		setup    func()
+		wantErr  bool
+		errType  error
+	}{
+		{
+			name:     "success - job is queued with the file and options",
+			filename: "roster.csv",
+			req: &dto.ImportUsersRequest{
+				Mode:         entity.UserImportModeUpsert,
+				AllowPartial: true,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockImportJobRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, job *entity.ImportJob) error {
+					assert.Equal(t, testID, job.ID)
+					assert.Equal(t, entity.ImportJobTypeUsers, job.Type)
+					assert.Equal(t, entity.ImportJobStatusPending, job.Status)
+					assert.Equal(t, "roster.csv", job.FileName)
+					assert.Equal(t, tabular.FormatCSV, job.FileFormat)
+					assert.Equal(t, fileContent, string(job.FileData))
+					assert.JSONEq(t, `{"mode":"upsert","dryRun":false,"allowPartial":true,"deactivateMissing":false}`, string(job.Options))
+					assert.Equal(t, entity.AuditActorAdmin, job.ActorType)
+					assert.Equal(t, &actorID, job.ActorID)
+					assert.Equal(t, "10.0.0.1", *job.IPAddress)
+					assert.Nil(t, job.UserAgent)
+					return nil
+				})
+			},
+			wantErr: false,
+		},
+		{
+			name:     "validation error - deactivateMissing without upsert",
+			filename: "roster.csv",
+			req: &dto.ImportUsersRequest{
+				DeactivateMissing: true,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
+					"body.deactivateMissing": validator.ValidationError{
+						Message: "deactivateMissing is only allowed in upsert mode",
+					},
+				})
+			},
+			wantErr: true,
+		},
+		{
+			name:     "validation error - unsupported file format",
+			filename: "roster.pdf",
+			req:      &dto.ImportUsersRequest{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidFileExtension,
+		},
+		{
+			name:     "repository error",
+			filename: "roster.csv",
+			req:      &dto.ImportUsersRequest{},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(testID, nil)
+				mockImportJobRepo.EXPECT().Create(ctx, gomock.Any()).Return(errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			tt.req.File = newFileHeader(t, tt.filename, fileContent)
+
+			result, err := service.Import(ctx, actor, tt.req)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testID.String(), result.ID)
+				assert.Equal(t, entity.ImportJobStatusPending, result.Status)
+			}
+		})
+	}
+}
+
+// newFileHeader returns an uploaded file, as read from a multipart form
+func newFileHeader(t *testing.T, filename string, content string) *multipart.FileHeader {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	assert.NoError(t, err)
+
+	return form.File["file"][0]
+}
+
+func TestUserService_RunImportJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
+	ctx := context.Background()
+
+	testID1 := uuid.New()
+	testID2 := uuid.New()
+	jobID := uuid.New()
+
+	header := []string{"phone_number", "name", "job_title", "gender", "date_of_birth"}
+	badGender := "other"
+	longJobTitle := strings.Repeat("x", 256)
+
+	// One problem per row; rows 8 and 11 are the only valid ones
+	problemRecords := [][]string{
+		header,
+		{"+1234567890", "John Doe", "", "", "", "extra"},
+		{"", "No Phone", "", "", ""},
+		{"+1234567891", "", "", "", ""},
+		{"12345", "Bad Phone", "", "", ""},
+		{"+1234567892", "Bad Gender", "", "other", ""},
+		{"+1234567893", "Bad Date", "", "", "01/15/1990"},
+		{"+1234567894", "First", "", "", ""},
+		{"+1234567894", "Second", "", "", ""},
+		{"+1234567895", "Existing", "", "", ""},
+		{"+1234567896", "Valid", "", "", ""},
+		{"+1234567897", "Long Job Title", longJobTitle, "", ""},
+	}
+
+	fieldErrors := func(field string, tag string) validator.ValidationErrors {
+		return validator.ValidationErrors{
+			"body": validator.ValidationError{
+				Fields: map[string]validator.FieldError{
+					field: {Tag: tag},
+				},
+			},
+		}
+	}
+
+	problemSetup := func() {
+		mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return(problemRecords, nil)
+		mockValidator.EXPECT().Validate(&dto.CreateUserRequest{PhoneNumber: "12345", Name: "Bad Phone"}).Return(fieldErrors("phoneNumber", "e164"))
+		mockValidator.EXPECT().Validate(&dto.CreateUserRequest{PhoneNumber: "+1234567892", Name: "Bad Gender", Gender: &badGender}).Return(fieldErrors("gender", "oneof"))
+		mockValidator.EXPECT().Validate(&dto.CreateUserRequest{PhoneNumber: "+1234567897", Name: "Long Job Title", JobTitle: &longJobTitle}).Return(fieldErrors("jobTitle", "max"))
+		mockValidator.EXPECT().Validate(&dto.CreateUserRequest{PhoneNumber: "", Name: "No Phone"}).Return(fieldErrors("phoneNumber", "required"))
+		mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(6)
+		mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, []string{"+1234567894", "+1234567895", "+1234567896"}).Return([]string{"+1234567895"}, nil)
+	}
+
+	problemErrors := []dto.ImportRowError{
+		{Row: 2, Column: "", Value: "+1234567890,John Doe,,,,extra", Code: errx.ErrInvalidCSVRow.ErrorCode},
+		{Row: 3, Column: "phone_number", Value: "", Code: errx.ErrMissingPhoneNumber.ErrorCode},
+		{Row: 4, Column: "name", Value: "", Code: errx.ErrMissingName.ErrorCode},
+		{Row: 5, Column: "phone_number", Value: "12345", Code: errx.ErrInvalidPhoneNumber.ErrorCode},
+		{Row: 6, Column: "gender", Value: "other", Code: errx.ErrInvalidGender.ErrorCode},
+		{Row: 7, Column: "date_of_birth", Value: "01/15/1990", Code: errx.ErrInvalidDateFormat.ErrorCode},
+		{Row: 9, Column: "phone_number", Value: "+1234567894", Code: errx.ErrDuplicatePhoneInFile.ErrorCode},
+		{Row: 10, Column: "phone_number", Value: "+1234567895", Code: errx.ErrUserPhoneExists.ErrorCode},
+		{Row: 12, Column: "job_title", Value: longJobTitle, Code: errx.ErrInvalidJobTitle.ErrorCode},
+	}
+
+	tests := []struct {
+		name       string
+		opts       dto.ImportUsersOptions
+		committed  json.RawMessage
+		setup      func()
+		wantErr    bool
+		errType    error
+		wantRes    *dto.ImportUsersResponse
+		wantErrors []dto.ImportRowError
+	}{
+		{
+			name: "success - valid CSV with all fields",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "Software Engineer", "male", "1990-01-15"},
+					{"+0987654321", "Jane Smith", "Product Manager", "female", "1985-05-20"},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, []string{"+1234567890", "+0987654321"}).Return([]string{}, nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUUID.EXPECT().NewV7().Return(testID2, nil)
+				mockUserRepo.EXPECT().BulkCreate(ctx, gomock.Any(), jobID).DoAndReturn(func(ctx context.Context, users []entity.User, importJobID uuid.UUID) error {
+					assert.Len(t, users, 2)
+					assert.Equal(t, testID1, users[0].ID)
+					assert.Equal(t, "+1234567890", users[0].PhoneNumber)
+					assert.Equal(t, "John Doe", users[0].Name)
+					assert.Equal(t, "Software Engineer", *users[0].JobTitle)
+					assert.Equal(t, "male", *users[0].Gender)
+					assert.NotNil(t, users[0].DateOfBirth)
+					assert.NotNil(t, users[0].CreatedAt)
+					assert.NotNil(t, users[0].UpdatedAt)
+					return nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionUserImport, req.Action)
+					assert.Equal(t, entity.AuditActorAdmin, req.Actor.Type)
+					assert.Equal(t, "10.0.0.1", req.Actor.IPAddress)
+					assert.Equal(t, 2, req.Metadata["imported"])
+					assert.Equal(t, "roster.csv", req.Metadata["fileName"])
+					assert.Equal(t, jobID.String(), req.Metadata["importJobId"])
+				})
+			},
+			wantErr:    false,
+			wantRes:    &dto.ImportUsersResponse{Mode: entity.UserImportModeCreate, Committed: true, TotalRows: 2, ValidRows: 2, Imported: 2, Created: 2},
+			wantErrors: []dto.ImportRowError{},
+		},
+		{
+			name:      "re-run after the users were written reports the earlier write",
+			opts:      dto.ImportUsersOptions{AllowPartial: true},
+			committed: json.RawMessage(`{"created":["+1234567890"],"updated":[],"unchanged":[],"deactivated":[]}`),
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "", "", ""},
+					{"+0987654321", "Jane Smith", "", "", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				// John Doe was created by the earlier run, Jane Smith already existed
+				mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, []string{"+1234567890", "+0987654321"}).Return([]string{"+1234567890", "+0987654321"}, nil)
+			},
+			wantErr: false,
+			wantRes: &dto.ImportUsersResponse{Mode: entity.UserImportModeCreate, Committed: true, TotalRows: 2, ValidRows: 1, InvalidRows: 1, Imported: 1, Created: 1},
+			wantErrors: []dto.ImportRowError{
+				{Row: 3, Column: "phone_number", Value: "+0987654321", Code: errx.ErrUserPhoneExists.ErrorCode},
+			},
+		},
+		{
+			name: "success - valid CSV with optional fields empty",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "", "", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, gomock.Any()).Return([]string{}, nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUserRepo.EXPECT().BulkCreate(ctx, gomock.Any(), jobID).DoAndReturn(func(ctx context.Context, users []entity.User, importJobID uuid.UUID) error {
+					assert.Len(t, users, 1)
+					assert.Equal(t, "+1234567890", users[0].PhoneNumber)
+					assert.Equal(t, "John Doe", users[0].Name)
+					assert.Nil(t, users[0].JobTitle)
+					assert.Nil(t, users[0].Gender)
+					assert.Nil(t, users[0].DateOfBirth)
+					return nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any())
+			},
+			wantErr:    false,
+			wantRes:    &dto.ImportUsersResponse{Mode: entity.UserImportModeCreate, Committed: true, TotalRows: 1, ValidRows: 1, Imported: 1, Created: 1},
+			wantErrors: []dto.ImportRowError{},
+		},
+		{
+			name: "every row problem is reported and nothing is imported",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				problemSetup()
+			},
+			wantErr:    false,
+			wantRes:    &dto.ImportUsersResponse{Mode: entity.UserImportModeCreate, TotalRows: 11, ValidRows: 2, InvalidRows: 9},
+			wantErrors: problemErrors,
+		},
+		{
+			name: "dry run - clean file isn't imported",
+			opts: dto.ImportUsersOptions{DryRun: true, AllowPartial: true},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "", "", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, gomock.Any()).Return([]string{}, nil)
+			},
+			wantErr:    false,
+			wantRes:    &dto.ImportUsersResponse{Mode: entity.UserImportModeCreate, DryRun: true, TotalRows: 1, ValidRows: 1},
+			wantErrors: []dto.ImportRowError{},
+		},
+		{
+			name: "partial import - only valid rows are imported",
+			opts: dto.ImportUsersOptions{AllowPartial: true},
+			setup: func() {
+				problemSetup()
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUUID.EXPECT().NewV7().Return(testID2, nil)
+				mockUserRepo.EXPECT().BulkCreate(ctx, gomock.Any(), jobID).DoAndReturn(func(ctx context.Context, users []entity.User, importJobID uuid.UUID) error {
+					assert.Len(t, users, 2)
+					assert.Equal(t, "First", users[0].Name)
+					assert.Equal(t, "+1234567896", users[1].PhoneNumber)
+					return nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, 9, req.Metadata["skipped"])
+				})
+			},
+			wantErr:    false,
+			wantRes:    &dto.ImportUsersResponse{Mode: entity.UserImportModeCreate, Committed: true, TotalRows: 11, ValidRows: 2, InvalidRows: 9, Imported: 2, Created: 2},
+			wantErrors: problemErrors,
+		},
+		{
+			name: "upsert - existing users are updated and missing users deactivated",
+			opts: dto.ImportUsersOptions{
+				Mode:              entity.UserImportModeUpsert,
+				AllowPartial:      true,
+				DeactivateMissing: true,
+			},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "Software Engineer", "male", "1990-01-15"},
+					{"+0987654321", "Jane Smith", "", "", ""},
+					{"+1122334455", "Bad Gender", "", "other", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(&dto.CreateUserRequest{PhoneNumber: "+1122334455", Name: "Bad Gender", Gender: &badGender}).Return(fieldErrors("gender", "oneof"))
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUUID.EXPECT().NewV7().Return(testID2, nil)
+				mockUserRepo.EXPECT().Upsert(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, users []entity.User, opts entity.UpsertUsersOptions) (*entity.UpsertUsersResult, error) {
+					assert.Len(t, users, 2)
+					assert.Equal(t, testID1, users[0].ID)
+					assert.True(t, opts.DeactivateMissing)
+					assert.Equal(t, jobID, opts.ImportJobID)
+					// The invalid row still keeps its user active
+					assert.Equal(t, []string{"+1234567890", "+0987654321", "+1122334455"}, opts.PresentPhoneNumbers)
+					return &entity.UpsertUsersResult{
+						Created:     []string{"+0987654321"},
+						Updated:     []string{"+1234567890"},
+						Unchanged:   []string{},
+						Deactivated: []string{"+6281111111111", "+6282222222222"},
+					}, nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.UserImportModeUpsert, req.Metadata["mode"])
+					assert.Equal(t, []string{"+6281111111111", "+6282222222222"}, req.Metadata["deactivated"])
+				})
+			},
+			wantErr: false,
+			wantRes: &dto.ImportUsersResponse{
+				Mode:        entity.UserImportModeUpsert,
+				Committed:   true,
+				TotalRows:   3,
+				ValidRows:   2,
+				InvalidRows: 1,
+				Imported:    2,
+				Created:     1,
+				Updated:     1,
+				Unchanged:   0,
+				Deactivated: 2,
+			},
+			wantErrors: []dto.ImportRowError{
+				{Row: 4, Column: "gender", Value: "other", Code: errx.ErrInvalidGender.ErrorCode},
+			},
+		},
+		{
+			name: "upsert - unchanged users",
+			opts: dto.ImportUsersOptions{Mode: entity.UserImportModeUpsert},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "", "", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUserRepo.EXPECT().Upsert(ctx, gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, users []entity.User, opts entity.UpsertUsersOptions) (*entity.UpsertUsersResult, error) {
+					assert.False(t, opts.DeactivateMissing)
+					return &entity.UpsertUsersResult{
+						Created:     []string{},
+						Updated:     []string{},
+						Unchanged:   []string{"+1234567890"},
+						Deactivated: []string{},
+					}, nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any())
+			},
+			wantErr:    false,
+			wantRes:    &dto.ImportUsersResponse{Mode: entity.UserImportModeUpsert, Committed: true, TotalRows: 1, ValidRows: 1, Unchanged: 1},
+			wantErrors: []dto.ImportRowError{},
+		},
+		{
+			name: "upsert - repository error",
+			opts: dto.ImportUsersOptions{Mode: entity.UserImportModeUpsert},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "", "", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUserRepo.EXPECT().Upsert(ctx, gomock.Any(), gomock.Any()).Return(nil, errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+		{
+			name: "validation error - empty CSV file",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{}, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrEmptyCSVFile,
+		},
+		{
+			name: "validation error - only header no data rows",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+				}, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrCSVNoData,
+		},
+		{
+			name: "success - columns in any order with extra columns and blank rows",
+			opts: dto.ImportUsersOptions{AllowPartial: true},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					{"\ufeffEmployee ID", "Name", "Date of Birth", "Phone Number"},
+					{"E001", " Jane Smith ", "1985-05-20", "+0987654321"},
+					{},
+					{"", "", ""},
+					// Excel leaves out trailing empty cells
+					{"E002", "John Doe"},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
+				mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, []string{"+0987654321"}).Return([]string{}, nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUserRepo.EXPECT().BulkCreate(ctx, gomock.Any(), jobID).DoAndReturn(func(ctx context.Context, users []entity.User, importJobID uuid.UUID) error {
+					assert.Len(t, users, 1)
+					assert.Equal(t, "+0987654321", users[0].PhoneNumber)
+					assert.Equal(t, "Jane Smith", users[0].Name)
+					assert.Nil(t, users[0].JobTitle)
+					assert.Equal(t, "1985-05-20", users[0].DateOfBirth.Format(time.DateOnly))
+					return nil
+				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any())
+			},
+			wantErr: false,
+			wantRes: &dto.ImportUsersResponse{Mode: entity.UserImportModeCreate, Committed: true, TotalRows: 2, ValidRows: 1, InvalidRows: 1, Imported: 1, Created: 1},
+			wantErrors: []dto.ImportRowError{
+				{Row: 5, Column: "phone_number", Value: "", Code: errx.ErrMissingPhoneNumber.ErrorCode},
+			},
+		},
+		{
+			name: "validation error - missing required column",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					{"phone_number", "job_title"},
+					{"+1234567890", "Engineer"},
+				}, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidCSVStructure,
+		},
+		{
+			name: "validation error - duplicate column",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					{"phone_number", "name", "Name"},
+					{"+1234567890", "John Doe", "John Doe"},
+				}, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidCSVStructure,
+		},
+		{
+			name: "repository error - duplicate phone number",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "", "", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, gomock.Any()).Return([]string{}, nil)
+				mockUUID.EXPECT().NewV7().Return(testID1, nil)
+				mockUserRepo.EXPECT().BulkCreate(ctx, gomock.Any(), jobID).Return(errx.ErrUserPhoneExists)
+			},
+			wantErr: true,
+			errType: errx.ErrUserPhoneExists,
+		},
+		{
+			name: "repository error - existing phone number lookup",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "", "", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, gomock.Any()).Return(nil, errx.ErrInternalServer)
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+		{
+			name: "csv parsing error",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return(nil, errors.New("malformed csv"))
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+		{
+			name: "uuid generation error",
+			opts: dto.ImportUsersOptions{},
+			setup: func() {
+				mockTabular.EXPECT().Parse(tabular.FormatCSV, gomock.Any()).Return([][]string{
+					header,
+					{"+1234567890", "John Doe", "", "", ""},
+				}, nil)
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().FindExistingPhoneNumbers(ctx, gomock.Any()).Return([]string{}, nil)
+				mockUUID.EXPECT().NewV7().Return(uuid.Nil, errors.New("uuid generation failed"))
+			},
+			wantErr: true,
+			errType: errx.ErrInternalServer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			options, err := json.Marshal(tt.opts)
+			assert.NoError(t, err)
+
+			ipAddress := "10.0.0.1"
+			job := &entity.ImportJob{
+				ID:         jobID,
+				Type:       entity.ImportJobTypeUsers,
+				FileName:   "roster.csv",
+				FileFormat: tabular.FormatCSV,
+				Options:    options,
+				ActorType:  entity.AuditActorAdmin,
+				IPAddress:  &ipAddress,
+
+				CommittedResult: tt.committed,
+			}
+
+			var progress [][2]int
+			result, err := service.RunImportJob(ctx, job, func(totalRows int, processedRows int) {
+				progress = append(progress, [2]int{totalRows, processedRows})
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+
+				// Messages are checked through the codes
+				gotErrors := make([]dto.ImportRowError, 0, len(result.Errors))
+				for _, rowErr := range result.Errors {
+					assert.NotEmpty(t, rowErr.Message)
+					rowErr.Message = ""
+					gotErrors = append(gotErrors, rowErr)
+				}
+				assert.Equal(t, tt.wantErrors, gotErrors)
+
+				result.Errors = nil
+				assert.Equal(t, tt.wantRes, result)
+				assert.Equal(t, [2]int{result.TotalRows, 0}, progress[0])
+			}
+		})
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
 )
 
-func (s *UserService) Create(ctx context.Context, req *dto.CreateUserRequest) (*dto.CreateUserResponse, error) {
+func (s *UserService) Create(ctx context.Context, actor entity.AuditActor, req *dto.CreateUserRequest) (*dto.CreateUserResponse, error) {
 	if err := s.validator.Validate(req); err != nil {
 		return nil, err
 	}
@@ -37,14 +37,23 @@ func (s *UserService) Create(ctx context.Context, req *dto.CreateUserRequest) (*
 		JobTitle:    req.JobTitle,
 		Gender:      req.Gender,
 		DateOfBirth: dateOfBirth,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
 	}
 
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
+	targetID := id.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionUserCreate,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   &targetID,
+		After:      dto.ToUserResponse(user),
+	})
+
 	res := dto.CreateUserResponse{
 		ID: id.String(),
 	}
@@ -90,6 +99,13 @@ func (s *UserService) GetByPhoneNumber(ctx context.Context, param *dto.GetUserBy
 		return nil, err
 	}
 
+	// Deactivated users can't use the bot anymore
+	if user.DeactivatedAt != nil {
+		return nil, errx.ErrUserNotFound.WithDetails(map[string]any{
+			"phone_number": param.PhoneNumber,
+		}).WithLocation("UserService.GetByPhoneNumber")
+	}
+
 	userRes := dto.ToUserResponse(user)
 
 	res := &dto.GetUserByPhoneNumberResponse{
@@ -105,6 +121,11 @@ func (s *UserService) List(ctx context.Context, query *dto.GetUsersQuery) (*dto.
 	}
 
 	limit := min(max(query.Limit, 10), 100)
+
+	if query.Cursor != "" {
+		return s.listByCursor(ctx, query, limit)
+	}
+
 	page := max(query.Page, 1)
 
 	filter := entity.GetUsersFilter{
@@ -118,23 +139,66 @@ func (s *UserService) List(ctx context.Context, query *dto.GetUsersQuery) (*dto.
 		return nil, err
 	}
 
-	userResponses := make([]dto.UserResponse, 0, len(users))
-	for i := range users {
-		userResponses = append(userResponses, dto.ToUserResponse(&users[i]))
+	paginationResponse := dto.NewPaginationResponse(total, page, limit)
+
+	res := &dto.GetUsersResponse{
+		Users: toUserResponses(users),
 	}
 
-	paginationResponse := dto.NewPaginationResponse(total, page, limit)
+	res.Meta.Pagination = &paginationResponse
+
+	// Lets the next page be fetched by cursor, past the offset limit
+	if len(users) > 0 && int64(filter.Offset+len(users)) < total {
+		last := users[len(users)-1]
+		nextCursor := dto.EncodeCursor(last.CreatedAt, last.ID)
+		res.Meta.NextCursor = &nextCursor
+	}
+
+	return res, nil
+}
+
+func (s *UserService) listByCursor(ctx context.Context, query *dto.GetUsersQuery, limit int) (*dto.GetUsersResponse, error) {
+	cursor, err := dto.DecodeCursor(query.Cursor)
+	if err != nil {
+		return nil, errx.ErrInvalidCursor.WithDetails(map[string]any{
+			"cursor": query.Cursor,
+		}).WithLocation("UserService.List").WithError(err)
+	}
+
+	filter := entity.GetUsersFilter{
+		Limit:  limit,
+		Search: query.Search,
+		After:  cursor,
+	}
+
+	users, hasMore, err := s.userRepo.ListByCursor(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
 
 	res := &dto.GetUsersResponse{
-		Users: userResponses,
+		Users: toUserResponses(users),
 	}
 
-	res.Meta.Pagination = paginationResponse
+	if hasMore {
+		last := users[len(users)-1]
+		nextCursor := dto.EncodeCursor(last.CreatedAt, last.ID)
+		res.Meta.NextCursor = &nextCursor
+	}
 
 	return res, nil
 }
 
-func (s *UserService) Update(ctx context.Context, param *dto.UpdateUserParam, req *dto.UpdateUserRequest) error {
+func toUserResponses(users []entity.User) []dto.UserResponse {
+	userResponses := make([]dto.UserResponse, 0, len(users))
+	for i := range users {
+		userResponses = append(userResponses, dto.ToUserResponse(&users[i]))
+	}
+
+	return userResponses
+}
+
+func (s *UserService) Update(ctx context.Context, actor entity.AuditActor, param *dto.UpdateUserParam, req *dto.UpdateUserRequest) error {
 	if err := s.validator.Validate(param); err != nil {
 		return err
 	}
@@ -155,6 +219,8 @@ func (s *UserService) Update(ctx context.Context, param *dto.UpdateUserParam, re
 		return err
 	}
 
+	before := dto.ToUserResponse(user)
+
 	if req.PhoneNumber != nil {
 		user.PhoneNumber = *req.PhoneNumber
 	}
@@ -181,16 +247,26 @@ func (s *UserService) Update(ctx context.Context, param *dto.UpdateUserParam, re
 		}
 	}
 
-	user.UpdatedAt = time.Now()
+	user.UpdatedAt = time.Now().UTC()
 
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return err
 	}
 
+	targetID := user.ID.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionUserUpdate,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   &targetID,
+		Before:     before,
+		After:      dto.ToUserResponse(user),
+	})
+
 	return nil
 }
 
-func (s *UserService) Delete(ctx context.Context, param *dto.DeleteUserParam) error {
+func (s *UserService) Delete(ctx context.Context, actor entity.AuditActor, param *dto.DeleteUserParam) error {
 	if err := s.validator.Validate(param); err != nil {
 		return err
 	}
@@ -202,10 +278,25 @@ func (s *UserService) Delete(ctx context.Context, param *dto.DeleteUserParam) er
 		}).WithLocation("UserService.Delete").WithError(err)
 	}
 
+	// Keep a snapshot so the audit log shows who was removed
+	user, err := s.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
 	if err := s.userRepo.Delete(ctx, id); err != nil {
 		return err
 	}
 
+	targetID := id.String()
+	s.auditSvc.Record(ctx, &dto.RecordAuditEventRequest{
+		Actor:      actor,
+		Action:     entity.AuditActionUserDelete,
+		TargetType: entity.AuditTargetUser,
+		TargetID:   &targetID,
+		Before:     dto.ToUserResponse(user),
+	})
+
 	return nil
 }
 
@@ -234,143 +325,3 @@ func (s *UserService) GetMetrics(ctx context.Context) (*dto.GetUserMetricsRespon
 
 	return res, nil
 }
-
-func (s *UserService) ImportFromCSV(ctx context.Context, req *dto.ImportUsersFromCSVRequest) error {
-	if err := s.validator.Validate(req); err != nil {
-		return err
-	}
-
-	records, err := s.csvPkg.ParseFileHeader(req.File)
-	if err != nil {
-		return errx.ErrInternalServer.WithLocation("UserService.ImportFromCSV").WithError(err)
-	}
-
-	// Validate CSV is not empty
-	if len(records) == 0 {
-		return errx.ErrEmptyCSVFile.WithLocation("UserService.ImportFromCSV")
-	}
-
-	// Validate header row exists and has correct columns
-	if len(records) < 2 {
-		return errx.ErrCSVNoData.WithLocation("UserService.ImportFromCSV")
-	}
-
-	header := records[0]
-	expectedColumns := 5
-	if len(header) != expectedColumns {
-		return errx.ErrInvalidCSVStructure.
-			WithLocation("UserService.ImportFromCSV").
-			WithDetails(map[string]any{
-				"expected": expectedColumns,
-				"got":      len(header),
-			})
-	}
-
-	users := make([]entity.User, 0, len(records)-1)
-	now := time.Now()
-
-	for idx, record := range records {
-		// skip header
-		if idx == 0 {
-			continue
-		}
-
-		// Validate record has correct number of columns
-		if len(record) != expectedColumns {
-			return errx.ErrInvalidCSVRow.
-				WithLocation("UserService.ImportFromCSV").
-				WithDetails(map[string]any{
-					"row":      idx + 1,
-					"expected": expectedColumns,
-					"got":      len(record),
-				})
-		}
-
-		phoneNumber := record[0]
-		name := record[1]
-
-		// Validate required fields
-		if phoneNumber == "" {
-			return errx.ErrMissingPhoneNumber.
-				WithLocation("UserService.ImportFromCSV").
-				WithDetails(map[string]any{"row": idx + 1})
-		}
-		if name == "" {
-			return errx.ErrMissingName.
-				WithLocation("UserService.ImportFromCSV").
-				WithDetails(map[string]any{"row": idx + 1})
-		}
-
-		// Validate phone number format using the same validator
-		phoneValidationErr := s.validator.Validate(&dto.CreateUserRequest{
-			PhoneNumber: phoneNumber,
-			Name:        name,
-		})
-		if phoneValidationErr != nil {
-			return errx.ErrInvalidPhoneNumber.
-				WithLocation("UserService.ImportFromCSV").
-				WithDetails(map[string]any{
-					"row":         idx + 1,
-					"phoneNumber": phoneNumber,
-				}).
-				WithError(phoneValidationErr)
-		}
-
-		id, err := s.uuidPkg.NewV7()
-		if err != nil {
-			return errx.ErrInternalServer.WithLocation("UserService.ImportFromCSV").WithError(err)
-		}
-
-		var jobTitle *string
-		if record[2] != "" {
-			jobTitle = &record[2]
-		}
-
-		var gender *string
-		if record[3] != "" {
-			genderValue := record[3]
-			// Validate gender value
-			if genderValue != "male" && genderValue != "female" {
-				return errx.ErrInvalidGender.
-					WithLocation("UserService.ImportFromCSV").
-					WithDetails(map[string]any{
-						"row":    idx + 1,
-						"gender": genderValue,
-					})
-			}
-			gender = &genderValue
-		}
-
-		var dateOfBirth *time.Time
-		if record[4] != "" {
-			parsedDate, err := time.Parse(time.DateOnly, record[4])
-			if err != nil {
-				return errx.ErrInvalidDateFormat.WithDetails(map[string]any{
-					"row":            idx + 1,
-					"dateOfBirth":    record[4],
-					"expectedFormat": "YYYY-MM-DD",
-				}).WithLocation("UserService.ImportFromCSV").WithError(err)
-			}
-			dateOfBirth = &parsedDate
-		}
-
-		user := entity.User{
-			ID:          id,
-			PhoneNumber: phoneNumber,
-			Name:        name,
-			JobTitle:    jobTitle,
-			Gender:      gender,
-			DateOfBirth: dateOfBirth,
-			CreatedAt:   now,
-			UpdatedAt:   now,
-		}
-
-		users = append(users, user)
-	}
-
-	if err := s.userRepo.BulkCreate(ctx, users); err != nil {
-		return err
-	}
-
-	return nil
-}
@@ -9,8 +9,10 @@ import (
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	auditSvcMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service/mock"
+	importJobRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/repository/mock"
 	userRepoMock "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/repository/mock"
-	mockCSV "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/csv/mock"
+	mockTabular "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular/mock"
 	mockUUID "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid/mock"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
 	mockValidator "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator/mock"
@@ -24,14 +26,17 @@ func TestUserService_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
 	ctx := context.Background()
 
 	testID := uuid.New()
+	testActor := entity.AuditActor{Type: entity.AuditActorAdmin, IPAddress: "127.0.0.1"}
 	jobTitle := "Software Engineer"
 	gender := "male"
 	dateOfBirth := "1990-01-01"
@@ -64,6 +69,12 @@ func TestUserService_Create(t *testing.T) {
 					assert.NotNil(t, user.DateOfBirth)
 					return nil
 				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, testActor, req.Actor)
+					assert.Equal(t, entity.AuditActionUserCreate, req.Action)
+					assert.Equal(t, testID.String(), *req.TargetID)
+					assert.Nil(t, req.Before)
+				})
 			},
 			wantErr: false,
 		},
@@ -128,7 +139,7 @@ func TestUserService_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			result, err := service.Create(ctx, tt.req)
+			result, err := service.Create(ctx, testActor, tt.req)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -150,11 +161,13 @@ func TestUserService_GetByID(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
 	ctx := context.Background()
 
 	testID := uuid.New()
@@ -252,11 +265,13 @@ func TestUserService_GetByPhoneNumber(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
 	ctx := context.Background()
 
 	testID := uuid.New()
@@ -312,6 +327,21 @@ func TestUserService_GetByPhoneNumber(t *testing.T) {
 			},
 			wantErr: true,
 			errType: errx.ErrUserNotFound,
+		}, {
+			name: "deactivated user",
+			param: &dto.GetUserByPhoneNumberParam{
+				PhoneNumber: testPhoneNumber,
+			},
+			setup: func() {
+				deactivatedAt := time.Now()
+				deactivatedUser := *testUser
+				deactivatedUser.DeactivatedAt = &deactivatedAt
+
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().FindByPhoneNumber(ctx, testPhoneNumber).Return(&deactivatedUser, nil)
+			},
+			wantErr: true,
+			errType: errx.ErrUserNotFound,
 		},
 	}
 
@@ -342,11 +372,13 @@ func TestUserService_List(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
 	ctx := context.Background()
 
 	testUsers := []entity.User{
@@ -503,16 +535,152 @@ func TestUserService_List(t *testing.T) {
 	}
 }
 
+func TestUserService_ListByCursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
+	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
+	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
+
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 3, 1, 8, 0, 0, 123456000, time.UTC)
+	testUsers := []entity.User{
+		{
+			ID:          uuid.New(),
+			PhoneNumber: "+1234567890",
+			Name:        "User 1",
+			CreatedAt:   createdAt.Add(time.Minute),
+		},
+		{
+			ID:          uuid.New(),
+			PhoneNumber: "+0987654321",
+			Name:        "User 2",
+			CreatedAt:   createdAt,
+		},
+	}
+	lastCursor := dto.EncodeCursor(testUsers[1].CreatedAt, testUsers[1].ID)
+
+	tests := []struct {
+		name           string
+		query          *dto.GetUsersQuery
+		setup          func()
+		wantErr        bool
+		errType        error
+		wantPagination bool
+		wantNextCursor *string
+	}{
+		{
+			name: "page mode hands out a cursor for the next page",
+			query: &dto.GetUsersQuery{
+				Page:  1,
+				Limit: 2,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().List(ctx, gomock.Any()).Return(testUsers, int64(20000), nil)
+			},
+			wantErr:        false,
+			wantPagination: true,
+			wantNextCursor: &lastCursor,
+		},
+		{
+			name: "page mode on the last page",
+			query: &dto.GetUsersQuery{
+				Page:  1,
+				Limit: 10,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().List(ctx, gomock.Any()).Return(testUsers, int64(2), nil)
+			},
+			wantErr:        false,
+			wantPagination: true,
+			wantNextCursor: nil,
+		},
+		{
+			name: "cursor mode pages on without counting",
+			query: &dto.GetUsersQuery{
+				Limit:  2,
+				Search: "User",
+				Cursor: dto.EncodeCursor(createdAt.Add(time.Hour), testUsers[0].ID),
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().ListByCursor(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, filter *entity.GetUsersFilter) ([]entity.User, bool, error) {
+					assert.Equal(t, 0, filter.Offset)
+					assert.Equal(t, "User", filter.Search)
+					assert.True(t, createdAt.Add(time.Hour).Equal(filter.After.CreatedAt))
+					assert.Equal(t, testUsers[0].ID, filter.After.ID)
+					return testUsers, true, nil
+				})
+			},
+			wantErr:        false,
+			wantPagination: false,
+			wantNextCursor: &lastCursor,
+		},
+		{
+			name: "cursor mode on the last page",
+			query: &dto.GetUsersQuery{
+				Cursor: lastCursor,
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+				mockUserRepo.EXPECT().ListByCursor(ctx, gomock.Any()).Return(testUsers[:1], false, nil)
+			},
+			wantErr:        false,
+			wantPagination: false,
+			wantNextCursor: nil,
+		},
+		{
+			name: "invalid cursor",
+			query: &dto.GetUsersQuery{
+				Cursor: "not-a-cursor",
+			},
+			setup: func() {
+				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+			errType: errx.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+			result, err := service.List(ctx, tt.query)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.errType != nil {
+					assert.ErrorIs(t, err, tt.errType)
+				}
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPagination, result.Meta.Pagination != nil)
+				assert.Equal(t, tt.wantNextCursor, result.Meta.NextCursor)
+			}
+		})
+	}
+}
 func TestUserService_Update(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
 	ctx := context.Background()
 
 	testID := uuid.New()
@@ -529,6 +697,7 @@ func TestUserService_Update(t *testing.T) {
 	newJobTitle := "Senior Engineer"
 	newGender := "female"
 	newDateOfBirth := "1985-05-15"
+	testActor := entity.AuditActor{Type: entity.AuditActorAdmin, IPAddress: "127.0.0.1"}
 
 	tests := []struct {
 		name    string
@@ -554,6 +723,7 @@ func TestUserService_Update(t *testing.T) {
 					assert.Equal(t, newPhone, user.PhoneNumber)
 					return nil
 				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any())
 			},
 			wantErr: false,
 		},
@@ -573,6 +743,13 @@ func TestUserService_Update(t *testing.T) {
 					assert.Equal(t, newName, user.Name)
 					return nil
 				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, testActor, req.Actor)
+					assert.Equal(t, entity.AuditActionUserUpdate, req.Action)
+					assert.Equal(t, testID.String(), *req.TargetID)
+					assert.Equal(t, "Test User", req.Before.(dto.UserResponse).Name)
+					assert.Equal(t, newName, req.After.(dto.UserResponse).Name)
+				})
 			},
 			wantErr: false,
 		},
@@ -600,6 +777,7 @@ func TestUserService_Update(t *testing.T) {
 					assert.NotNil(t, user.DateOfBirth)
 					return nil
 				})
+				mockAudit.EXPECT().Record(ctx, gomock.Any())
 			},
 			wantErr: false,
 		},
@@ -708,7 +886,7 @@ func TestUserService_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			err := service.Update(ctx, tt.param, tt.req)
+			err := service.Update(ctx, testActor, tt.param, tt.req)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -727,14 +905,22 @@ func TestUserService_Delete(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
 	ctx := context.Background()
 
 	testID := uuid.New()
+	testUser := &entity.User{
+		ID:          testID,
+		PhoneNumber: "+1234567890",
+		Name:        "Test User",
+	}
+	testActor := entity.AuditActor{Type: entity.AuditActorAdmin, IPAddress: "127.0.0.1"}
 
 	tests := []struct {
 		name    string
@@ -751,7 +937,13 @@ func TestUserService_Delete(t *testing.T) {
 			setup: func() {
 				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
 				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
+				mockUserRepo.EXPECT().FindByID(ctx, testID).Return(testUser, nil)
 				mockUserRepo.EXPECT().Delete(ctx, testID).Return(nil)
+				mockAudit.EXPECT().Record(ctx, gomock.Any()).Do(func(ctx context.Context, req *dto.RecordAuditEventRequest) {
+					assert.Equal(t, entity.AuditActionUserDelete, req.Action)
+					assert.Equal(t, "+1234567890", req.Before.(dto.UserResponse).PhoneNumber)
+					assert.Nil(t, req.After)
+				})
 			},
 			wantErr: false,
 		},
@@ -788,7 +980,7 @@ func TestUserService_Delete(t *testing.T) {
 			setup: func() {
 				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
 				mockUUID.EXPECT().Parse(testID.String()).Return(testID, nil)
-				mockUserRepo.EXPECT().Delete(ctx, testID).Return(errx.ErrUserNotFound)
+				mockUserRepo.EXPECT().FindByID(ctx, testID).Return(nil, errx.ErrUserNotFound)
 			},
 			wantErr: true,
 			errType: errx.ErrUserNotFound,
@@ -798,7 +990,7 @@ func TestUserService_Delete(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.setup()
-			err := service.Delete(ctx, tt.param)
+			err := service.Delete(ctx, testActor, tt.param)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -817,11 +1009,13 @@ func TestUserService_GetAllPhoneNumbers(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
 	ctx := context.Background()
 
 	testPhoneNumbers := []string{"+1234567890", "+0987654321", "+1122334455"}
@@ -887,11 +1081,13 @@ func TestUserService_GetMetrics(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
+	mockImportJobRepo := importJobRepoMock.NewMockImportJobRepository(ctrl)
 	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
 	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
+	mockTabular := mockTabular.NewMockCustomTabularInterface(ctrl)
+	mockAudit := auditSvcMock.NewMockAuditService(ctrl)
 
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
+	service := NewUserService(mockUserRepo, mockImportJobRepo, mockValidator, mockUUID, mockTabular, mockAudit)
 	ctx := context.Background()
 
 	tests := []struct {
@@ -954,257 +1150,3 @@ func TestUserService_GetMetrics(t *testing.T) {
 		})
 	}
 }
-
-func TestUserService_ImportFromCSV(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockUserRepo := userRepoMock.NewMockUserRepository(ctrl)
-	mockValidator := mockValidator.NewMockCustomValidatorInterface(ctrl)
-	mockUUID := mockUUID.NewMockUUIDInterface(ctrl)
-	mockCSV := mockCSV.NewMockCustomCSVInterface(ctrl)
-
-	service := NewUserService(mockUserRepo, mockValidator, mockUUID, mockCSV)
-	ctx := context.Background()
-
-	testID1 := uuid.New()
-	testID2 := uuid.New()
-
-	tests := []struct {
-		name    string
-		req     *dto.ImportUsersFromCSVRequest
-		setup   func()
-		wantErr bool
-		errType error
-	}{
-		{
-			name: "success - valid CSV with all fields",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"+1234567890", "John Doe", "Software Engineer", "male", "1990-01-15"},
-					{"+0987654321", "Jane Smith", "Product Manager", "female", "1985-05-20"},
-				}, nil)
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil).Times(2)
-				mockUUID.EXPECT().NewV7().Return(testID1, nil)
-				mockUUID.EXPECT().NewV7().Return(testID2, nil)
-				mockUserRepo.EXPECT().BulkCreate(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, users []entity.User) error {
-					assert.Len(t, users, 2)
-					assert.Equal(t, "+1234567890", users[0].PhoneNumber)
-					assert.Equal(t, "John Doe", users[0].Name)
-					assert.Equal(t, "Software Engineer", *users[0].JobTitle)
-					assert.Equal(t, "male", *users[0].Gender)
-					assert.NotNil(t, users[0].DateOfBirth)
-					assert.NotNil(t, users[0].CreatedAt)
-					assert.NotNil(t, users[0].UpdatedAt)
-					return nil
-				})
-			},
-			wantErr: false,
-		},
-		{
-			name: "success - valid CSV with optional fields empty",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"+1234567890", "John Doe", "", "", ""},
-				}, nil)
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockUUID.EXPECT().NewV7().Return(testID1, nil)
-				mockUserRepo.EXPECT().BulkCreate(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, users []entity.User) error {
-					assert.Len(t, users, 1)
-					assert.Equal(t, "+1234567890", users[0].PhoneNumber)
-					assert.Equal(t, "John Doe", users[0].Name)
-					assert.Nil(t, users[0].JobTitle)
-					assert.Nil(t, users[0].Gender)
-					assert.Nil(t, users[0].DateOfBirth)
-					return nil
-				})
-			},
-			wantErr: false,
-		},
-		{
-			name: "validation error - empty CSV file",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{}, nil)
-			},
-			wantErr: true,
-			errType: errx.ErrEmptyCSVFile,
-		},
-		{
-			name: "validation error - only header no data rows",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-				}, nil)
-			},
-			wantErr: true,
-			errType: errx.ErrCSVNoData,
-		},
-		{
-			name: "validation error - wrong number of columns in header",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name"},
-					{"+1234567890", "John Doe"},
-				}, nil)
-			},
-			wantErr: true,
-			errType: errx.ErrInvalidCSVStructure,
-		},
-		{
-			name: "validation error - wrong number of columns in data row",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"+1234567890", "John Doe"},
-				}, nil)
-			},
-			wantErr: true,
-			errType: errx.ErrInvalidCSVRow,
-		},
-		{
-			name: "validation error - missing phone number",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"", "John Doe", "", "", ""},
-				}, nil)
-			},
-			wantErr: true,
-			errType: errx.ErrMissingPhoneNumber,
-		},
-		{
-			name: "validation error - missing name",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"+1234567890", "", "", "", ""},
-				}, nil)
-			},
-			wantErr: true,
-			errType: errx.ErrMissingName,
-		},
-		{
-			name: "validation error - invalid phone number format",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"12345", "John Doe", "", "", ""},
-				}, nil)
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(validator.ValidationErrors{
-					"body.phone_number": validator.ValidationError{
-						Message: "phone_number must be in E.164 format",
-					},
-				})
-			},
-			wantErr: true,
-			errType: errx.ErrInvalidPhoneNumber,
-		},
-		{
-			name: "validation error - invalid gender",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"+1234567890", "John Doe", "", "other", ""},
-				}, nil)
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockUUID.EXPECT().NewV7().Return(testID1, nil)
-			},
-			wantErr: true,
-			errType: errx.ErrInvalidGender,
-		},
-		{
-			name: "validation error - invalid date format",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"+1234567890", "John Doe", "", "", "01/15/1990"},
-				}, nil)
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockUUID.EXPECT().NewV7().Return(testID1, nil)
-			},
-			wantErr: true,
-			errType: errx.ErrInvalidDateFormat,
-		},
-		{
-			name: "repository error - duplicate phone number",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"+1234567890", "John Doe", "", "", ""},
-				}, nil)
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockUUID.EXPECT().NewV7().Return(testID1, nil)
-				mockUserRepo.EXPECT().BulkCreate(ctx, gomock.Any()).Return(errx.ErrUserPhoneExists)
-			},
-			wantErr: true,
-			errType: errx.ErrUserPhoneExists,
-		},
-		{
-			name: "csv parsing error",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return(nil, errors.New("malformed csv"))
-			},
-			wantErr: true,
-			errType: errx.ErrInternalServer,
-		},
-		{
-			name: "uuid generation error",
-			req:  &dto.ImportUsersFromCSVRequest{},
-			setup: func() {
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockCSV.EXPECT().ParseFileHeader(gomock.Any()).Return([][]string{
-					{"phone_number", "name", "job_title", "gender", "date_of_birth"},
-					{"+1234567890", "John Doe", "", "", ""},
-				}, nil)
-				mockValidator.EXPECT().Validate(gomock.Any()).Return(nil)
-				mockUUID.EXPECT().NewV7().Return(uuid.Nil, errors.New("uuid generation failed"))
-			},
-			wantErr: true,
-			errType: errx.ErrInternalServer,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.setup()
-			err := service.ImportFromCSV(ctx, tt.req)
-
-			if tt.wantErr {
-				assert.Error(t, err)
-				if tt.errType != nil {
-					assert.ErrorIs(t, err, tt.errType)
-				}
-			} else {
-				assert.NoError(t, err)
-			}
-		})
-	}
-}
@@ -8,20 +8,30 @@ import (
 )
 
 type Env struct {
-	AppEnv       string        `mapstructure:"APP_ENV"`
-	AppPort      string        `mapstructure:"APP_PORT"`
-	APIKey       string        `mapstructure:"API_KEY"`
-	DBHost       string        `mapstructure:"DB_HOST"`
-	DBPort       string        `mapstructure:"DB_PORT"`
-	DBUser       string        `mapstructure:"DB_USER"`
-	DBPass       string        `mapstructure:"DB_PASS"`
-	DBName       string        `mapstructure:"DB_NAME"`
-	JwtSecretKey string        `mapstructure:"JWT_SECRET_KEY"`
-	JwtExpTime   time.Duration `mapstructure:"JWT_EXP_TIME"`
-	GoogleAPIKey string        `mapstructure:"GOOGLE_API_KEY"`
-	BotEnabled   bool          `mapstructure:"BOT_ENABLED"`
-	DifyAPIURL   string        `mapstructure:"DIFY_API_URL"`
-	DifyAPIKey   string        `mapstructure:"DIFY_API_KEY"`
+	AppEnv                  string        `mapstructure:"APP_ENV"`
+	AppPort                 string        `mapstructure:"APP_PORT"`
+	DBHost                  string        `mapstructure:"DB_HOST"`
+	DBPort                  string        `mapstructure:"DB_PORT"`
+	DBUser                  string        `mapstructure:"DB_USER"`
+	DBPass                  string        `mapstructure:"DB_PASS"`
+	DBName                  string        `mapstructure:"DB_NAME"`
+	JwtSecretKey            string        `mapstructure:"JWT_SECRET_KEY"`
+	JwtExpTime              time.Duration `mapstructure:"JWT_EXP_TIME"`
+	JwtRefreshExpTime       time.Duration `mapstructure:"JWT_REFRESH_EXP_TIME"`
+	GoogleAPIKey            string        `mapstructure:"GOOGLE_API_KEY"`
+	BotEnabled              bool          `mapstructure:"BOT_ENABLED"`
+	DifyAPIURL              string        `mapstructure:"DIFY_API_URL"`
+	DifyAPIKey              string        `mapstructure:"DIFY_API_KEY"`
+	DifyTimeout             time.Duration `mapstructure:"DIFY_TIMEOUT"`
+	DifyStreamTimeout       time.Duration `mapstructure:"DIFY_STREAM_TIMEOUT"`
+	DifyMaxRetries          int           `mapstructure:"DIFY_MAX_RETRIES"`
+	DifyRetryBaseDelay      time.Duration `mapstructure:"DIFY_RETRY_BASE_DELAY"`
+	DifyRetryMaxDelay       time.Duration `mapstructure:"DIFY_RETRY_MAX_DELAY"`
+	DifyBreakerThreshold    int           `mapstructure:"DIFY_BREAKER_THRESHOLD"`
+	DifyBreakerCooldown     time.Duration `mapstructure:"DIFY_BREAKER_COOLDOWN"`
+	AnswerProviders         string        `mapstructure:"ANSWER_PROVIDERS"`
+	TopicExtractionEnabled  bool          `mapstructure:"TOPIC_EXTRACTION_ENABLED"`
+	TopicExtractionInterval time.Duration `mapstructure:"TOPIC_EXTRACTION_INTERVAL"`
 }
 
 var AppEnv = getEnv()
@@ -1,21 +1,38 @@
 package server
 
 import (
+	apikeycontroller "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/apikey/controller"
+	apikeyrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/apikey/repository"
+	apikeyservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/apikey/service"
+	auditcontroller "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/controller"
+	auditrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/repository"
+	auditservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service"
+	authcontroller "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/auth/controller"
+	authrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/auth/repository"
+	authservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/auth/service"
+	conversationcontroller "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/controller"
+	conversationrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/repository"
+	conversationservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/service"
 	feedbackcontroller "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/feedback/controller"
 	feedbackrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/feedback/repository"
 	feedbackservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/feedback/service"
+	importjobcontroller "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/controller"
+	importjobrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/repository"
+	importjobservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/service"
 	topiccontroller "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/controller"
 	topicrepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/repository"
 	topicservice "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/service"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/controller"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/repository"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/env"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/middlewares"
-	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/csv"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/bcrypt"
 	errorhandler "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/error_handler"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/helpers/http/response"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/jwt"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
 	"github.com/bytedance/sonic"
@@ -78,12 +95,22 @@ func (s *httpServer) MountMiddlewares() {
 }
 
 func (s *httpServer) MountRoutes(db *sqlx.DB) {
-	jwtService := jwt.Jwt
+	jwtService := jwt.NewJwt(env.AppEnv.JwtSecretKey, env.AppEnv.JwtExpTime)
 	validatorService := validator.Validator
 	uuidService := uuid.UUID
-	csv := csv.CSV
+	tabular := tabular.Tabular
+	bcryptService := bcrypt.Bcrypt
 
-	middleware := middlewares.NewMiddleware(jwtService)
+	auditRepo := auditrepository.NewAuditRepository(db)
+	auditService := auditservice.NewAuditService(auditRepo, validatorService, uuidService)
+
+	apiKeyRepo := apikeyrepository.NewAPIKeyRepository(db)
+	apiKeyService := apikeyservice.NewAPIKeyService(apiKeyRepo, validatorService, uuidService, auditService)
+
+	authRepo := authrepository.NewAuthRepository(db)
+	authService := authservice.NewAuthService(authRepo, validatorService, uuidService, jwtService, bcryptService, env.AppEnv.JwtRefreshExpTime, auditService)
+
+	middleware := middlewares.NewMiddleware(jwtService, apiKeyService, authService)
 
 	s.app.Get("/", func(c *fiber.Ctx) error {
 		return response.SendResponse(c, fiber.StatusOK, "HC PPN Backend is running")
@@ -96,16 +123,31 @@ func (s *httpServer) MountRoutes(db *sqlx.DB) {
 		return response.SendResponse(c, fiber.StatusOK, "HC PPN Backend is running")
 	})
 
+	authcontroller.InitAuthController(v1, authService, middleware)
+
+	apikeycontroller.InitAPIKeyController(v1, apiKeyService, middleware)
+
+	auditcontroller.InitAuditController(v1, auditService, middleware)
+
+	importJobRepo := importjobrepository.NewImportJobRepository(db)
+
 	userRepo := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepo, validatorService, uuidService, csv)
+	userService := service.NewUserService(userRepo, importJobRepo, validatorService, uuidService, tabular, auditService)
 	controller.InitUserController(v1, userService, middleware)
 
+	importJobService := importjobservice.NewImportJobService(importJobRepo, userService, validatorService, uuidService)
+	importjobcontroller.InitImportJobController(v1, importJobService, middleware)
+
+	conversationRepo := conversationrepository.NewConversationRepository(db)
+	conversationService := conversationservice.NewConversationService(conversationRepo, validatorService, uuidService)
+	conversationcontroller.InitConversationController(v1, conversationService, middleware)
+
 	feedbackRepo := feedbackrepository.NewFeedbackRepository(db)
-	feedbackService := feedbackservice.NewFeedbackService(feedbackRepo, validatorService, uuidService)
+	feedbackService := feedbackservice.NewFeedbackService(feedbackRepo, conversationService, validatorService, uuidService, tabular)
 	feedbackcontroller.InitFeedbackController(v1, feedbackService, middleware)
 
 	topicRepo := topicrepository.NewTopicRepository(db)
-	topicService := topicservice.NewTopicService(topicRepo, validatorService)
+	topicService := topicservice.NewTopicService(topicRepo, validatorService, uuidService, auditService)
 	topiccontroller.InitTopicController(v1, topicService, middleware)
 
 	s.app.Use(func(c *fiber.Ctx) error {
@@ -2,6 +2,7 @@ package whatsapp
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -9,6 +10,8 @@ import (
 	"time"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/answer"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/dify"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/phoneutil"
@@ -59,7 +62,15 @@ func (s *WhatsAppBot) handleMessage(msg *events.Message) {
 		"quotedMsg": quotedMsg,
 	}, "[WhatsAppBot] Received WhatsApp message")
 
-	session := s.getSession(phoneNumber)
+	session, err := s.getSession(phoneNumber)
+	if err != nil {
+		// Starting over would overwrite the stored session, losing a pending
+		// rating or comment and the Dify conversation
+		s.clientLog.Errorf("Failed to load session for %s: %v", phoneNumber, err)
+		s.sendMessage(chatJID, "Maaf, saya tidak dapat memproses pesan Anda saat ini. Silakan coba lagi nanti.")
+		return
+	}
+
 	if session == nil {
 		userRes, err := s.userSvc.GetByPhoneNumber(s.ctx, &dto.GetUserByPhoneNumberParam{
 			PhoneNumber: phoneNumber,
@@ -79,6 +90,7 @@ func (s *WhatsAppBot) handleMessage(msg *events.Message) {
 		}, "[WhatsAppBot] Starting new session for authorized phone number")
 
 		session = s.createSession(phoneNumber, &chatJID, &userRes.User)
+		s.recordMessage(session, entity.MessageRoleUser, entity.MessageKindText, text, &msg.Info.ID)
 
 		// Mark message as read (blue ticks) before welcoming
 		s.markMessageAsRead(msg)
@@ -86,10 +98,13 @@ func (s *WhatsAppBot) handleMessage(msg *events.Message) {
 		greeting := getTimeBasedGreeting(getJakartaTime())
 		salutation := getSalutation(userRes.User.Gender)
 		welcomeMessage := fmt.Sprintf("Halo, %s %s %s 👋\nSaya DIGDAYA (Digital Guide for Development & Your Acceleration), teman digital Anda di HC PPN Regional Jatimbalinus.\nButuh info seputar pengelolaan SDM, coaching, learning atau yang lainnya?\nSampaikan saja, saya siap membantu %s.", greeting, salutation, userRes.User.Name, salutation)
+		s.recordMessage(session, entity.MessageRoleSystem, entity.MessageKindWelcome, welcomeMessage, nil)
 		s.sendMessage(chatJID, welcomeMessage)
 		return
 	}
 
+	s.recordMessage(session, entity.MessageRoleUser, entity.MessageKindText, text, &msg.Info.ID)
+
 	if session.WaitingForRating {
 		s.handleRatingInput(msg, text, session)
 		return
@@ -101,7 +116,7 @@ func (s *WhatsAppBot) handleMessage(msg *events.Message) {
 	}
 
 	if strings.ToLower(strings.TrimSpace(text)) == "/help" {
-		s.handleHelpCommand(msg)
+		s.handleHelpCommand(msg, session)
 		return
 	}
 
@@ -119,7 +134,7 @@ func (s *WhatsAppBot) handleMessage(msg *events.Message) {
 		timeSinceLastMsg := time.Since(lastMsgTime)
 		if timeSinceLastMsg < 3*time.Second {
 			s.sessionsMux.Unlock()
-			s.sendReply(msg, "Mohon tunggu sebentar sebelum mengirim pesan berikutnya 🙏")
+			s.replyAndRecord(msg, session, entity.MessageKindRateLimited, "Mohon tunggu sebentar sebelum mengirim pesan berikutnya 🙏")
 			return
 		}
 	}
@@ -128,12 +143,12 @@ func (s *WhatsAppBot) handleMessage(msg *events.Message) {
 	const maxMessagesInWindow = 20
 	const windowDuration = 10 * time.Minute
 
-	now := time.Now()
+	now := time.Now().UTC()
 	session.MessageHistory = filterRecentMessages(session.MessageHistory, now, windowDuration)
 
 	if len(session.MessageHistory) >= maxMessagesInWindow {
 		s.sessionsMux.Unlock()
-		s.sendReply(msg, "Anda telah mencapai batas maksimal pesan (20 pesan per 10 menit). Mohon tunggu beberapa saat 🙏")
+		s.replyAndRecord(msg, session, entity.MessageKindRateLimited, "Anda telah mencapai batas maksimal pesan (20 pesan per 10 menit). Mohon tunggu beberapa saat 🙏")
 		return
 	}
 
@@ -151,41 +166,60 @@ func (s *WhatsAppBot) handleMessage(msg *events.Message) {
 
 	s.updateSessionActivity(phoneNumber)
 
-	difyReq := &dify.Request{
+	answerReq := &answer.Request{
 		Inputs: map[string]any{
 			"user": session.User,
 		},
 		Query:          text,
-		ResponseMode:   "blocking",
 		ConversationID: session.ConversationID,
 		User:           phoneNumber,
-		Files:          []any{},
 	}
 
 	log.Debug(log.CustomLogInfo{
-		"difyReq": difyReq,
-	}, "[WhatsAppBot] Sending message to Dify AI")
-
-	difyResp, err := s.difySvc.ChatMessages(s.ctx, difyReq)
-	if err != nil {
-		s.clientLog.Errorf("Failed to get response from Dify AI: %v", err)
-		s.sendReply(msg, "Maaf, saya tidak dapat memproses pesan Anda saat ini. Silakan coba lagi nanti.")
-		return
-	}
+		"answerReq": answerReq,
+	}, "[WhatsAppBot] Requesting answer")
 
-	log.Debug(log.CustomLogInfo{
-		"difyResp": difyResp,
-	}, "[WhatsAppBot] Received response from Dify AI")
-
-	if difyResp.ConversationID != "" {
+	res, err := s.streamAnswer(msg, answerReq)
+	if res != nil && res.ConversationID != "" {
 		s.sessionsMux.Lock()
-		if session.ConversationID == "" {
-			session.ConversationID = difyResp.ConversationID
+		isNewConversation := session.ConversationID == ""
+		if isNewConversation {
+			session.ConversationID = res.ConversationID
 		}
 		s.sessionsMux.Unlock()
+
+		if isNewConversation {
+			s.saveSession(session)
+			s.setTranscriptDifyConversationID(session, res.ConversationID)
+		}
+	}
+
+	// A partial answer is kept in the transcript even if the provider failed
+	// before finishing it
+	if res != nil && res.Text != "" {
+		s.recordAnswer(session, res)
+	}
+
+	if errors.Is(err, dify.ErrCircuitOpen) {
+		// Dify has been failing and no fallback could answer, so tell the user
+		// right away instead of simulating typing for an answer that won't come
+		busyMessage := "Maaf, layanan kami sedang sibuk. Silakan coba lagi dalam beberapa saat. 🙏"
+		s.recordMessage(session, entity.MessageRoleSystem, entity.MessageKindServiceBusy, busyMessage, nil)
+		s.deliverReply(msg, busyMessage)
+		return
+	}
+
+	if err != nil {
+		s.clientLog.Errorf("Failed to get an answer: %v", err)
+		s.replyAndRecord(msg, session, entity.MessageKindError, "Maaf, saya tidak dapat memproses pesan Anda saat ini. Silakan coba lagi nanti.")
+		return
 	}
 
-	s.sendReply(msg, difyResp.Answer)
+	log.Debug(log.CustomLogInfo{
+		"provider":       res.Provider,
+		"conversationID": res.ConversationID,
+		"answerLength":   len(res.Text),
+	}, "[WhatsAppBot] Received answer")
 }
 
 func (s *WhatsAppBot) handleEndSession(msg *events.Message, session *Session) {
@@ -193,6 +227,8 @@ func (s *WhatsAppBot) handleEndSession(msg *events.Message, session *Session) {
 	session.WaitingForRating = true
 	s.sessionsMux.Unlock()
 
+	s.saveSession(session)
+
 	salutation := getSalutation(nil)
 	if session.User != nil {
 		salutation = getSalutation(session.User.Gender)
@@ -200,13 +236,13 @@ func (s *WhatsAppBot) handleEndSession(msg *events.Message, session *Session) {
 
 	ratingMessage := fmt.Sprintf("*[Langkah 1/2]* ⭐\n\nTerima kasih telah menggunakan layanan kami! 🙏\n\nMohon kesediaan %s untuk memberikan feedback terhadap kualitas pelayanan kami dengan rating 1-5.\n\nAdapun 3 poin penilaian sebagai berikut:\n1. Kecepatan dalam merespon pertanyaan/keluhan\n2. Kualitas komunikasi dan informasi yang diberikan\n3. Ketepatan dan kegunaan solusi yang diberikan\n\nSilakan berikan rating Anda (1-5):\n\n*Skala Penilaian:*\n1 = Sangat Tidak Memuaskan\n2 = Tidak Memuaskan\n3 = Cukup Memuaskan\n4 = Memuaskan\n5 = Sangat Memuaskan", salutation)
 
-	s.sendReply(msg, ratingMessage)
+	s.replyAndRecord(msg, session, entity.MessageKindRatingRequest, ratingMessage)
 }
 
 func (s *WhatsAppBot) handleRatingInput(msg *events.Message, text string, session *Session) {
 	rating, err := strconv.Atoi(strings.TrimSpace(text))
 	if err != nil || rating < 1 || rating > 5 {
-		s.sendReply(msg, "Mohon maaf, rating harus berupa angka dari 1 sampai 5 ya 😊\n\n*Contoh:* ketik angka *3* untuk rating 3 bintang\n\nSilakan coba lagi:")
+		s.replyAndRecord(msg, session, entity.MessageKindInvalidRating, "Mohon maaf, rating harus berupa angka dari 1 sampai 5 ya 😊\n\n*Contoh:* ketik angka *3* untuk rating 3 bintang\n\nSilakan coba lagi:")
 		return
 	}
 
@@ -216,7 +252,9 @@ func (s *WhatsAppBot) handleRatingInput(msg *events.Message, text string, sessio
 	session.WaitingForComment = true
 	s.sessionsMux.Unlock()
 
-	s.sendReply(msg, getRatingConfirmationMessage(rating))
+	s.saveSession(session)
+
+	s.replyAndRecord(msg, session, entity.MessageKindRatingConfirmation, getRatingConfirmationMessage(rating))
 }
 
 func (s *WhatsAppBot) handleCommentInput(msg *events.Message, phoneNumber string, text string, session *Session) {
@@ -241,20 +279,27 @@ func (s *WhatsAppBot) handleCommentInput(msg *events.Message, phoneNumber string
 		comment = &trimmedText
 	}
 
+	conversationID, difyConversationID := s.feedbackConversationRefs(session)
+
 	feedbackRes, err := s.feedbackSvc.Create(ctx, &dto.CreateFeedbackRequest{
-		UserID:  userRes.User.ID,
-		Rating:  session.Rating,
-		Comment: comment,
+		UserID:             userRes.User.ID,
+		ConversationID:     conversationID,
+		DifyConversationID: difyConversationID,
+		Rating:             session.Rating,
+		Comment:            comment,
 	})
 	if err != nil {
 		s.clientLog.Errorf("Failed to save feedback: %v", err)
-		s.sendReply(msg, "Maaf, terjadi kesalahan saat menyimpan feedback Anda. Silakan coba lagi nanti.")
+		s.replyAndRecord(msg, session, entity.MessageKindError, "Maaf, terjadi kesalahan saat menyimpan feedback Anda. Silakan coba lagi nanti.")
 		return
 	}
 
+	goodbyeMessage := getGoodbyeMessage(session.Rating, comment != nil)
+	s.recordMessage(session, entity.MessageRoleSystem, entity.MessageKindGoodbye, goodbyeMessage, nil)
+
 	s.deleteSession(phoneNumber)
 
-	s.sendReply(msg, getGoodbyeMessage(session.Rating, comment != nil))
+	s.sendReply(msg, goodbyeMessage)
 
 	log.Info(log.CustomLogInfo{
 		"phone_number": phoneNumber,
@@ -311,6 +356,11 @@ func (s *WhatsAppBot) sendReply(msg *events.Message, text string) {
 	// Simulate typing before sending the reply
 	s.simulateTyping(msg.Info.Chat, text)
 
+	s.deliverReply(msg, text)
+}
+
+// deliverReply sends text quoting msg, without marking it read or simulating typing
+func (s *WhatsAppBot) deliverReply(msg *events.Message, text string) {
 	_, err := s.client.SendMessage(s.ctx, msg.Info.Chat, &waE2E.Message{
 		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
 			Text: proto.String(text),
@@ -326,10 +376,21 @@ func (s *WhatsAppBot) sendReply(msg *events.Message, text string) {
 	}
 }
 
+// replyAndRecord replies with a system message and records it in the session's transcript
+func (s *WhatsAppBot) replyAndRecord(msg *events.Message, session *Session, kind string, text string) {
+	s.recordMessage(session, entity.MessageRoleSystem, kind, text, nil)
+	s.sendReply(msg, text)
+}
+
 func (s *WhatsAppBot) sendMessage(to types.JID, text string) {
 	// Simulate typing before sending the message
 	s.simulateTyping(to, text)
 
+	s.deliverMessage(to, text)
+}
+
+// deliverMessage sends text to the chat without simulating typing
+func (s *WhatsAppBot) deliverMessage(to types.JID, text string) {
 	_, err := s.client.SendMessage(s.ctx, to, &waE2E.Message{
 		ExtendedTextMessage: &waE2E.ExtendedTextMessage{
 			Text: proto.String(text),
@@ -388,13 +449,14 @@ func getGoodbyeMessage(rating int, hasComment bool) string {
 	return message
 }
 
-func (s *WhatsAppBot) handleHelpCommand(msg *events.Message) {
+func (s *WhatsAppBot) handleHelpCommand(msg *events.Message, session *Session) {
 	helpMessage := "📖 *Panduan Penggunaan Bot*\n\nSaya adalah asisten virtual yang siap membantu Anda 🤖\n\n*Command yang tersedia:*\n• /help - Menampilkan panduan ini\n• /selesai - Mengakhiri sesi dan memberikan feedback\n\nAnda bisa mengirim pertanyaan kapan saja, dan saya akan membantu menjawabnya! 💬"
-	s.sendReply(msg, helpMessage)
+	s.replyAndRecord(msg, session, entity.MessageKindHelp, helpMessage)
 }
 
-func (s *WhatsAppBot) autoSubmitFeedback(phoneNumber string, chatJID types.JID) {
+func (s *WhatsAppBot) autoSubmitFeedback(session *Session) {
 	ctx := context.Background()
+	phoneNumber := session.PhoneNumber
 
 	userRes, err := s.userSvc.GetByPhoneNumber(ctx, &dto.GetUserByPhoneNumberParam{
 		PhoneNumber: phoneNumber,
@@ -407,11 +469,16 @@ func (s *WhatsAppBot) autoSubmitFeedback(phoneNumber string, chatJID types.JID)
 		return
 	}
 
+	conversationID, difyConversationID := s.feedbackConversationRefs(session)
+
 	rating := 5
 	_, err = s.feedbackSvc.Create(ctx, &dto.CreateFeedbackRequest{
-		UserID:  userRes.User.ID,
-		Rating:  rating,
-		Comment: nil,
+		UserID:             userRes.User.ID,
+		ConversationID:     conversationID,
+		DifyConversationID: difyConversationID,
+		Rating:             rating,
+		Comment:            nil,
+		Source:             entity.FeedbackSourceAuto,
 	})
 	if err != nil {
 		s.clientLog.Errorf("Failed to auto-submit feedback: %v", err)
@@ -419,7 +486,8 @@ func (s *WhatsAppBot) autoSubmitFeedback(phoneNumber string, chatJID types.JID)
 	}
 
 	confirmationMessage := "Terima kasih! ✨\n\nKarena tidak ada respons, kami mencatat feedback Anda dengan rating 5 bintang ⭐\n\nKami menghargai waktu Anda dan berharap layanan kami memuaskan. Sampai jumpa lagi! 👋"
-	s.sendMessage(chatJID, confirmationMessage)
+	s.recordMessage(session, entity.MessageRoleSystem, entity.MessageKindAutoFeedback, confirmationMessage, nil)
+	s.sendMessage(*session.ChatJID, confirmationMessage)
 
 	log.Info(log.CustomLogInfo{
 		"phone_number": phoneNumber,
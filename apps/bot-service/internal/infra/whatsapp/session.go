@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
 	"go.mau.fi/whatsmeow/types"
 )
 
@@ -78,6 +79,7 @@ type sessionAction struct {
 	phoneNumber string
 	chatJID     types.JID
 	message     string // only for send_prompt
+	session     *Session
 }
 
 func (s *WhatsAppBot) processExpiredSessions() {
@@ -85,7 +87,7 @@ func (s *WhatsAppBot) processExpiredSessions() {
 	var actions []sessionAction
 
 	s.sessionsMux.Lock()
-	now := time.Now()
+	now := time.Now().UTC()
 	for phoneNumber, session := range s.sessions {
 		if session.WaitingForRating || session.WaitingForComment {
 			continue
@@ -109,6 +111,7 @@ func (s *WhatsAppBot) processExpiredSessions() {
 				phoneNumber: phoneNumber,
 				chatJID:     *session.ChatJID,
 				message:     feedbackMessage,
+				session:     session,
 			})
 		}
 
@@ -120,6 +123,7 @@ func (s *WhatsAppBot) processExpiredSessions() {
 						actionType:  "auto_submit",
 						phoneNumber: phoneNumber,
 						chatJID:     *session.ChatJID,
+						session:     session,
 					})
 				} else {
 					actions = append(actions, sessionAction{
@@ -138,11 +142,13 @@ func (s *WhatsAppBot) processExpiredSessions() {
 		switch action.actionType {
 		case "send_prompt":
 			s.clientLog.Infof("Sending feedback prompt to %s due to inactivity", action.phoneNumber)
+			s.saveSession(action.session)
+			s.recordMessage(action.session, entity.MessageRoleSystem, entity.MessageKindFeedbackPrompt, action.message, nil)
 			s.sendMessage(action.chatJID, action.message)
 
 		case "auto_submit":
 			s.clientLog.Infof("Auto-submitting feedback rating 5 for %s due to no response", action.phoneNumber)
-			s.autoSubmitFeedback(action.phoneNumber, action.chatJID)
+			s.autoSubmitFeedback(action.session)
 			sessionsToDelete = append(sessionsToDelete, action.phoneNumber)
 
 		case "auto_close":
@@ -151,52 +157,121 @@ func (s *WhatsAppBot) processExpiredSessions() {
 		}
 	}
 
-	// Delete sessions while holding lock
-	if len(sessionsToDelete) > 0 {
-		s.sessionsMux.Lock()
-		for _, phoneNumber := range sessionsToDelete {
-			delete(s.sessions, phoneNumber)
-		}
-		s.sessionsMux.Unlock()
+	for _, phoneNumber := range sessionsToDelete {
+		s.deleteSession(phoneNumber)
 	}
 }
 
-func (s *WhatsAppBot) getSession(phoneNumber string) *Session {
-	s.sessionsMux.RLock()
-	defer s.sessionsMux.RUnlock()
+// restoreSessions loads persisted sessions into memory so that conversations
+// and pending feedback steps carry on after a restart.
+func (s *WhatsAppBot) restoreSessions(ctx context.Context) error {
+	sessions, err := s.sessionStore.List(ctx)
+	if err != nil {
+		return err
+	}
 
-	return s.sessions[phoneNumber]
+	s.sessionsMux.Lock()
+	for _, session := range sessions {
+		s.sessions[session.PhoneNumber] = session
+	}
+	s.sessionsMux.Unlock()
+
+	s.clientLog.Infof("Restored %d WhatsApp bot session(s)", len(sessions))
+
+	return nil
 }
 
-func (s *WhatsAppBot) createSession(phoneNumber string, chatJID *types.JID, user *dto.UserResponse) *Session {
+// getSession returns the session for phoneNumber, loading it from the store
+// when it isn't in memory. It returns nil without an error when there is none,
+// so a failed lookup isn't mistaken for a new conversation.
+func (s *WhatsAppBot) getSession(phoneNumber string) (*Session, error) {
+	s.sessionsMux.RLock()
+	session, exists := s.sessions[phoneNumber]
+	s.sessionsMux.RUnlock()
+
+	if exists {
+		return session, nil
+	}
+
+	session, err := s.sessionStore.Get(s.ctx, phoneNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if session == nil {
+		return nil, nil
+	}
+
 	s.sessionsMux.Lock()
 	defer s.sessionsMux.Unlock()
 
+	// Another goroutine may have loaded the session in the meantime
+	if existing, exists := s.sessions[phoneNumber]; exists {
+		return existing, nil
+	}
+	s.sessions[phoneNumber] = session
+
+	return session, nil
+}
+
+func (s *WhatsAppBot) createSession(phoneNumber string, chatJID *types.JID, user *dto.UserResponse) *Session {
 	session := &Session{
 		PhoneNumber:   phoneNumber,
-		LastMessageAt: time.Now(),
+		LastMessageAt: time.Now().UTC(),
 		ChatJID:       chatJID,
 		User:          user,
 	}
+
+	s.sessionsMux.Lock()
 	s.sessions[phoneNumber] = session
+	s.sessionsMux.Unlock()
+
+	// Starting the transcript also persists the session
+	if s.ensureTranscript(session) == "" {
+		s.saveSession(session)
+	}
 
 	return session
 }
 
 func (s *WhatsAppBot) updateSessionActivity(phoneNumber string) {
 	s.sessionsMux.Lock()
-	defer s.sessionsMux.Unlock()
+	session, exists := s.sessions[phoneNumber]
+	if exists {
+		session.LastMessageAt = time.Now().UTC()
+	}
+	s.sessionsMux.Unlock()
 
-	if session, exists := s.sessions[phoneNumber]; exists {
-		session.LastMessageAt = time.Now()
+	if exists {
+		s.saveSession(session)
+	}
+}
+
+// saveSession writes a snapshot of the session to the store. Failures are
+// logged rather than returned so a database hiccup doesn't interrupt the chat.
+func (s *WhatsAppBot) saveSession(session *Session) {
+	s.sessionsMux.RLock()
+	snapshot := *session
+	s.sessionsMux.RUnlock()
+
+	if err := s.sessionStore.Save(s.ctx, &snapshot); err != nil {
+		s.clientLog.Errorf("Failed to save session for %s: %v", snapshot.PhoneNumber, err)
 	}
 }
 
 func (s *WhatsAppBot) deleteSession(phoneNumber string) {
 	s.sessionsMux.Lock()
-	defer s.sessionsMux.Unlock()
-
+	session, exists := s.sessions[phoneNumber]
 	delete(s.sessions, phoneNumber)
+	s.sessionsMux.Unlock()
+
+	if exists {
+		s.endTranscript(session)
+	}
+
+	if err := s.sessionStore.Delete(s.ctx, phoneNumber); err != nil {
+		s.clientLog.Errorf("Failed to delete session for %s: %v", phoneNumber, err)
+	}
 }
 
 // filterRecentMessages removes messages outside the specified time window
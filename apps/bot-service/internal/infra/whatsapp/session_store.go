@@ -0,0 +1,229 @@
+package whatsapp
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/jmoiron/sqlx"
+	"go.mau.fi/whatsmeow/types"
+)
+
+// SessionStore persists bot sessions so that conversations and pending
+// feedback steps survive restarts.
+type SessionStore interface {
+	// Get returns the session for the phone number, or nil if there is none.
+	Get(ctx context.Context, phoneNumber string) (*Session, error)
+	List(ctx context.Context) ([]*Session, error)
+	Save(ctx context.Context, session *Session) error
+	Delete(ctx context.Context, phoneNumber string) error
+}
+
+type postgresSessionStore struct {
+	db *sqlx.DB
+}
+
+func NewPostgresSessionStore(db *sqlx.DB) SessionStore {
+	return &postgresSessionStore{db: db}
+}
+
+// sessionRow is the bot_sessions row joined with its user. MessageHistory is
+// only used for rate limiting and is intentionally not persisted.
+type sessionRow struct {
+	PhoneNumber          string     `db:"phone_number"`
+	UserID               string     `db:"user_id"`
+	ChatJID              string     `db:"chat_jid"`
+	ConversationID       string     `db:"conversation_id"`
+	TranscriptID         *string    `db:"transcript_id"`
+	LastMessageAt        time.Time  `db:"last_message_at"`
+	WaitingForRating     bool       `db:"waiting_for_rating"`
+	WaitingForComment    bool       `db:"waiting_for_comment"`
+	Rating               int        `db:"rating"`
+	FeedbackPromptSent   bool       `db:"feedback_prompt_sent"`
+	FeedbackPromptSentAt *time.Time `db:"feedback_prompt_sent_at"`
+	IsAutoPrompt         bool       `db:"is_auto_prompt"`
+	UpdatedAt            time.Time  `db:"updated_at"`
+
+	User entity.User `db:"user"`
+}
+
+const selectSessionQuery = `
+	SELECT
+		bot_sessions.phone_number,
+		bot_sessions.user_id,
+		bot_sessions.chat_jid,
+		bot_sessions.conversation_id,
+		bot_sessions.transcript_id,
+		bot_sessions.last_message_at,
+		bot_sessions.waiting_for_rating,
+		bot_sessions.waiting_for_comment,
+		bot_sessions.rating,
+		bot_sessions.feedback_prompt_sent,
+		bot_sessions.feedback_prompt_sent_at,
+		bot_sessions.is_auto_prompt,
+		bot_sessions.updated_at,
+
+		users.id AS "user.id",
+		users.phone_number AS "user.phone_number",
+		users.name AS "user.name",
+		users.job_title AS "user.job_title",
+		users.gender AS "user.gender",
+		users.date_of_birth AS "user.date_of_birth",
+		users.created_at AS "user.created_at",
+		users.updated_at AS "user.updated_at"
+	FROM bot_sessions
+	INNER JOIN users ON bot_sessions.user_id = users.id
+`
+
+func (r *postgresSessionStore) Get(ctx context.Context, phoneNumber string) (*Session, error) {
+	var row sessionRow
+	err := r.db.GetContext(ctx, &row, selectSessionQuery+" WHERE bot_sessions.phone_number = $1", phoneNumber)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, errx.ErrInternalServer.WithLocation("postgresSessionStore.Get").WithError(err)
+	}
+
+	session, err := row.toSession()
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("postgresSessionStore.Get").WithError(err)
+	}
+
+	return session, nil
+}
+
+func (r *postgresSessionStore) List(ctx context.Context) ([]*Session, error) {
+	var rows []sessionRow
+	err := r.db.SelectContext(ctx, &rows, selectSessionQuery+" ORDER BY bot_sessions.last_message_at ASC")
+	if err != nil {
+		return nil, errx.ErrInternalServer.WithLocation("postgresSessionStore.List").WithError(err)
+	}
+
+	sessions := make([]*Session, 0, len(rows))
+	for i := range rows {
+		session, err := rows[i].toSession()
+		if err != nil {
+			return nil, errx.ErrInternalServer.WithDetails(map[string]any{
+				"phone_number": rows[i].PhoneNumber,
+			}).WithLocation("postgresSessionStore.List").WithError(err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (r *postgresSessionStore) Save(ctx context.Context, session *Session) error {
+	if session.User == nil || session.ChatJID == nil {
+		return errx.ErrInternalServer.WithDetails(map[string]any{
+			"phone_number": session.PhoneNumber,
+		}).WithLocation("postgresSessionStore.Save").WithError(errors.New("session has no user or chat JID"))
+	}
+
+	query := `
+		INSERT INTO bot_sessions (
+			phone_number, user_id, chat_jid, conversation_id, transcript_id, last_message_at,
+			waiting_for_rating, waiting_for_comment, rating,
+			feedback_prompt_sent, feedback_prompt_sent_at, is_auto_prompt,
+			created_at, updated_at
+		)
+		VALUES (
+			:phone_number, :user_id, :chat_jid, :conversation_id, :transcript_id, :last_message_at,
+			:waiting_for_rating, :waiting_for_comment, :rating,
+			:feedback_prompt_sent, :feedback_prompt_sent_at, :is_auto_prompt,
+			:updated_at, :updated_at
+		)
+		ON CONFLICT (phone_number) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			chat_jid = EXCLUDED.chat_jid,
+			conversation_id = EXCLUDED.conversation_id,
+			transcript_id = EXCLUDED.transcript_id,
+			last_message_at = EXCLUDED.last_message_at,
+			waiting_for_rating = EXCLUDED.waiting_for_rating,
+			waiting_for_comment = EXCLUDED.waiting_for_comment,
+			rating = EXCLUDED.rating,
+			feedback_prompt_sent = EXCLUDED.feedback_prompt_sent,
+			feedback_prompt_sent_at = EXCLUDED.feedback_prompt_sent_at,
+			is_auto_prompt = EXCLUDED.is_auto_prompt,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	var transcriptID *string
+	if session.TranscriptID != "" {
+		transcriptID = &session.TranscriptID
+	}
+
+	row := sessionRow{
+		PhoneNumber:          session.PhoneNumber,
+		UserID:               session.User.ID,
+		ChatJID:              session.ChatJID.String(),
+		ConversationID:       session.ConversationID,
+		TranscriptID:         transcriptID,
+		LastMessageAt:        session.LastMessageAt,
+		WaitingForRating:     session.WaitingForRating,
+		WaitingForComment:    session.WaitingForComment,
+		Rating:               session.Rating,
+		FeedbackPromptSent:   session.FeedbackPromptSent,
+		FeedbackPromptSentAt: session.FeedbackPromptSentAt,
+		IsAutoPrompt:         session.IsAutoPrompt,
+		UpdatedAt:            time.Now().UTC(),
+	}
+
+	_, err := r.db.NamedExecContext(ctx, query, row)
+	if err != nil {
+		return errx.ErrInternalServer.WithDetails(map[string]any{
+			"phone_number": session.PhoneNumber,
+		}).WithLocation("postgresSessionStore.Save").WithError(err)
+	}
+
+	return nil
+}
+
+func (r *postgresSessionStore) Delete(ctx context.Context, phoneNumber string) error {
+	query := `DELETE FROM bot_sessions WHERE phone_number = $1`
+
+	_, err := r.db.ExecContext(ctx, query, phoneNumber)
+	if err != nil {
+		return errx.ErrInternalServer.WithDetails(map[string]any{
+			"phone_number": phoneNumber,
+		}).WithLocation("postgresSessionStore.Delete").WithError(err)
+	}
+
+	return nil
+}
+
+func (row *sessionRow) toSession() (*Session, error) {
+	chatJID, err := types.ParseJID(row.ChatJID)
+	if err != nil {
+		return nil, err
+	}
+
+	user := dto.ToUserResponse(&row.User)
+
+	var transcriptID string
+	if row.TranscriptID != nil {
+		transcriptID = *row.TranscriptID
+	}
+
+	return &Session{
+		PhoneNumber:          row.PhoneNumber,
+		ConversationID:       row.ConversationID,
+		TranscriptID:         transcriptID,
+		LastMessageAt:        row.LastMessageAt,
+		WaitingForRating:     row.WaitingForRating,
+		WaitingForComment:    row.WaitingForComment,
+		Rating:               row.Rating,
+		FeedbackPromptSent:   row.FeedbackPromptSent,
+		FeedbackPromptSentAt: row.FeedbackPromptSentAt,
+		IsAutoPrompt:         row.IsAutoPrompt,
+		ChatJID:              &chatJID,
+		User:                 &user,
+	}, nil
+}
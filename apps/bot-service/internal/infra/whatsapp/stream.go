@@ -0,0 +1,83 @@
+package whatsapp
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/answer"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+const (
+	// WhatsApp clears the composing indicator after roughly 10 seconds
+	composingRefreshInterval = 8 * time.Second
+
+	// Once this many characters are buffered, completed paragraphs are sent
+	// right away instead of waiting for the whole answer
+	streamChunkThreshold = 1500
+)
+
+// streamAnswer gets the answer to msg from the answer providers, keeping the
+// composing indicator on while it's generated. Short answers are sent as a
+// single reply; long ones are sent paragraph by paragraph as they arrive. A
+// provider that fails before anything was sent gives way to the next one.
+// Once part of an answer was sent, what was received is sent and returned
+// even on error.
+func (s *WhatsAppBot) streamAnswer(msg *events.Message, req *answer.Request) (*answer.Answer, error) {
+	s.markMessageAsRead(msg)
+
+	stopComposing := s.keepComposing(msg.Info.Chat)
+	defer stopComposing()
+
+	sentChunks := 0
+	out := answer.NewParagraphOutput(streamChunkThreshold, func(text string) {
+		// Only the first part quotes the user's message
+		if sentChunks == 0 {
+			s.deliverReply(msg, text)
+		} else {
+			s.deliverMessage(msg.Info.Chat, text)
+		}
+		sentChunks++
+	})
+
+	res, err := s.answerProvider.Stream(s.ctx, req, out)
+	out.Flush()
+
+	return res, err
+}
+
+// keepComposing shows the composing indicator in the chat until the returned
+// function is called.
+func (s *WhatsAppBot) keepComposing(chatJID types.JID) func() {
+	ctx, cancel := context.WithCancel(s.ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(composingRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.client.SendChatPresence(ctx, chatJID, types.ChatPresenceComposing, types.ChatPresenceMediaText); err != nil {
+				s.clientLog.Warnf("Failed to send composing presence: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+
+		if err := s.client.SendChatPresence(s.ctx, chatJID, types.ChatPresencePaused, types.ChatPresenceMediaText); err != nil {
+			s.clientLog.Warnf("Failed to send paused presence: %v", err)
+		}
+	}
+}
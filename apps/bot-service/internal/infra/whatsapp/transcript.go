@@ -0,0 +1,133 @@
+package whatsapp
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/answer"
+)
+
+// ensureTranscript returns the ID of the conversation recording the session,
+// starting one if the session doesn't have it yet (e.g. sessions restored from
+// before transcripts were recorded).
+func (s *WhatsAppBot) ensureTranscript(session *Session) string {
+	s.sessionsMux.RLock()
+	transcriptID := session.TranscriptID
+	s.sessionsMux.RUnlock()
+
+	if transcriptID != "" {
+		return transcriptID
+	}
+
+	if session.User == nil {
+		return ""
+	}
+
+	res, err := s.conversationSvc.Start(s.ctx, &dto.StartConversationRequest{
+		UserID: session.User.ID,
+	})
+	if err != nil {
+		s.clientLog.Errorf("Failed to start conversation transcript for %s: %v", session.PhoneNumber, err)
+		return ""
+	}
+
+	s.sessionsMux.Lock()
+	if session.TranscriptID == "" {
+		session.TranscriptID = res.ID
+	}
+	transcriptID = session.TranscriptID
+	difyConversationID := session.ConversationID
+	s.sessionsMux.Unlock()
+
+	s.saveSession(session)
+
+	if difyConversationID != "" {
+		s.setTranscriptDifyConversationID(session, difyConversationID)
+	}
+
+	return transcriptID
+}
+
+// recordMessage appends a message to the session's transcript. Failures are
+// logged so that transcript problems never block a reply.
+func (s *WhatsAppBot) recordMessage(session *Session, role, kind, content string, whatsappMessageID *string) {
+	s.record(session, &dto.RecordMessageRequest{
+		Role:              role,
+		Kind:              kind,
+		Content:           content,
+		WhatsAppMessageID: whatsappMessageID,
+	})
+}
+
+// recordAnswer appends an AI answer to the session's transcript along with the
+// provider that produced it.
+func (s *WhatsAppBot) recordAnswer(session *Session, res *answer.Answer) {
+	s.record(session, &dto.RecordMessageRequest{
+		Role:     entity.MessageRoleAssistant,
+		Kind:     entity.MessageKindAnswer,
+		Content:  res.Text,
+		Provider: &res.Provider,
+	})
+}
+
+func (s *WhatsAppBot) record(session *Session, req *dto.RecordMessageRequest) {
+	transcriptID := s.ensureTranscript(session)
+	if transcriptID == "" {
+		return
+	}
+
+	req.ConversationID = transcriptID
+	if err := s.conversationSvc.RecordMessage(s.ctx, req); err != nil {
+		s.clientLog.Errorf("Failed to record %s message for %s: %v", req.Kind, session.PhoneNumber, err)
+	}
+}
+
+func (s *WhatsAppBot) setTranscriptDifyConversationID(session *Session, difyConversationID string) {
+	transcriptID := s.ensureTranscript(session)
+	if transcriptID == "" {
+		return
+	}
+
+	err := s.conversationSvc.SetDifyConversationID(s.ctx, &dto.SetDifyConversationIDRequest{
+		ID:                 transcriptID,
+		DifyConversationID: difyConversationID,
+	})
+	if err != nil {
+		s.clientLog.Errorf("Failed to store Dify conversation ID for %s: %v", session.PhoneNumber, err)
+	}
+}
+
+func (s *WhatsAppBot) endTranscript(session *Session) {
+	s.sessionsMux.RLock()
+	transcriptID := session.TranscriptID
+	s.sessionsMux.RUnlock()
+
+	if transcriptID == "" {
+		return
+	}
+
+	err := s.conversationSvc.End(s.ctx, &dto.EndConversationRequest{
+		ID: transcriptID,
+	})
+	if err != nil {
+		s.clientLog.Errorf("Failed to end conversation transcript for %s: %v", session.PhoneNumber, err)
+	}
+}
+
+// feedbackConversationRefs returns the transcript and Dify conversation IDs
+// that feedback submitted for the session should point back to.
+func (s *WhatsAppBot) feedbackConversationRefs(session *Session) (*string, *string) {
+	var conversationID *string
+	if transcriptID := s.ensureTranscript(session); transcriptID != "" {
+		conversationID = &transcriptID
+	}
+
+	s.sessionsMux.RLock()
+	difyConversationID := session.ConversationID
+	s.sessionsMux.RUnlock()
+
+	if difyConversationID == "" {
+		return conversationID, nil
+	}
+
+	return conversationID, &difyConversationID
+}
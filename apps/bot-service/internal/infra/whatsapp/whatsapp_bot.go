@@ -5,17 +5,26 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	auditRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/repository"
+	auditService "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service"
+	conversationRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/repository"
+	conversationService "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/service"
 	feedbackRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/feedback/repository"
 	feedbackService "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/feedback/service"
+	importJobRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/repository"
 	userRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/repository"
 	userService "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/service"
-	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/csv"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/env"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/answer"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/dify"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/genai"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
 	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
 	"github.com/jmoiron/sqlx"
@@ -33,15 +42,17 @@ var (
 )
 
 type WhatsAppBot struct {
-	ctx         context.Context
-	client      *whatsmeow.Client
-	dbLog       waLog.Logger
-	clientLog   waLog.Logger
-	difySvc     dify.CustomDifyInterface
-	feedbackSvc contracts.FeedbackService
-	userSvc     contracts.UserService
-	sessions    map[string]*Session
-	sessionsMux sync.RWMutex
+	ctx             context.Context
+	client          *whatsmeow.Client
+	dbLog           waLog.Logger
+	clientLog       waLog.Logger
+	answerProvider  *answer.Chain
+	feedbackSvc     contracts.FeedbackService
+	conversationSvc contracts.ConversationService
+	userSvc         contracts.UserService
+	sessionStore    SessionStore
+	sessions        map[string]*Session
+	sessionsMux     sync.RWMutex
 
 	isOfflineSyncing    bool
 	isOfflineSyncingMux sync.RWMutex
@@ -50,6 +61,7 @@ type WhatsAppBot struct {
 type Session struct {
 	PhoneNumber          string
 	ConversationID       string
+	TranscriptID         string // ID of the conversation that records this session's messages
 	LastMessageAt        time.Time
 	WaitingForRating     bool
 	WaitingForComment    bool
@@ -78,36 +90,89 @@ func NewWhatsAppBot(ctx context.Context, db *sql.DB, sqlxDB *sqlx.DB) (*WhatsApp
 
 	validator := validator.Validator
 	uuid := uuid.UUID
-	csv := csv.CSV
+	tabular := tabular.Tabular
 
 	clientLog := waLog.Stdout("Client", "INFO", true)
 	client := whatsmeow.NewClient(deviceStore, clientLog)
 
 	feedbackRepo := feedbackRepository.NewFeedbackRepository(sqlxDB)
 	userRepo := userRepository.NewUserRepository(sqlxDB)
+	conversationRepo := conversationRepository.NewConversationRepository(sqlxDB)
+	auditRepo := auditRepository.NewAuditRepository(sqlxDB)
+	importJobRepo := importJobRepository.NewImportJobRepository(sqlxDB)
 
-	feedbackSvc := feedbackService.NewFeedbackService(feedbackRepo, validator, uuid)
-	userSvc := userService.NewUserService(userRepo, validator, uuid, csv)
+	auditSvc := auditService.NewAuditService(auditRepo, validator, uuid)
+	conversationSvc := conversationService.NewConversationService(conversationRepo, validator, uuid)
+	feedbackSvc := feedbackService.NewFeedbackService(feedbackRepo, conversationSvc, validator, uuid, tabular)
+	userSvc := userService.NewUserService(userRepo, importJobRepo, validator, uuid, tabular, auditSvc)
+
+	answerProvider, err := newAnswerProvider()
+	if err != nil {
+		return nil, err
+	}
 
 	bot := &WhatsAppBot{
-		ctx:         ctx,
-		client:      client,
-		dbLog:       dbLog,
-		clientLog:   clientLog,
-		difySvc:     dify.Dify,
-		feedbackSvc: feedbackSvc,
-		userSvc:     userSvc,
-		sessions:    make(map[string]*Session),
+		ctx:             ctx,
+		client:          client,
+		dbLog:           dbLog,
+		clientLog:       clientLog,
+		answerProvider:  answerProvider,
+		feedbackSvc:     feedbackSvc,
+		conversationSvc: conversationSvc,
+		userSvc:         userSvc,
+		sessionStore:    NewPostgresSessionStore(sqlxDB),
+		sessions:        make(map[string]*Session),
 	}
 
 	return bot, nil
 }
 
+// newAnswerProvider chains the providers named in ANSWER_PROVIDERS, in order.
+// Dify with Gemini as fallback is used when it's not set.
+func newAnswerProvider() (*answer.Chain, error) {
+	names := strings.Split(env.AppEnv.AnswerProviders, ",")
+	if strings.TrimSpace(env.AppEnv.AnswerProviders) == "" {
+		names = []string{answer.ProviderDify, answer.ProviderGemini}
+	}
+
+	providers := make([]answer.Provider, 0, len(names))
+	for _, name := range names {
+		switch strings.TrimSpace(name) {
+		case answer.ProviderDify:
+			providers = append(providers, answer.NewDifyProvider(newDifyClient()))
+		case answer.ProviderGemini:
+			providers = append(providers, answer.NewGeminiProvider(genai.GenAI))
+		default:
+			return nil, fmt.Errorf("unknown answer provider %q in ANSWER_PROVIDERS", name)
+		}
+	}
+
+	return answer.NewChain(providers...), nil
+}
+
+func newDifyClient() dify.CustomDifyInterface {
+	return dify.NewDify(dify.Config{
+		APIURL:           env.AppEnv.DifyAPIURL,
+		APIKey:           env.AppEnv.DifyAPIKey,
+		Timeout:          env.AppEnv.DifyTimeout,
+		StreamTimeout:    env.AppEnv.DifyStreamTimeout,
+		MaxRetries:       env.AppEnv.DifyMaxRetries,
+		RetryBaseDelay:   env.AppEnv.DifyRetryBaseDelay,
+		RetryMaxDelay:    env.AppEnv.DifyRetryMaxDelay,
+		BreakerThreshold: env.AppEnv.DifyBreakerThreshold,
+		BreakerCooldown:  env.AppEnv.DifyBreakerCooldown,
+	})
+}
+
 func (s *WhatsAppBot) Start(ctx context.Context) error {
 	s.clientLog.Infof("Starting WhatsApp bot...")
 
 	s.client.AddEventHandler(s.eventHandler)
 
+	if err := s.restoreSessions(ctx); err != nil {
+		return fmt.Errorf("failed to restore sessions: %w", err)
+	}
+
 	go s.sessionExpiryChecker(ctx)
 
 	if s.client.Store.ID == nil {
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+
+	auditRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/repository"
+	auditService "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/audit/service"
+	importJobRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/repository"
+	importJobService "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/importjob/service"
+	userRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/repository"
+	userService "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/user/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/validator"
+	"github.com/jmoiron/sqlx"
+)
+
+// RunImportJobs processes uploaded import files in the background until ctx
+// is done
+func RunImportJobs(ctx context.Context, db *sqlx.DB) {
+	validator := validator.Validator
+	uuid := uuid.UUID
+	tabular := tabular.Tabular
+
+	auditRepo := auditRepository.NewAuditRepository(db)
+	importJobRepo := importJobRepository.NewImportJobRepository(db)
+	userRepo := userRepository.NewUserRepository(db)
+
+	auditSvc := auditService.NewAuditService(auditRepo, validator, uuid)
+	userSvc := userService.NewUserService(userRepo, importJobRepo, validator, uuid, tabular, auditSvc)
+	importJobSvc := importJobService.NewImportJobService(importJobRepo, userSvc, validator, uuid)
+
+	importJobSvc.Run(ctx)
+}
@@ -0,0 +1,34 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	conversationRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/conversation/repository"
+	topicRepository "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/repository"
+	topicService "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/app/topic/service"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/genai"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/topicclassifier"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const defaultTopicExtractionInterval = 10 * time.Minute
+
+// RunTopicExtraction classifies bot questions into topics with Gemini every
+// interval until ctx is done
+func RunTopicExtraction(ctx context.Context, db *sqlx.DB, interval time.Duration) {
+	if interval <= 0 {
+		interval = defaultTopicExtractionInterval
+	}
+
+	uuid := uuid.UUID
+	classifier := topicclassifier.NewGeminiClassifier(genai.GenAI)
+
+	topicRepo := topicRepository.NewTopicRepository(db)
+	conversationRepo := conversationRepository.NewConversationRepository(db)
+
+	topicExtractionSvc := topicService.NewTopicExtractionService(topicRepo, conversationRepo, classifier, uuid)
+
+	topicExtractionSvc.Run(ctx, interval)
+}
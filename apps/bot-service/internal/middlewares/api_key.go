@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIKeyAuth only lets through requests with an active API key, sent in the
+// X-API-Key header, that has scope.
+func (m *Middleware) APIKeyAuth(scope string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		key := ctx.Get("X-API-Key")
+		if key == "" {
+			return errx.ErrNoAPIKey
+		}
+
+		apiKey, err := m.apiKeySvc.Authenticate(ctx.Context(), key, scope)
+		if err != nil {
+			return err
+		}
+
+		ctx.Locals("api_key", apiKey)
+
+		return ctx.Next()
+	}
+}
+
+// GetAPIKey returns the API key stored by APIKeyAuth.
+func GetAPIKey(ctx *fiber.Ctx) (*entity.APIKey, bool) {
+	apiKey, ok := ctx.Locals("api_key").(*entity.APIKey)
+	return apiKey, ok
+}
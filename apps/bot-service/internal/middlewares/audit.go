@@ -0,0 +1,27 @@
+package middlewares
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetAuditActor returns who is making the request, as stored by RequireAuth or
+// APIKeyAuth, along with where it came from.
+func GetAuditActor(ctx *fiber.Ctx) entity.AuditActor {
+	actor := entity.AuditActor{
+		Type:      entity.AuditActorSystem,
+		IPAddress: ctx.IP(),
+		UserAgent: ctx.Get(fiber.HeaderUserAgent),
+	}
+
+	if claims, ok := GetClaims(ctx); ok {
+		actor.Type = entity.AuditActorAdmin
+		actor.ID = &claims.Subject
+	} else if apiKey, ok := GetAPIKey(ctx); ok {
+		id := apiKey.ID.String()
+		actor.Type = entity.AuditActorAPIKey
+		actor.ID = &id
+	}
+
+	return actor
+}
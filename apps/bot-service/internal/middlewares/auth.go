@@ -17,7 +17,7 @@ func (m *Middleware) OptionalAuth() fiber.Handler {
 		}
 
 		headerSlice := strings.Split(header, " ")
-		if len(headerSlice) != 2 && headerSlice[0] != "Bearer" {
+		if len(headerSlice) != 2 || headerSlice[0] != "Bearer" {
 			return errx.ErrInvalidBearerToken
 		}
 
@@ -60,7 +60,7 @@ func (m *Middleware) RequireAuth() fiber.Handler {
 		}
 
 		headerSlice := strings.Split(header, " ")
-		if len(headerSlice) != 2 && headerSlice[0] != "Bearer" {
+		if len(headerSlice) != 2 || headerSlice[0] != "Bearer" {
 			return errx.ErrInvalidBearerToken
 		}
 
@@ -94,3 +94,31 @@ func (m *Middleware) RequireAuth() fiber.Handler {
 		return ctx.Next()
 	}
 }
+
+// RequireRole only lets through admins that still exist and currently have
+// one of roles; the role in the token may be out of date. It must be mounted
+// after RequireAuth.
+func (m *Middleware) RequireRole(roles ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		claims, ok := GetClaims(ctx)
+		if !ok {
+			return errx.ErrUnauthorized
+		}
+
+		admin, err := m.authSvc.Authorize(ctx.Context(), claims.Subject, roles)
+		if err != nil {
+			return err
+		}
+
+		claims.Role = admin.Role
+		ctx.Locals("claims", claims)
+
+		return ctx.Next()
+	}
+}
+
+// GetClaims returns the claims stored by RequireAuth or OptionalAuth.
+func GetClaims(ctx *fiber.Ctx) (jwt.Claims, bool) {
+	claims, ok := ctx.Locals("claims").(jwt.Claims)
+	return claims, ok
+}
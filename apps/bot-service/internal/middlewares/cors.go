@@ -9,7 +9,7 @@ func Cors() fiber.Handler {
 	config := cors.Config{
 		AllowMethods:  "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
 		AllowHeaders:  "Content-Type,Authorization,X-API-Key,Accept,Origin,X-Requested-With,X-XSRF-Token,X-Cursor,Token-Type",
-		ExposeHeaders: "Content-Length",
+		ExposeHeaders: "Content-Length,Content-Disposition",
 	}
 
 	return cors.New(config)
@@ -1,15 +1,24 @@
 package middlewares
 
-import "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/jwt"
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/contracts"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/jwt"
+)
 
 type Middleware struct {
-	jwt jwt.CustomJwtInterface
+	jwt       jwt.CustomJwtInterface
+	apiKeySvc contracts.APIKeyService
+	authSvc   contracts.AuthService
 }
 
 func NewMiddleware(
 	jwt jwt.CustomJwtInterface,
+	apiKeySvc contracts.APIKeyService,
+	authSvc contracts.AuthService,
 ) *Middleware {
 	return &Middleware{
-		jwt: jwt,
+		jwt:       jwt,
+		apiKeySvc: apiKeySvc,
+		authSvc:   authSvc,
 	}
 }
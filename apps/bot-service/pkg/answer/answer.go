@@ -0,0 +1,126 @@
+package answer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Provider names, as configured in ANSWER_PROVIDERS and recorded with each answer
+const (
+	ProviderDify   = "dify"
+	ProviderGemini = "gemini"
+)
+
+var (
+	ErrNoProviders = errors.New("no answer providers configured")
+	ErrEmptyAnswer = errors.New("provider returned an empty answer")
+)
+
+type Request struct {
+	Query          string
+	ConversationID string // Dify conversation to continue, if any
+	User           string
+	Inputs         map[string]any
+}
+
+type Answer struct {
+	Provider       string
+	Text           string
+	ConversationID string // Set by providers that keep their own conversation state
+}
+
+// Provider answers a user's question. The answer text is passed to onText as
+// it's generated, possibly in several pieces, before Answer returns. When it
+// fails after some text was passed on, the partial answer is returned along
+// with the error.
+type Provider interface {
+	Name() string
+	Answer(ctx context.Context, req *Request, onText func(text string)) (*Answer, error)
+}
+
+// Output receives an answer as it's generated, and may hold some of it back
+// before it reaches the user
+type Output interface {
+	// Write passes on the next piece of the answer
+	Write(text string)
+	// Delivered reports whether any of the answer has reached the user
+	Delivered() bool
+	// Discard drops the text that hasn't reached the user
+	Discard()
+}
+
+// Chain is a Provider that tries providers in order until one answers
+type Chain struct {
+	providers []Provider
+}
+
+// NewChain returns a Chain of providers. It only falls back while none of the
+// answer has reached the user, since they have already seen part of it.
+func NewChain(providers ...Provider) *Chain {
+	return &Chain{providers: providers}
+}
+
+func (c *Chain) Name() string {
+	names := make([]string, 0, len(c.providers))
+	for _, provider := range c.providers {
+		names = append(names, provider.Name())
+	}
+
+	return strings.Join(names, ",")
+}
+
+// Answer passes the answer straight to onText, so it only falls back while
+// nothing has been passed on yet
+func (c *Chain) Answer(ctx context.Context, req *Request, onText func(text string)) (*Answer, error) {
+	return c.Stream(ctx, req, &funcOutput{onText: onText})
+}
+
+// Stream writes the answer to out. When a provider fails before any of its
+// answer was delivered, what out held back is discarded and the next provider
+// tries. A partial answer is only returned when some of it was delivered.
+func (c *Chain) Stream(ctx context.Context, req *Request, out Output) (*Answer, error) {
+	if len(c.providers) == 0 {
+		return nil, ErrNoProviders
+	}
+
+	var errs []error
+	for _, provider := range c.providers {
+		res, err := provider.Answer(ctx, req, out.Write)
+		if err == nil {
+			return res, nil
+		}
+
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+
+		if out.Delivered() {
+			return res, errors.Join(errs...)
+		}
+
+		out.Discard()
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// funcOutput delivers each piece of the answer as it's written
+type funcOutput struct {
+	onText    func(text string)
+	delivered bool
+}
+
+func (o *funcOutput) Write(text string) {
+	o.delivered = true
+	o.onText(text)
+}
+
+func (o *funcOutput) Delivered() bool {
+	return o.delivered
+}
+
+func (o *funcOutput) Discard() {}
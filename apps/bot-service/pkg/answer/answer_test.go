@@ -0,0 +1,200 @@
+package answer
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/dify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	name   string
+	texts  []string
+	err    error
+	called bool
+}
+
+func (p *fakeProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeProvider) Answer(ctx context.Context, req *Request, onText func(text string)) (*Answer, error) {
+	p.called = true
+
+	res := &Answer{Provider: p.name}
+	for _, text := range p.texts {
+		res.Text += text
+		onText(text)
+	}
+
+	if p.err != nil {
+		if res.Text == "" {
+			return nil, p.err
+		}
+		return res, p.err
+	}
+
+	return res, nil
+}
+
+func TestChain_Answer(t *testing.T) {
+	errDown := errors.New("down")
+
+	tests := []struct {
+		name         string
+		providers    []*fakeProvider
+		wantProvider string
+		wantText     string
+		wantCalled   []bool
+		wantErr      error
+	}{
+		{
+			name: "success - first provider answers",
+			providers: []*fakeProvider{
+				{name: ProviderDify, texts: []string{"Halo", "!"}},
+				{name: ProviderGemini, texts: []string{"Hai"}},
+			},
+			wantProvider: ProviderDify,
+			wantText:     "Halo!",
+			wantCalled:   []bool{true, false},
+		},
+		{
+			name: "success - falls back when the first provider fails",
+			providers: []*fakeProvider{
+				{name: ProviderDify, err: errDown},
+				{name: ProviderGemini, texts: []string{"Hai"}},
+			},
+			wantProvider: ProviderGemini,
+			wantText:     "Hai",
+			wantCalled:   []bool{true, true},
+		},
+		{
+			name: "error - no fallback after part of the answer was sent",
+			providers: []*fakeProvider{
+				{name: ProviderDify, texts: []string{"Hal"}, err: errDown},
+				{name: ProviderGemini, texts: []string{"Hai"}},
+			},
+			wantProvider: ProviderDify,
+			wantText:     "Hal",
+			wantCalled:   []bool{true, false},
+			wantErr:      errDown,
+		},
+		{
+			name: "error - every provider fails",
+			providers: []*fakeProvider{
+				{name: ProviderDify, err: errDown},
+				{name: ProviderGemini, err: ErrEmptyAnswer},
+			},
+			wantCalled: []bool{true, true},
+			wantErr:    ErrEmptyAnswer,
+		},
+		{
+			name:    "error - no providers",
+			wantErr: ErrNoProviders,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			providers := make([]Provider, 0, len(tt.providers))
+			for _, provider := range tt.providers {
+				providers = append(providers, provider)
+			}
+
+			var sent string
+			res, err := NewChain(providers...).Answer(context.Background(), &Request{Query: "halo"}, func(text string) {
+				sent += text
+			})
+
+			for i, provider := range tt.providers {
+				assert.Equal(t, tt.wantCalled[i], provider.called, provider.name)
+			}
+
+			assert.Equal(t, tt.wantText, sent)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			if tt.wantProvider == "" {
+				assert.Nil(t, res)
+			} else {
+				assert.Equal(t, tt.wantProvider, res.Provider)
+				assert.Equal(t, tt.wantText, res.Text)
+			}
+		})
+	}
+}
+
+func TestChain_Stream(t *testing.T) {
+	errDown := errors.New("down")
+	// The threshold WhatsApp replies are sent at
+	const threshold = 1500
+	longParagraph := strings.Repeat("a", threshold)
+
+	tests := []struct {
+		name         string
+		difyEvents   []dify.StreamEvent
+		wantProvider string
+		wantText     string
+		wantSent     []string
+		wantGemini   bool
+		wantErr      error
+	}{
+		{
+			name: "success - short answer that failed midway falls back",
+			difyEvents: []dify.StreamEvent{
+				{Event: dify.EventMessage, Answer: "Cuti tahunan bisa"},
+				{Event: dify.EventError, Err: errDown},
+			},
+			wantProvider: ProviderGemini,
+			wantText:     "Hai",
+			wantSent:     []string{"Hai"},
+			wantGemini:   true,
+		},
+		{
+			name: "error - no fallback once a paragraph was sent",
+			difyEvents: []dify.StreamEvent{
+				{Event: dify.EventMessage, Answer: longParagraph + "\n\n"},
+				{Event: dify.EventMessage, Answer: "Sisa"},
+				{Event: dify.EventError, Err: errDown},
+			},
+			wantProvider: ProviderDify,
+			wantText:     longParagraph + "\n\nSisa",
+			// What was received is sent, so the user has what's returned
+			wantSent: []string{longParagraph, "Sisa"},
+			wantErr:  errDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gemini := &fakeProvider{name: ProviderGemini, texts: []string{"Hai"}}
+			chain := NewChain(NewDifyProvider(&fakeDify{events: tt.difyEvents}), gemini)
+
+			var sent []string
+			out := NewParagraphOutput(threshold, func(text string) {
+				sent = append(sent, text)
+			})
+
+			res, err := chain.Stream(context.Background(), &Request{Query: "halo"}, out)
+			out.Flush()
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantGemini, gemini.called)
+			assert.Equal(t, tt.wantSent, sent)
+			assert.Equal(t, tt.wantProvider, res.Provider)
+			assert.Equal(t, tt.wantText, res.Text)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package answer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/dify"
+)
+
+type difyProvider struct {
+	client dify.CustomDifyInterface
+}
+
+// NewDifyProvider returns a Provider that streams answers from Dify and keeps
+// the conversation going on Dify's side.
+func NewDifyProvider(client dify.CustomDifyInterface) Provider {
+	return &difyProvider{client: client}
+}
+
+func (p *difyProvider) Name() string {
+	return ProviderDify
+}
+
+func (p *difyProvider) Answer(ctx context.Context, req *Request, onText func(text string)) (*Answer, error) {
+	stream, err := p.client.ChatMessagesStream(ctx, &dify.Request{
+		Inputs:         req.Inputs,
+		Query:          req.Query,
+		ResponseMode:   "streaming",
+		ConversationID: req.ConversationID,
+		User:           req.User,
+		Files:          []any{},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Answer{Provider: ProviderDify}
+
+	var text strings.Builder
+	for event := range stream {
+		if event.ConversationID != "" {
+			res.ConversationID = event.ConversationID
+		}
+
+		switch event.Event {
+		case dify.EventMessage:
+			// Leading whitespace is held back, so a blank answer passes
+			// nothing on and the chain can still fall back
+			started := strings.TrimSpace(text.String()) != ""
+			text.WriteString(event.Answer)
+			if started {
+				onText(event.Answer)
+			} else if strings.TrimSpace(text.String()) != "" {
+				onText(text.String())
+			}
+		case dify.EventMessageEnd:
+			if strings.TrimSpace(text.String()) == "" {
+				return nil, ErrEmptyAnswer
+			}
+
+			res.Text = text.String()
+			return res, nil
+		case dify.EventError:
+			res.Text = text.String()
+			return res, event.Err
+		}
+	}
+
+	// The channel is only closed without a final event when ctx is done
+	res.Text = text.String()
+	if err := ctx.Err(); err != nil {
+		return res, err
+	}
+
+	return res, dify.ErrStreamIncomplete
+}
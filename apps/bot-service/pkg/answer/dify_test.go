@@ -0,0 +1,143 @@
+package answer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/dify"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDify struct {
+	events []dify.StreamEvent
+}
+
+func (d *fakeDify) ChatMessages(ctx context.Context, req *dify.Request) (*dify.Response, error) {
+	return nil, errors.New("not used")
+}
+
+func (d *fakeDify) ChatMessagesStream(ctx context.Context, req *dify.Request) (<-chan dify.StreamEvent, error) {
+	stream := make(chan dify.StreamEvent, len(d.events))
+	for _, event := range d.events {
+		stream <- event
+	}
+	close(stream)
+
+	return stream, nil
+}
+
+func TestDifyProvider_Answer(t *testing.T) {
+	errDown := errors.New("down")
+
+	tests := []struct {
+		name      string
+		events    []dify.StreamEvent
+		wantText  string
+		wantSent  []string
+		wantConv  string
+		wantErr   error
+		wantNoRes bool
+	}{
+		{
+			name: "success - streamed answer",
+			events: []dify.StreamEvent{
+				{Event: dify.EventMessage, Answer: "Halo", ConversationID: "conv-1"},
+				{Event: dify.EventMessage, Answer: "!"},
+				{Event: dify.EventMessageEnd},
+			},
+			wantText: "Halo!",
+			wantSent: []string{"Halo", "!"},
+			wantConv: "conv-1",
+		},
+		{
+			name: "success - leading whitespace held back",
+			events: []dify.StreamEvent{
+				{Event: dify.EventMessage, Answer: "\n"},
+				{Event: dify.EventMessage, Answer: "Halo"},
+				{Event: dify.EventMessageEnd},
+			},
+			wantText: "\nHalo",
+			wantSent: []string{"\nHalo"},
+		},
+		{
+			name: "empty answer",
+			events: []dify.StreamEvent{
+				{Event: dify.EventMessageEnd, ConversationID: "conv-1"},
+			},
+			wantErr:   ErrEmptyAnswer,
+			wantNoRes: true,
+		},
+		{
+			name: "blank answer",
+			events: []dify.StreamEvent{
+				{Event: dify.EventMessage, Answer: " \n\n "},
+				{Event: dify.EventMessageEnd},
+			},
+			wantErr:   ErrEmptyAnswer,
+			wantNoRes: true,
+		},
+		{
+			name: "error after partial answer",
+			events: []dify.StreamEvent{
+				{Event: dify.EventMessage, Answer: "Hal"},
+				{Event: dify.EventError, Err: errDown},
+			},
+			wantText: "Hal",
+			wantSent: []string{"Hal"},
+			wantErr:  errDown,
+		},
+		{
+			name: "stream closed without message_end",
+			events: []dify.StreamEvent{
+				{Event: dify.EventMessage, Answer: "Hal"},
+			},
+			wantText: "Hal",
+			wantSent: []string{"Hal"},
+			wantErr:  dify.ErrStreamIncomplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewDifyProvider(&fakeDify{events: tt.events})
+
+			var sent []string
+			res, err := provider.Answer(context.Background(), &Request{Query: "halo"}, func(text string) {
+				sent = append(sent, text)
+			})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.wantSent, sent)
+
+			if tt.wantNoRes {
+				assert.Nil(t, res)
+				return
+			}
+
+			assert.Equal(t, ProviderDify, res.Provider)
+			assert.Equal(t, tt.wantText, res.Text)
+			assert.Equal(t, tt.wantConv, res.ConversationID)
+		})
+	}
+}
+
+func TestChain_Answer_FallsBackOnEmptyDifyAnswer(t *testing.T) {
+	gemini := &fakeProvider{name: ProviderGemini, texts: []string{"Hai"}}
+	chain := NewChain(
+		NewDifyProvider(&fakeDify{events: []dify.StreamEvent{{Event: dify.EventMessageEnd}}}),
+		gemini,
+	)
+
+	res, err := chain.Answer(context.Background(), &Request{Query: "halo"}, func(text string) {})
+
+	assert.NoError(t, err)
+	assert.True(t, gemini.called)
+	assert.Equal(t, ProviderGemini, res.Provider)
+	assert.Equal(t, "Hai", res.Text)
+}
@@ -0,0 +1,45 @@
+package answer
+
+import (
+	"context"
+	"strings"
+)
+
+// GeminiClient is the part of genai.CustomGenAIInterface the Gemini provider
+// uses.
+type GeminiClient interface {
+	Chat(ctx context.Context, texts []string) (string, error)
+}
+
+type geminiProvider struct {
+	client GeminiClient
+}
+
+// NewGeminiProvider returns a Provider that answers with Gemini. Gemini has no
+// conversation state here, so each question is answered on its own.
+func NewGeminiProvider(client GeminiClient) Provider {
+	return &geminiProvider{client: client}
+}
+
+func (p *geminiProvider) Name() string {
+	return ProviderGemini
+}
+
+func (p *geminiProvider) Answer(ctx context.Context, req *Request, onText func(text string)) (*Answer, error) {
+	text, err := p.client.Chat(ctx, []string{req.Query})
+	if err != nil {
+		return nil, err
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyAnswer
+	}
+
+	onText(text)
+
+	return &Answer{
+		Provider: ProviderGemini,
+		Text:     text,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package answer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// SplitCompletedParagraphs returns the completed paragraphs of a streamed
+// answer once buffer reaches threshold characters, along with the text still
+// being written. It returns an empty chunk while the buffer is short or has
+// no paragraph break.
+func SplitCompletedParagraphs(buffer string, threshold int) (string, string) {
+	if utf8.RuneCountInString(buffer) < threshold {
+		return "", buffer
+	}
+
+	idx := strings.LastIndex(buffer, "\n\n")
+	if idx <= 0 {
+		return "", buffer
+	}
+
+	chunk := strings.TrimSpace(buffer[:idx])
+	rest := strings.TrimLeft(buffer[idx:], "\n")
+
+	return chunk, rest
+}
+
+// ParagraphOutput is an Output that holds an answer back until threshold
+// characters are buffered, then sends its completed paragraphs as they come.
+// Flush sends the rest once the answer is done.
+type ParagraphOutput struct {
+	threshold int
+	send      func(text string)
+	pending   string
+	delivered bool
+}
+
+func NewParagraphOutput(threshold int, send func(text string)) *ParagraphOutput {
+	return &ParagraphOutput{threshold: threshold, send: send}
+}
+
+func (o *ParagraphOutput) Write(text string) {
+	o.pending += text
+
+	var chunk string
+	chunk, o.pending = SplitCompletedParagraphs(o.pending, o.threshold)
+	if chunk != "" {
+		o.deliver(chunk)
+	}
+}
+
+func (o *ParagraphOutput) Delivered() bool {
+	return o.delivered
+}
+
+func (o *ParagraphOutput) Discard() {
+	o.pending = ""
+}
+
+// Flush sends the text held back so far
+func (o *ParagraphOutput) Flush() {
+	if rest := strings.TrimSpace(o.pending); rest != "" {
+		o.deliver(rest)
+	}
+	o.pending = ""
+}
+
+func (o *ParagraphOutput) deliver(text string) {
+	o.send(text)
+	o.delivered = true
+}
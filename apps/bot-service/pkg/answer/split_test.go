@@ -0,0 +1,84 @@
+package answer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitCompletedParagraphs(t *testing.T) {
+	tests := []struct {
+		name      string
+		buffer    string
+		threshold int
+		wantChunk string
+		wantRest  string
+	}{
+		{
+			name:      "below threshold",
+			buffer:    "Paragraf satu.\n\nParagraf dua",
+			threshold: 100,
+			wantChunk: "",
+			wantRest:  "Paragraf satu.\n\nParagraf dua",
+		},
+		{
+			name:      "at threshold splits at the last break",
+			buffer:    "Satu.\n\nDua.\n\nTiga",
+			threshold: 17,
+			wantChunk: "Satu.\n\nDua.",
+			wantRest:  "Tiga",
+		},
+		{
+			name:      "no paragraph break",
+			buffer:    "Satu.\nDua.\nTiga yang panjang",
+			threshold: 10,
+			wantChunk: "",
+			wantRest:  "Satu.\nDua.\nTiga yang panjang",
+		},
+		{
+			name:      "break at the start only",
+			buffer:    "\n\nTiga yang panjang",
+			threshold: 10,
+			wantChunk: "",
+			wantRest:  "\n\nTiga yang panjang",
+		},
+		{
+			name:      "trailing break leaves nothing pending",
+			buffer:    "Satu.\n\nDua.\n\n",
+			threshold: 10,
+			wantChunk: "Satu.\n\nDua.",
+			wantRest:  "",
+		},
+		{
+			name:      "threshold counts characters, not bytes",
+			buffer:    "Halo 👋\n\nApa kabar 😊",
+			threshold: 20,
+			wantChunk: "",
+			wantRest:  "Halo 👋\n\nApa kabar 😊",
+		},
+		{
+			name:      "multibyte text splits on the break",
+			buffer:    "Halo 👋\n\nApa kabar 😊",
+			threshold: 19,
+			wantChunk: "Halo 👋",
+			wantRest:  "Apa kabar 😊",
+		},
+		{
+			name:      "long answer",
+			buffer:    strings.Repeat("a", 20) + "\n\n" + strings.Repeat("b", 5),
+			threshold: 25,
+			wantChunk: strings.Repeat("a", 20),
+			wantRest:  strings.Repeat("b", 5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunk, rest := SplitCompletedParagraphs(tt.buffer, tt.threshold)
+
+			assert.Equal(t, tt.wantChunk, chunk)
+			assert.Equal(t, tt.wantRest, rest)
+		})
+	}
+}
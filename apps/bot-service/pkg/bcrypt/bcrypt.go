@@ -0,0 +1,43 @@
+package bcrypt
+
+import (
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//go:generate mockgen -destination=mock/mock_bcrypt.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/bcrypt CustomBcryptInterface
+
+type CustomBcryptInterface interface {
+	Hash(password string) (string, error)
+	Compare(hashedPassword string, password string) bool
+}
+
+type CustomBcryptStruct struct {
+	cost int
+}
+
+var Bcrypt = getBcrypt()
+
+func getBcrypt() CustomBcryptInterface {
+	return &CustomBcryptStruct{
+		cost: 12,
+	}
+}
+
+func (b *CustomBcryptStruct) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		log.Error(log.CustomLogInfo{
+			"error": err.Error(),
+		}, "[BCRYPT][Hash] failed to hash password")
+
+		return "", err
+	}
+
+	return string(hashed), nil
+}
+
+func (b *CustomBcryptStruct) Compare(hashedPassword string, password string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	return err == nil
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/bcrypt (interfaces: CustomBcryptInterface)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_bcrypt.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/bcrypt CustomBcryptInterface
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomBcryptInterface is a mock of CustomBcryptInterface interface.
+type MockCustomBcryptInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomBcryptInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomBcryptInterfaceMockRecorder is the mock recorder for MockCustomBcryptInterface.
+type MockCustomBcryptInterfaceMockRecorder struct {
+	mock *MockCustomBcryptInterface
+}
+
+// NewMockCustomBcryptInterface creates a new mock instance.
+func NewMockCustomBcryptInterface(ctrl *gomock.Controller) *MockCustomBcryptInterface {
+	mock := &MockCustomBcryptInterface{ctrl: ctrl}
+	mock.recorder = &MockCustomBcryptInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomBcryptInterface) EXPECT() *MockCustomBcryptInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Compare mocks base method.
+func (m *MockCustomBcryptInterface) Compare(hashedPassword, password string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compare", hashedPassword, password)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Compare indicates an expected call of Compare.
+func (mr *MockCustomBcryptInterfaceMockRecorder) Compare(hashedPassword, password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compare", reflect.TypeOf((*MockCustomBcryptInterface)(nil).Compare), hashedPassword, password)
+}
+
+// Hash mocks base method.
+func (m *MockCustomBcryptInterface) Hash(password string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hash", password)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hash indicates an expected call of Hash.
+func (mr *MockCustomBcryptInterfaceMockRecorder) Hash(password any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockCustomBcryptInterface)(nil).Hash), password)
+}
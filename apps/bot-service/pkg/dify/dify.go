@@ -1,14 +1,16 @@
 package dify
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-
-	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/env"
+	"strings"
+	"time"
 )
 
 type Request struct {
@@ -32,27 +34,130 @@ type Response struct {
 	CreatedAt      int64          `json:"created_at"`
 }
 
+// Server-sent event types emitted by the streaming chat-messages endpoint.
+const (
+	EventMessage      = "message"
+	EventAgentMessage = "agent_message"
+	EventMessageEnd   = "message_end"
+	EventError        = "error"
+	EventPing         = "ping"
+)
+
+// StreamEvent is a single event from a streaming chat-messages response.
+// Agent apps send "agent_message" events, which are reported as EventMessage.
+// Err is set when the stream itself fails (network or decoding errors); such
+// an event always has Event set to EventError and is the last one sent.
+type StreamEvent struct {
+	Event          string         `json:"event"`
+	TaskID         string         `json:"task_id"`
+	MessageID      string         `json:"message_id"`
+	ConversationID string         `json:"conversation_id"`
+	Answer         string         `json:"answer"`
+	Metadata       map[string]any `json:"metadata"`
+	CreatedAt      int64          `json:"created_at"`
+
+	// Populated for error events
+	Status  int    `json:"status"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Err     error  `json:"-"`
+}
+
+// ErrStreamIncomplete is reported when a stream ends without a message_end event.
+var ErrStreamIncomplete = errors.New("stream ended before message_end")
+
 type CustomDifyInterface interface {
 	ChatMessages(ctx context.Context, req *Request) (*Response, error)
+	ChatMessagesStream(ctx context.Context, req *Request) (<-chan StreamEvent, error)
+}
+
+// Config holds the connection, retry and circuit breaker settings of the Dify
+// client. Zero values fall back to the defaults below.
+type Config struct {
+	APIURL string
+	APIKey string
+
+	// Timeout bounds each blocking request attempt, and the wait for response
+	// headers of a streaming one
+	Timeout time.Duration
+	// StreamTimeout bounds a whole streaming response
+	StreamTimeout time.Duration
+
+	// MaxRetries is the number of extra attempts made on 429, 5xx and network errors
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	// The breaker opens after BreakerThreshold consecutive failed calls and
+	// rejects calls with ErrCircuitOpen until BreakerCooldown has passed
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+const (
+	defaultTimeout          = 30 * time.Second
+	defaultStreamTimeout    = 2 * time.Minute
+	defaultMaxRetries       = 2
+	defaultRetryBaseDelay   = 500 * time.Millisecond
+	defaultRetryMaxDelay    = 5 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+func (c Config) withDefaults() Config {
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.StreamTimeout <= 0 {
+		c.StreamTimeout = defaultStreamTimeout
+	}
+	if c.MaxRetries < 0 {
+		c.MaxRetries = 0
+	} else if c.MaxRetries == 0 {
+		c.MaxRetries = defaultMaxRetries
+	}
+	if c.RetryBaseDelay <= 0 {
+		c.RetryBaseDelay = defaultRetryBaseDelay
+	}
+	if c.RetryMaxDelay <= 0 {
+		c.RetryMaxDelay = defaultRetryMaxDelay
+	}
+	if c.BreakerThreshold <= 0 {
+		c.BreakerThreshold = defaultBreakerThreshold
+	}
+	if c.BreakerCooldown <= 0 {
+		c.BreakerCooldown = defaultBreakerCooldown
+	}
+
+	return c
 }
 
 type CustomDifyStruct struct {
 	DifyAPIURL string
 	DifyAPIKey string
+
+	config  Config
+	client  *http.Client
+	breaker *circuitBreaker
 }
 
-func getDify() CustomDifyInterface {
-	difyAPIURL := env.AppEnv.DifyAPIURL
-	difyAPIKey := env.AppEnv.DifyAPIKey
+// NewDify returns a Dify client that reuses connections across requests.
+func NewDify(config Config) CustomDifyInterface {
+	config = config.withDefaults()
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 10
+	transport.ResponseHeaderTimeout = config.Timeout
 
 	return &CustomDifyStruct{
-		DifyAPIURL: difyAPIURL,
-		DifyAPIKey: difyAPIKey,
+		DifyAPIURL: config.APIURL,
+		DifyAPIKey: config.APIKey,
+		config:     config,
+		client:     &http.Client{Transport: transport},
+		breaker:    newCircuitBreaker(config.BreakerThreshold, config.BreakerCooldown),
 	}
 }
 
-var Dify = getDify()
-
 // ChatMessages sends a chat message request to the Dify API and returns the response.
 func (o *CustomDifyStruct) ChatMessages(ctx context.Context, req *Request) (*Response, error) {
 	reqBody, err := json.Marshal(req)
@@ -60,34 +165,204 @@ func (o *CustomDifyStruct) ChatMessages(ctx context.Context, req *Request) (*Res
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, o.DifyAPIURL+"/chat-messages", bytes.NewBuffer(reqBody))
+	if !o.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	var res Response
+	err = o.withRetry(ctx, func() error {
+		attemptCtx, cancel := context.WithTimeout(ctx, o.config.Timeout)
+		defer cancel()
+
+		httpResp, err := o.post(attemptCtx, reqBody, "application/json")
+		if err != nil {
+			return err
+		}
+		defer httpResp.Body.Close()
+
+		respBody, err := io.ReadAll(httpResp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+
+		if httpResp.StatusCode != http.StatusOK {
+			return newAPIError(httpResp, respBody)
+		}
+
+		if err := json.Unmarshal(respBody, &res); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		return nil
+	})
+	o.breaker.record(ctx, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, err
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", "Bearer "+o.DifyAPIKey)
+	return &res, nil
+}
+
+// ChatMessagesStream sends a chat message request in streaming mode and returns
+// a channel of the events Dify sends back. The channel is closed after the
+// message_end or error event, or when ctx is cancelled. Events other than
+// message, message_end, error and ping are skipped.
+func (o *CustomDifyStruct) ChatMessagesStream(ctx context.Context, req *Request) (<-chan StreamEvent, error) {
+	streamReq := *req
+	streamReq.ResponseMode = "streaming"
 
-	client := &http.Client{}
-	httpResp, err := client.Do(httpReq)
+	reqBody, err := json.Marshal(&streamReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	if !o.breaker.allow() {
+		return nil, ErrCircuitOpen
 	}
-	defer httpResp.Body.Close()
 
-	respBody, err := io.ReadAll(httpResp.Body)
+	streamCtx, cancel := context.WithTimeout(ctx, o.config.StreamTimeout)
+
+	var httpResp *http.Response
+	err = o.withRetry(streamCtx, func() error {
+		resp, err := o.post(streamCtx, reqBody, "text/event-stream")
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			defer resp.Body.Close()
+
+			respBody, err := io.ReadAll(resp.Body)
+			if err != nil {
+				return fmt.Errorf("failed to read response: %w", err)
+			}
+
+			return newAPIError(resp, respBody)
+		}
+
+		httpResp = resp
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		cancel()
+		o.breaker.record(ctx, err)
+		return nil, err
 	}
 
-	if httpResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", httpResp.StatusCode, string(respBody))
+	events := make(chan StreamEvent)
+
+	go func() {
+		defer close(events)
+		defer cancel()
+		defer httpResp.Body.Close()
+
+		send := func(event StreamEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for event, err := range parseStream(httpResp.Body) {
+			if err != nil {
+				o.breaker.record(ctx, err)
+				send(StreamEvent{Event: EventError, Message: err.Error(), Err: err})
+				return
+			}
+
+			// Error events are reported by Dify itself, so they don't count
+			// against the breaker
+			if event.Event == EventMessageEnd || event.Event == EventError {
+				o.breaker.record(ctx, nil)
+				send(event)
+				return
+			}
+
+			if !send(event) {
+				o.breaker.record(ctx, nil)
+				return
+			}
+		}
+
+		o.breaker.record(ctx, ErrStreamIncomplete)
+		send(StreamEvent{
+			Event:   EventError,
+			Message: ErrStreamIncomplete.Error(),
+			Err:     ErrStreamIncomplete,
+		})
+	}()
+
+	return events, nil
+}
+
+func (o *CustomDifyStruct) post(ctx context.Context, body []byte, accept string) (*http.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, o.DifyAPIURL+"/chat-messages", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	var res Response
-	if err := json.Unmarshal(respBody, &res); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", accept)
+	httpReq.Header.Set("Authorization", "Bearer "+o.DifyAPIKey)
+
+	httpResp, err := o.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
 	}
 
-	return &res, nil
+	return httpResp, nil
+}
+
+const maxStreamLineSize = 1024 * 1024
+
+// parseStream yields the events of a Dify server-sent event stream.
+func parseStream(body io.Reader) func(yield func(StreamEvent, error) bool) {
+	return func(yield func(StreamEvent, error) bool) {
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxStreamLineSize)
+
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+
+			// Dify sends keep-alives as a bare "event: ping" line
+			if name, ok := strings.CutPrefix(line, "event:"); ok {
+				if strings.TrimSpace(name) == EventPing && !yield(StreamEvent{Event: EventPing}, nil) {
+					return
+				}
+				continue
+			}
+
+			data, ok := strings.CutPrefix(line, "data:")
+			if !ok {
+				continue
+			}
+
+			var event StreamEvent
+			if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+				yield(StreamEvent{}, fmt.Errorf("failed to unmarshal stream event: %w", err))
+				return
+			}
+
+			switch event.Event {
+			case EventAgentMessage:
+				event.Event = EventMessage
+			case EventMessage, EventMessageEnd, EventPing:
+			case EventError:
+				event.Err = fmt.Errorf("stream error %d (%s): %s", event.Status, event.Code, event.Message)
+			default:
+				continue
+			}
+
+			if !yield(event, nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield(StreamEvent{}, fmt.Errorf("failed to read stream: %w", err))
+			return
+		}
+	}
 }
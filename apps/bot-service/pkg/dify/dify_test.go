@@ -0,0 +1,329 @@
+package dify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDify(t *testing.T, handler http.HandlerFunc, config Config) *CustomDifyStruct {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config.APIURL = server.URL
+	config.APIKey = "test-key"
+	if config.RetryBaseDelay == 0 {
+		config.RetryBaseDelay = time.Millisecond
+	}
+	if config.RetryMaxDelay == 0 {
+		config.RetryMaxDelay = 5 * time.Millisecond
+	}
+
+	return NewDify(config).(*CustomDifyStruct)
+}
+
+func respondWithStatuses(calls *atomic.Int32, statuses ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1)) - 1
+
+		status := statuses[min(call, len(statuses)-1)]
+		if status != http.StatusOK {
+			http.Error(w, "failed", status)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"event":"message","conversation_id":"conv-1","answer":"Halo!"}`)
+	}
+}
+
+func TestDify_ChatMessages(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		config     Config
+		wantCalls  int32
+		wantStatus int
+		wantErr    bool
+	}{
+		{
+			name:      "success - first attempt",
+			statuses:  []int{http.StatusOK},
+			wantCalls: 1,
+		},
+		{
+			name:      "success - retried after server errors",
+			statuses:  []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			config:    Config{MaxRetries: 2},
+			wantCalls: 3,
+		},
+		{
+			name:      "success - retried after rate limiting",
+			statuses:  []int{http.StatusTooManyRequests, http.StatusOK},
+			config:    Config{MaxRetries: 2},
+			wantCalls: 2,
+		},
+		{
+			name:       "error - retries exhausted",
+			statuses:   []int{http.StatusInternalServerError},
+			config:     Config{MaxRetries: 2},
+			wantCalls:  3,
+			wantStatus: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+		{
+			name:       "error - client errors are not retried",
+			statuses:   []int{http.StatusBadRequest},
+			config:     Config{MaxRetries: 2},
+			wantCalls:  1,
+			wantStatus: http.StatusBadRequest,
+			wantErr:    true,
+		},
+		{
+			name:       "error - retries disabled",
+			statuses:   []int{http.StatusServiceUnavailable},
+			config:     Config{MaxRetries: -1},
+			wantCalls:  1,
+			wantStatus: http.StatusServiceUnavailable,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			client := newTestDify(t, respondWithStatuses(&calls, tt.statuses...), tt.config)
+
+			res, err := client.ChatMessages(context.Background(), &Request{Query: "halo"})
+
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if tt.wantErr {
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, tt.wantStatus, apiErr.StatusCode)
+				assert.Nil(t, res)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "Halo!", res.Answer)
+				assert.Equal(t, "conv-1", res.ConversationID)
+			}
+		})
+	}
+}
+
+func TestDify_ChatMessages_Timeout(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestDify(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(200 * time.Millisecond):
+		}
+	}, Config{Timeout: 20 * time.Millisecond, MaxRetries: 1})
+
+	start := time.Now()
+	_, err := client.ChatMessages(context.Background(), &Request{Query: "halo"})
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestDify_CircuitBreaker(t *testing.T) {
+	t.Run("opens after consecutive failures", func(t *testing.T) {
+		var calls atomic.Int32
+		client := newTestDify(t, respondWithStatuses(&calls, http.StatusServiceUnavailable), Config{
+			MaxRetries:       -1,
+			BreakerThreshold: 2,
+			BreakerCooldown:  time.Minute,
+		})
+
+		for range 2 {
+			_, err := client.ChatMessages(context.Background(), &Request{})
+			require.Error(t, err)
+		}
+
+		_, err := client.ChatMessages(context.Background(), &Request{})
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+
+		_, err = client.ChatMessagesStream(context.Background(), &Request{})
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("client errors don't open it", func(t *testing.T) {
+		var calls atomic.Int32
+		client := newTestDify(t, respondWithStatuses(&calls, http.StatusBadRequest), Config{
+			MaxRetries:       -1,
+			BreakerThreshold: 1,
+			BreakerCooldown:  time.Minute,
+		})
+
+		for range 3 {
+			_, err := client.ChatMessages(context.Background(), &Request{})
+			assert.NotErrorIs(t, err, ErrCircuitOpen)
+		}
+
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("closes after a successful trial call", func(t *testing.T) {
+		var calls atomic.Int32
+		client := newTestDify(t, respondWithStatuses(&calls, http.StatusServiceUnavailable, http.StatusOK), Config{
+			MaxRetries:       -1,
+			BreakerThreshold: 1,
+			BreakerCooldown:  20 * time.Millisecond,
+		})
+
+		_, err := client.ChatMessages(context.Background(), &Request{})
+		require.Error(t, err)
+
+		_, err = client.ChatMessages(context.Background(), &Request{})
+		require.ErrorIs(t, err, ErrCircuitOpen)
+
+		time.Sleep(30 * time.Millisecond)
+
+		for range 2 {
+			_, err = client.ChatMessages(context.Background(), &Request{})
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("reopens after a failed trial call", func(t *testing.T) {
+		var calls atomic.Int32
+		client := newTestDify(t, respondWithStatuses(&calls, http.StatusServiceUnavailable), Config{
+			MaxRetries:       -1,
+			BreakerThreshold: 2,
+			BreakerCooldown:  20 * time.Millisecond,
+		})
+
+		for range 2 {
+			_, err := client.ChatMessages(context.Background(), &Request{})
+			require.Error(t, err)
+		}
+
+		time.Sleep(30 * time.Millisecond)
+
+		_, err := client.ChatMessages(context.Background(), &Request{})
+		require.NotErrorIs(t, err, ErrCircuitOpen)
+
+		_, err = client.ChatMessages(context.Background(), &Request{})
+		assert.ErrorIs(t, err, ErrCircuitOpen)
+
+		assert.Equal(t, int32(3), calls.Load())
+	})
+}
+
+func TestDify_ChatMessagesStream(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantAnswer  string
+		wantLast    string
+		wantErr     error
+		wantConvoID string
+	}{
+		{
+			name: "success - message events until message_end",
+			body: "event: ping\n\n" +
+				`data: {"event":"message","conversation_id":"conv-1","answer":"Halo"}` + "\n\n" +
+				`data: {"event":"agent_message","conversation_id":"conv-1","answer":", apa kabar?"}` + "\n\n" +
+				`data: {"event":"workflow_started","conversation_id":"conv-1"}` + "\n\n" +
+				`data: {"event":"message_end","conversation_id":"conv-1"}` + "\n\n",
+			wantAnswer:  "Halo, apa kabar?",
+			wantLast:    EventMessageEnd,
+			wantConvoID: "conv-1",
+		},
+		{
+			name: "error - error event",
+			body: `data: {"event":"message","conversation_id":"conv-1","answer":"Halo"}` + "\n\n" +
+				`data: {"event":"error","status":400,"code":"invalid_param","message":"bad"}` + "\n\n",
+			wantAnswer:  "Halo",
+			wantLast:    EventError,
+			wantConvoID: "conv-1",
+		},
+		{
+			name:       "error - stream ends early",
+			body:       `data: {"event":"message","answer":"Halo"}` + "\n\n",
+			wantAnswer: "Halo",
+			wantLast:   EventError,
+			wantErr:    ErrStreamIncomplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestDify(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+				assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+				w.Header().Set("Content-Type", "text/event-stream")
+				fmt.Fprint(w, tt.body)
+			}, Config{})
+
+			stream, err := client.ChatMessagesStream(context.Background(), &Request{Query: "halo"})
+			require.NoError(t, err)
+
+			var answer, convoID string
+			var last StreamEvent
+			for event := range stream {
+				if event.Event == EventMessage {
+					answer += event.Answer
+				}
+				if event.ConversationID != "" {
+					convoID = event.ConversationID
+				}
+				last = event
+			}
+
+			assert.Equal(t, tt.wantAnswer, answer)
+			assert.Equal(t, tt.wantLast, last.Event)
+			assert.Equal(t, tt.wantConvoID, convoID)
+			if tt.wantLast == EventError {
+				assert.Error(t, last.Err)
+			}
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, last.Err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDify_ChatMessagesStream_RetriesBeforeStreaming(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestDify(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+
+		fmt.Fprint(w, `data: {"event":"message_end","conversation_id":"conv-1"}`+"\n\n")
+	}, Config{MaxRetries: 1})
+
+	stream, err := client.ChatMessagesStream(context.Background(), &Request{})
+	require.NoError(t, err)
+
+	var events []StreamEvent
+	for event := range stream {
+		events = append(events, event)
+	}
+
+	require.Len(t, events, 1)
+	assert.Equal(t, EventMessageEnd, events[0].Event)
+	assert.Equal(t, int32(2), calls.Load())
+}
@@ -0,0 +1,86 @@
+package dify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError is returned when Dify responds with a non-200 status.
+type APIError struct {
+	StatusCode int
+	Body       string
+	RetryAfter time.Duration // From the Retry-After header, if any
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	return apiErr
+}
+
+// isFailure reports whether err means Dify is unhealthy: rate limiting, server
+// errors, timeouts and network errors. Other 4xx responses are the request's
+// fault and are neither retried nor counted by the circuit breaker.
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	return true
+}
+
+// withRetry calls attempt until it succeeds, fails with an error that isn't
+// worth retrying, or MaxRetries extra attempts have been made.
+func (o *CustomDifyStruct) withRetry(ctx context.Context, attempt func() error) error {
+	for i := 0; ; i++ {
+		err := attempt()
+		if err == nil || i >= o.config.MaxRetries || !isFailure(err) || ctx.Err() != nil {
+			return err
+		}
+
+		timer := time.NewTimer(o.retryDelay(i, err))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// retryDelay returns a jittered exponential backoff for the given retry,
+// honouring Retry-After up to RetryMaxDelay.
+func (o *CustomDifyStruct) retryDelay(retry int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return min(apiErr.RetryAfter, o.config.RetryMaxDelay)
+	}
+
+	ceiling := min(o.config.RetryBaseDelay<<min(retry, 16), o.config.RetryMaxDelay)
+
+	// Anywhere between half the ceiling and the ceiling, so that clients
+	// retrying at the same time spread out
+	half := ceiling / 2
+	return half + rand.N(ceiling-half+1)
+}
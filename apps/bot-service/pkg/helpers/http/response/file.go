@@ -0,0 +1,29 @@
+package response
+
+import (
+	"bufio"
+	"context"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/dto"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/log"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SendFile streams file to the client as a download
+func SendFile(ctx *fiber.Ctx, file *dto.ExportFile) error {
+	ctx.Attachment(file.FileName)
+	ctx.Set(fiber.HeaderContentType, file.ContentType)
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The status has already been sent, so a failure can only cut the
+		// file short. This runs after the handler returns, when the request's
+		// context is no longer usable.
+		if err := file.Write(context.Background(), w); err != nil {
+			log.Error(log.CustomLogInfo{
+				"error": err.Error(),
+				"file":  file.FileName,
+			}, "[Response] File stopped partway through")
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,149 @@
+// Package report resolves the date ranges analytics endpoints cover. Ranges
+// are whole days in a time zone, with an exclusive upper bound at the
+// midnight after the last day. Timestamp columns hold UTC without a zone, as
+// everything writes time.Now().UTC(), so bounds are passed to queries in UTC.
+package report
+
+import (
+	"math"
+	"time"
+
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/entity"
+	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/domain/errx"
+)
+
+// The bot talks to users in Jakarta time, so reports default to it too
+const DefaultTimezone = "Asia/Jakarta"
+
+// How far apart a report's from and to dates can be
+const MaxYears = 2
+
+// LoadLocation loads timezone, or DefaultTimezone when it is empty
+func LoadLocation(timezone string, location string) (*time.Location, error) {
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errx.ErrInvalidTimezone.WithDetails(map[string]any{
+			"tz": timezone,
+		}).WithLocation(location).WithError(err)
+	}
+
+	return loc, nil
+}
+
+// ParseRange turns inclusive from and to dates in loc into midnight bounds,
+// where the upper bound is the start of the day after to. to defaults to
+// today and from to defaultFrom(to).
+func ParseRange(from, to *string, loc *time.Location, defaultFrom func(lastDay time.Time) time.Time, location string) (time.Time, time.Time, error) {
+	now := time.Now().In(loc)
+	lastDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if to != nil {
+		parsedDate, err := time.ParseInLocation(time.DateOnly, *to, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, errx.ErrInvalidDateFormat.WithDetails(map[string]any{
+				"to": *to,
+			}).WithLocation(location).WithError(err)
+		}
+		lastDay = parsedDate
+	}
+
+	firstDay := defaultFrom(lastDay)
+	if from != nil {
+		parsedDate, err := time.ParseInLocation(time.DateOnly, *from, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, errx.ErrInvalidDateFormat.WithDetails(map[string]any{
+				"from": *from,
+			}).WithLocation(location).WithError(err)
+		}
+		firstDay = parsedDate
+	}
+
+	if firstDay.After(lastDay) || lastDay.After(firstDay.AddDate(MaxYears, 0, 0)) {
+		return time.Time{}, time.Time{}, errx.ErrInvalidDateRange.WithDetails(map[string]any{
+			"from": firstDay.Format(time.DateOnly),
+			"to":   lastDay.Format(time.DateOnly),
+		}).WithLocation(location)
+	}
+
+	return firstDay, lastDay.AddDate(0, 0, 1), nil
+}
+
+// ParseFilterRange turns the inclusive from and to dates of a list filter in
+// timezone into bounds like ParseRange's. A missing date leaves that end of
+// the range open, and both are nil when from and to are.
+func ParseFilterRange(from, to *string, timezone string, location string) (*time.Time, *time.Time, error) {
+	if from == nil && to == nil {
+		return nil, nil, nil
+	}
+
+	loc, err := LoadLocation(timezone, location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only the given ends are kept, so a missing one stands in for the other
+	lastDay := to
+	if lastDay == nil {
+		lastDay = from
+	}
+
+	firstBound, lastBound, err := ParseRange(from, lastDay, loc, LastDays(1), location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var lower, upper *time.Time
+	if from != nil {
+		lower = &firstBound
+	}
+	if to != nil {
+		upper = &lastBound
+	}
+
+	return lower, upper, nil
+}
+
+// LastDays makes a ParseRange default that covers days days up to and
+// including the last one
+func LastDays(days int) func(lastDay time.Time) time.Time {
+	return func(lastDay time.Time) time.Time {
+		return lastDay.AddDate(0, 0, -(days - 1))
+	}
+}
+
+// TrendStart goes back 30 days, or to the start of the week or month 11
+// buckets before the one lastDay is in
+func TrendStart(granularity string, lastDay time.Time) time.Time {
+	switch granularity {
+	case entity.TrendGranularityWeek:
+		start := lastDay.AddDate(0, 0, -7*11)
+		// Weeks start on Monday, as in Postgres
+		return start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	case entity.TrendGranularityMonth:
+		return time.Date(lastDay.Year(), lastDay.Month()-11, 1, 0, 0, 0, 0, lastDay.Location())
+	default:
+		return lastDay.AddDate(0, 0, -30)
+	}
+}
+
+// PreviousStart returns the start of the range with as many days as
+// [from, to) that ends at from
+func PreviousStart(from, to time.Time) time.Time {
+	// Count days rather than hours so a DST change doesn't shift the start
+	days := int(math.Round(to.Sub(from).Hours() / 24))
+	return from.AddDate(0, 0, -days)
+}
+
+// Dates returns the inclusive first and last dates of [from, to)
+func Dates(from, to time.Time) (string, string) {
+	return from.Format(time.DateOnly), to.AddDate(0, 0, -1).Format(time.DateOnly)
+}
+
+// WallClock returns t's date and time of day with the zone dropped, for
+// comparing against timestamps that were converted to t's zone in SQL
+func WallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/ahargunyllib/hc-ppn-app/apps/bot-service/internal/infra/env"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
@@ -12,12 +11,15 @@ import (
 //go:generate mockgen -destination=mock/mock_jwt.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/jwt CustomJwtInterface
 
 type CustomJwtInterface interface {
-	Create() (string, error)
+	Create(subject string, role string) (string, error)
 	Decode(tokenString string, claims *Claims) error
 }
 
+// Claims identifies the admin a token was issued to: Subject is the admin ID
+// and Role their role at the time.
 type Claims struct {
 	jwt.RegisteredClaims
+	Role string `json:"role"`
 }
 
 type CustomJwtStruct struct {
@@ -25,25 +27,26 @@ type CustomJwtStruct struct {
 	ExpiredTime time.Duration
 }
 
-var Jwt = getJwt()
-
-func getJwt() CustomJwtInterface {
+func NewJwt(secretKey string, expiredTime time.Duration) CustomJwtInterface {
 	return &CustomJwtStruct{
-		SecretKey:   env.AppEnv.JwtSecretKey,
-		ExpiredTime: env.AppEnv.JwtExpTime,
+		SecretKey:   secretKey,
+		ExpiredTime: expiredTime,
 	}
 }
 
-func (j *CustomJwtStruct) Create() (string, error) {
+// Create signs an access token for subject, the ID of the authenticated admin.
+func (j *CustomJwtStruct) Create(subject string, role string) (string, error) {
 	claims := Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    "hc-ppn-app",
+			Subject:   subject,
 			Audience:  jwt.ClaimStrings{"hc-ppn-app"},
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.ExpiredTime)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			ID:        uuid.New().String(),
 		},
+		Role: role,
 	}
 
 	unsignedJWT := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -41,18 +41,18 @@ func (m *MockCustomJwtInterface) EXPECT() *MockCustomJwtInterfaceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockCustomJwtInterface) Create() (string, error) {
+func (m *MockCustomJwtInterface) Create(subject, role string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create")
+	ret := m.ctrl.Call(m, "Create", subject, role)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockCustomJwtInterfaceMockRecorder) Create() *gomock.Call {
+func (mr *MockCustomJwtInterfaceMockRecorder) Create(subject, role any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomJwtInterface)(nil).Create))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomJwtInterface)(nil).Create), subject, role)
 }
 
 // Decode mocks base method.
@@ -0,0 +1,37 @@
+package tabular
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+type csvReader struct{}
+
+func (r *csvReader) ReadAll(file io.Reader) ([][]string, error) {
+	reader := csv.NewReader(file)
+	// Rows are checked against the header by the caller
+	reader.FieldsPerRecord = -1
+
+	return reader.ReadAll()
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (Writer, error) {
+	return &csvWriter{writer: csv.NewWriter(w)}, nil
+}
+
+func (w *csvWriter) WriteRow(row []string) error {
+	return w.writer.Write(row)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+
+	return w.writer.Error()
+}
+
+// Discard leaves the rows already written as they are
+func (w *csvWriter) Discard() {}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular (interfaces: CustomTabularInterface)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_tabular.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular CustomTabularInterface
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	io "io"
+	reflect "reflect"
+
+	tabular "github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCustomTabularInterface is a mock of CustomTabularInterface interface.
+type MockCustomTabularInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomTabularInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCustomTabularInterfaceMockRecorder is the mock recorder for MockCustomTabularInterface.
+type MockCustomTabularInterfaceMockRecorder struct {
+	mock *MockCustomTabularInterface
+}
+
+// NewMockCustomTabularInterface creates a new mock instance.
+func NewMockCustomTabularInterface(ctrl *gomock.Controller) *MockCustomTabularInterface {
+	mock := &MockCustomTabularInterface{ctrl: ctrl}
+	mock.recorder = &MockCustomTabularInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomTabularInterface) EXPECT() *MockCustomTabularInterfaceMockRecorder {
+	return m.recorder
+}
+
+// NewWriter mocks base method.
+func (m *MockCustomTabularInterface) NewWriter(format string, w io.Writer) (tabular.Writer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewWriter", format, w)
+	ret0, _ := ret[0].(tabular.Writer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewWriter indicates an expected call of NewWriter.
+func (mr *MockCustomTabularInterfaceMockRecorder) NewWriter(format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWriter", reflect.TypeOf((*MockCustomTabularInterface)(nil).NewWriter), format, w)
+}
+
+// Parse mocks base method.
+func (m *MockCustomTabularInterface) Parse(format string, r io.Reader) ([][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", format, r)
+	ret0, _ := ret[0].([][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockCustomTabularInterfaceMockRecorder) Parse(format, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockCustomTabularInterface)(nil).Parse), format, r)
+}
@@ -0,0 +1,119 @@
+package tabular
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+)
+
+//go:generate mockgen -destination=mock/mock_tabular.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/tabular CustomTabularInterface
+
+// File formats, named after their extension
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+type CustomTabularInterface interface {
+	// Parse reads every row of a CSV or Excel file. Rows can have different
+	// lengths; Excel leaves out trailing empty cells.
+	Parse(format string, r io.Reader) ([][]string, error)
+	// NewWriter starts a CSV or Excel file on w that's written one row at a
+	// time. The file isn't complete until Close is called; a file that can't
+	// be finished has to be given up with Discard instead.
+	NewWriter(format string, w io.Writer) (Writer, error)
+}
+
+// Reader reads every row of one file format
+type Reader interface {
+	ReadAll(r io.Reader) ([][]string, error)
+}
+
+// Writer writes a file of one format row by row
+type Writer interface {
+	WriteRow(row []string) error
+	Close() error
+	Discard()
+}
+
+type CustomTabularStruct struct {
+	readers map[string]Reader
+	writers map[string]func(w io.Writer) (Writer, error)
+}
+
+var Tabular = getTabular()
+
+func getTabular() CustomTabularInterface {
+	return &CustomTabularStruct{
+		readers: map[string]Reader{
+			FormatCSV:  &csvReader{},
+			FormatXLSX: &xlsxReader{},
+		},
+		writers: map[string]func(w io.Writer) (Writer, error){
+			FormatCSV:  newCSVWriter,
+			FormatXLSX: newXLSXWriter,
+		},
+	}
+}
+
+func (t *CustomTabularStruct) Parse(format string, r io.Reader) ([][]string, error) {
+	reader, ok := t.readers[format]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	return reader.ReadAll(r)
+}
+
+func (t *CustomTabularStruct) NewWriter(format string, w io.Writer) (Writer, error) {
+	newWriter, ok := t.writers[format]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	return newWriter(w)
+}
+
+// ContentType returns the content type to serve a file of format with
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+
+	return "application/octet-stream"
+}
+
+// Content types browsers send for each format
+var formatsByContentType = map[string]string{
+	"text/csv":                 FormatCSV,
+	"application/csv":          FormatCSV,
+	"text/plain":               FormatCSV,
+	"application/vnd.ms-excel": FormatCSV, // What Windows sends for .csv files
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": FormatXLSX,
+}
+
+// DetectFormat picks the format of an uploaded file by its extension, falling
+// back to its content type. It returns "" when neither is known.
+func DetectFormat(fileHeader *multipart.FileHeader) string {
+	switch strings.ToLower(filepath.Ext(fileHeader.Filename)) {
+	case ".csv":
+		return FormatCSV
+	case ".xlsx":
+		return FormatXLSX
+	}
+
+	mediaType, _, err := mime.ParseMediaType(fileHeader.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+
+	return formatsByContentType[mediaType]
+}
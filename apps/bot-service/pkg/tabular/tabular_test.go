@@ -0,0 +1,176 @@
+package tabular
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		contentType string
+		want        string
+	}{
+		{name: "csv extension", filename: "roster.csv", want: FormatCSV},
+		{name: "xlsx extension in upper case", filename: "ROSTER.XLSX", want: FormatXLSX},
+		{name: "extension wins over content type", filename: "roster.csv", contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", want: FormatCSV},
+		{name: "content type with parameters", filename: "roster", contentType: "text/csv; charset=utf-8", want: FormatCSV},
+		{name: "xlsx content type", filename: "roster", contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", want: FormatXLSX},
+		{name: "unknown", filename: "roster.pdf", contentType: "application/pdf", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileHeader := &multipart.FileHeader{
+				Filename: tt.filename,
+				Header:   textproto.MIMEHeader{"Content-Type": {tt.contentType}},
+			}
+
+			assert.Equal(t, tt.want, DetectFormat(fileHeader))
+		})
+	}
+}
+
+func TestCSVReader_ReadAll(t *testing.T) {
+	rows, err := (&csvReader{}).ReadAll(strings.NewReader("phone_number,name,job_title\n+6281234567890,Budi\n"))
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"phone_number", "name", "job_title"},
+		{"+6281234567890", "Budi"},
+	}, rows)
+}
+
+func TestXLSXReader_ReadAll(t *testing.T) {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+
+	sheet := workbook.GetSheetName(0)
+	require.NoError(t, workbook.SetSheetRow(sheet, "A1", &[]any{"name", "phone_number", "job_title"}))
+	require.NoError(t, workbook.SetSheetRow(sheet, "A2", &[]any{"Budi", "+6281234567890"}))
+
+	var buf bytes.Buffer
+	require.NoError(t, workbook.Write(&buf))
+
+	rows, err := (&xlsxReader{}).ReadAll(&buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"name", "phone_number", "job_title"},
+		{"Budi", "+6281234567890"},
+	}, rows)
+}
+
+func TestXLSXReader_ReadAll_RawValues(t *testing.T) {
+	workbook := excelize.NewFile()
+	defer workbook.Close()
+
+	sheet := workbook.GetSheetName(0)
+	require.NoError(t, workbook.SetSheetRow(sheet, "A1", &[]any{"phone_number", "name", "date_of_birth", "joined_on"}))
+	require.NoError(t, workbook.SetSheetRow(sheet, "A2", &[]any{"+6281234567890", "Budi", 32888, 45000}))
+	require.NoError(t, workbook.SetSheetRow(sheet, "A3", &[]any{6281234567891, "Siti", 32889, 45001}))
+
+	// 1990-01-15 and 1990-01-16, shown as 01-15-90 by Excel's built-in format
+	builtInDate, err := workbook.NewStyle(&excelize.Style{NumFmt: 14})
+	require.NoError(t, err)
+	require.NoError(t, workbook.SetCellStyle(sheet, "C2", "C3", builtInDate))
+
+	customFormat := "dd \"of\" mmmm yyyy"
+	customDate, err := workbook.NewStyle(&excelize.Style{CustomNumFmt: &customFormat})
+	require.NoError(t, err)
+	require.NoError(t, workbook.SetCellStyle(sheet, "D2", "D3", customDate))
+
+	// A long number is shown in scientific notation, but read in full
+	scientific, err := workbook.NewStyle(&excelize.Style{NumFmt: 11})
+	require.NoError(t, err)
+	require.NoError(t, workbook.SetCellStyle(sheet, "A3", "A3", scientific))
+
+	var buf bytes.Buffer
+	require.NoError(t, workbook.Write(&buf))
+
+	rows, err := (&xlsxReader{}).ReadAll(&buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"phone_number", "name", "date_of_birth", "joined_on"},
+		{"+6281234567890", "Budi", "1990-01-15", "2023-03-15"},
+		{"6281234567891", "Siti", "1990-01-16", "2023-03-16"},
+	}, rows)
+}
+
+func TestIsDateNumFmt(t *testing.T) {
+	custom := func(format string) *string { return &format }
+
+	tests := []struct {
+		name         string
+		numFmt       int
+		customNumFmt *string
+		want         bool
+	}{
+		{name: "general", numFmt: 0, want: false},
+		{name: "built-in date", numFmt: 14, want: true},
+		{name: "built-in date and time", numFmt: 22, want: true},
+		{name: "built-in time only", numFmt: 20, want: false},
+		{name: "custom date", customNumFmt: custom("yyyy-mm-dd"), want: true},
+		{name: "custom time only", customNumFmt: custom("hh:mm"), want: false},
+		{name: "day only in quoted text", customNumFmt: custom("0 \"days\""), want: false},
+		{name: "day only in a color section", customNumFmt: custom("[Red]0.00"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isDateNumFmt(tt.numFmt, tt.customNumFmt))
+		})
+	}
+}
+
+func TestXLSXReader_ReadAll_NotAWorkbook(t *testing.T) {
+	_, err := (&xlsxReader{}).ReadAll(strings.NewReader("phone_number,name\n"))
+
+	assert.Error(t, err)
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"phone_number", "name", "job_title"},
+		{"+6281234567890", "Budi, S.T.", ""},
+		{"+6281234567891", "Siti", "Engineer"},
+	}
+
+	for _, format := range []string{FormatCSV, FormatXLSX} {
+		t.Run(format, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			writer, err := Tabular.NewWriter(format, &buf)
+			require.NoError(t, err)
+			for _, row := range rows {
+				require.NoError(t, writer.WriteRow(row))
+			}
+			require.NoError(t, writer.Close())
+
+			got, err := Tabular.Parse(format, &buf)
+			require.NoError(t, err)
+
+			// Excel leaves out trailing empty cells
+			want := rows
+			if format == FormatXLSX {
+				want = [][]string{rows[0], {"+6281234567890", "Budi, S.T."}, rows[2]}
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := Tabular.NewWriter("pdf", &bytes.Buffer{})
+
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
@@ -0,0 +1,190 @@
+package tabular
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var ErrNoSheets = errors.New("workbook has no sheets")
+
+// xlsxReader reads the first sheet of an Excel workbook. Cells are read as
+// they're stored rather than as Excel displays them, so a number formatted as
+// 6.28E+12 comes back in full. Dates are stored as serial numbers and come
+// back as YYYY-MM-DD.
+type xlsxReader struct{}
+
+func (r *xlsxReader) ReadAll(file io.Reader) ([][]string, error) {
+	workbook, err := excelize.OpenReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer workbook.Close()
+
+	sheets := workbook.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, ErrNoSheets
+	}
+	sheet := sheets[0]
+
+	rows, err := workbook.GetRows(sheet, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, err
+	}
+
+	props, err := workbook.GetWorkbookProps()
+	if err != nil {
+		return nil, err
+	}
+	date1904 := props.Date1904 != nil && *props.Date1904
+
+	// Whether each cell style formats a date, as most cells share a few styles
+	dateStyles := map[int]bool{}
+
+	for i, row := range rows {
+		for j, value := range row {
+			serial, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			cell, err := excelize.CoordinatesToCellName(j+1, i+1)
+			if err != nil {
+				return nil, err
+			}
+
+			isDate, err := isDateCell(workbook, sheet, cell, dateStyles)
+			if err != nil {
+				return nil, err
+			}
+			if !isDate {
+				continue
+			}
+
+			date, err := excelize.ExcelDateToTime(serial, date1904)
+			if err != nil {
+				continue
+			}
+			row[j] = date.Format(time.DateOnly)
+		}
+	}
+
+	return rows, nil
+}
+
+// isDateCell reports whether cell holds a number formatted as a date
+func isDateCell(workbook *excelize.File, sheet string, cell string, dateStyles map[int]bool) (bool, error) {
+	cellType, err := workbook.GetCellType(sheet, cell)
+	if err != nil {
+		return false, err
+	}
+	if cellType != excelize.CellTypeUnset && cellType != excelize.CellTypeNumber && cellType != excelize.CellTypeDate {
+		return false, nil
+	}
+
+	styleID, err := workbook.GetCellStyle(sheet, cell)
+	if err != nil {
+		return false, err
+	}
+
+	isDate, ok := dateStyles[styleID]
+	if !ok {
+		style, err := workbook.GetStyle(styleID)
+		if err != nil {
+			return false, err
+		}
+
+		isDate = isDateNumFmt(style.NumFmt, style.CustomNumFmt)
+		dateStyles[styleID] = isDate
+	}
+
+	return isDate, nil
+}
+
+// isDateNumFmt reports whether a number format shows a date. Built-in formats
+// are matched on ID, and custom ones on having a day or year in them once
+// literal text and [...] sections are left out.
+func isDateNumFmt(numFmt int, customNumFmt *string) bool {
+	if customNumFmt == nil {
+		return (numFmt >= 14 && numFmt <= 17) || numFmt == 22 ||
+			(numFmt >= 27 && numFmt <= 36) || (numFmt >= 50 && numFmt <= 58)
+	}
+
+	inQuotes := false
+	inBrackets := false
+	for _, c := range strings.ToLower(*customNumFmt) {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case inQuotes:
+		case c == '[':
+			inBrackets = true
+		case c == ']':
+			inBrackets = false
+		case inBrackets:
+		case c == 'd' || c == 'y':
+			return true
+		}
+	}
+
+	return false
+}
+
+// xlsxWriter writes a single sheet workbook. Rows go through excelize's
+// stream writer, which spills to a temporary file instead of holding the
+// whole sheet in memory, and the workbook is written out on Close.
+type xlsxWriter struct {
+	out      io.Writer
+	workbook *excelize.File
+	stream   *excelize.StreamWriter
+	rows     int
+}
+
+func newXLSXWriter(w io.Writer) (Writer, error) {
+	workbook := excelize.NewFile()
+
+	stream, err := workbook.NewStreamWriter(workbook.GetSheetName(0))
+	if err != nil {
+		workbook.Close()
+		return nil, err
+	}
+
+	return &xlsxWriter{out: w, workbook: workbook, stream: stream}, nil
+}
+
+func (w *xlsxWriter) WriteRow(row []string) error {
+	w.rows++
+
+	cell, err := excelize.CoordinatesToCellName(1, w.rows)
+	if err != nil {
+		return err
+	}
+
+	// Strings are written as text, so phone numbers keep their leading +
+	values := make([]any, len(row))
+	for i := range row {
+		values[i] = row[i]
+	}
+
+	return w.stream.SetRow(cell, values)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.workbook.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return err
+	}
+
+	return w.workbook.Write(w.out)
+}
+
+// Discard removes the stream writer's temporary file without writing the
+// workbook
+func (w *xlsxWriter) Discard() {
+	w.workbook.Close()
+}
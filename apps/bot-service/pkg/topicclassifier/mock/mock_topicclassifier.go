@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/topicclassifier (interfaces: TopicClassifierInterface)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mock/mock_topicclassifier.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/topicclassifier TopicClassifierInterface
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTopicClassifierInterface is a mock of TopicClassifierInterface interface.
+type MockTopicClassifierInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTopicClassifierInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTopicClassifierInterfaceMockRecorder is the mock recorder for MockTopicClassifierInterface.
+type MockTopicClassifierInterfaceMockRecorder struct {
+	mock *MockTopicClassifierInterface
+}
+
+// NewMockTopicClassifierInterface creates a new mock instance.
+func NewMockTopicClassifierInterface(ctrl *gomock.Controller) *MockTopicClassifierInterface {
+	mock := &MockTopicClassifierInterface{ctrl: ctrl}
+	mock.recorder = &MockTopicClassifierInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTopicClassifierInterface) EXPECT() *MockTopicClassifierInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Classify mocks base method.
+func (m *MockTopicClassifierInterface) Classify(ctx context.Context, topics, questions []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Classify", ctx, topics, questions)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Classify indicates an expected call of Classify.
+func (mr *MockTopicClassifierInterfaceMockRecorder) Classify(ctx, topics, questions any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Classify", reflect.TypeOf((*MockTopicClassifierInterface)(nil).Classify), ctx, topics, questions)
+}
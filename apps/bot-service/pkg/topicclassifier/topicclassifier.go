@@ -0,0 +1,115 @@
+package topicclassifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//go:generate mockgen -destination=mock/mock_topicclassifier.go -package=mock github.com/ahargunyllib/hc-ppn-app/apps/bot-service/pkg/topicclassifier TopicClassifierInterface
+
+// ErrInvalidResponse is returned when the model's reply isn't the JSON the
+// prompt asked for.
+var ErrInvalidResponse = errors.New("topic classifier returned an invalid response")
+
+// Questions and topic names are cut to this many characters so one long
+// message can't blow up the prompt
+const (
+	maxQuestionLength = 500
+	maxTopicLength    = 100
+)
+
+const classifyInstruction = `Kamu mengelompokkan pertanyaan karyawan ke chatbot HC (Human Capital) ke dalam topik.
+Untuk setiap pertanyaan, pilih satu topik dari daftar topik yang ada jika cocok. Jika tidak ada yang cocok, usulkan topik baru yang singkat (maksimal 4 kata, huruf kapital di awal kata). Jika pesan bukan pertanyaan seputar HC (misalnya salam atau ucapan terima kasih), isi topik dengan string kosong.
+Balas HANYA dengan JSON array tanpa teks lain, satu objek per pertanyaan: [{"index": 0, "topic": "Cuti Tahunan"}]`
+
+// TopicClassifierInterface puts questions into topics
+type TopicClassifierInterface interface {
+	// Classify returns one topic per question, in the same order. Each topic
+	// is one of topics or a new one, or "" when the question has none.
+	Classify(ctx context.Context, topics []string, questions []string) ([]string, error)
+}
+
+// GeminiClient is the part of genai.CustomGenAIInterface the classifier uses.
+type GeminiClient interface {
+	Chat(ctx context.Context, texts []string) (string, error)
+}
+
+type geminiClassifier struct {
+	client GeminiClient
+}
+
+// NewGeminiClassifier returns a classifier that asks Gemini to pick the
+// topics
+func NewGeminiClassifier(client GeminiClient) TopicClassifierInterface {
+	return &geminiClassifier{client: client}
+}
+
+type classification struct {
+	Index int    `json:"index"`
+	Topic string `json:"topic"`
+}
+
+func (c *geminiClassifier) Classify(ctx context.Context, topics []string, questions []string) ([]string, error) {
+	if len(questions) == 0 {
+		return []string{}, nil
+	}
+
+	var topicList strings.Builder
+	topicList.WriteString("Daftar topik yang ada:\n")
+	if len(topics) == 0 {
+		topicList.WriteString("(belum ada)\n")
+	}
+	for _, topic := range topics {
+		topicList.WriteString("- " + topic + "\n")
+	}
+
+	var questionList strings.Builder
+	questionList.WriteString("Pertanyaan:\n")
+	for i, question := range questions {
+		question = strings.Join(strings.Fields(truncate(question, maxQuestionLength)), " ")
+		fmt.Fprintf(&questionList, "%d. %s\n", i, question)
+	}
+
+	text, err := c.client.Chat(ctx, []string{classifyInstruction, topicList.String(), questionList.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	return parseClassifications(text, len(questions))
+}
+
+// parseClassifications reads the JSON array out of text, which models tend to
+// wrap in a code fence. Questions the reply skips get no topic.
+func parseClassifications(text string, count int) ([]string, error) {
+	start := strings.Index(text, "[")
+	end := strings.LastIndex(text, "]")
+	if start == -1 || end < start {
+		return nil, ErrInvalidResponse
+	}
+
+	var classifications []classification
+	if err := json.Unmarshal([]byte(text[start:end+1]), &classifications); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidResponse, err)
+	}
+
+	result := make([]string, count)
+	for _, c := range classifications {
+		if c.Index < 0 || c.Index >= count {
+			continue
+		}
+		result[c.Index] = truncate(strings.TrimSpace(c.Topic), maxTopicLength)
+	}
+
+	return result, nil
+}
+
+func truncate(s string, length int) string {
+	runes := []rune(s)
+	if len(runes) <= length {
+		return s
+	}
+	return string(runes[:length])
+}
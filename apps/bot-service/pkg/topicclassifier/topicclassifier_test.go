@@ -0,0 +1,102 @@
+package topicclassifier
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGemini struct {
+	reply string
+	err   error
+	texts []string
+}
+
+func (g *fakeGemini) Chat(ctx context.Context, texts []string) (string, error) {
+	g.texts = texts
+	return g.reply, g.err
+}
+
+func TestGeminiClassifier_Classify(t *testing.T) {
+	errDown := errors.New("down")
+
+	tests := []struct {
+		name      string
+		reply     string
+		err       error
+		questions []string
+		want      []string
+		wantErr   error
+	}{
+		{
+			name:      "success - plain JSON",
+			reply:     `[{"index": 0, "topic": "Cuti Tahunan"}, {"index": 1, "topic": "Payroll"}]`,
+			questions: []string{"cara ajukan cuti?", "kapan gajian?"},
+			want:      []string{"Cuti Tahunan", "Payroll"},
+		},
+		{
+			name:      "success - fenced JSON with skipped and out of range indexes",
+			reply:     "```json\n[{\"index\": 1, \"topic\": \" BPJS \"}, {\"index\": 7, \"topic\": \"Lain\"}]\n```",
+			questions: []string{"terima kasih", "bpjs saya belum aktif"},
+			want:      []string{"", "BPJS"},
+		},
+		{
+			name:      "no questions",
+			questions: []string{},
+			want:      []string{},
+		},
+		{
+			name:      "not JSON",
+			reply:     "Maaf, saya tidak bisa membantu",
+			questions: []string{"halo"},
+			wantErr:   ErrInvalidResponse,
+		},
+		{
+			name:      "malformed JSON",
+			reply:     `[{"index": "zero"}]`,
+			questions: []string{"halo"},
+			wantErr:   ErrInvalidResponse,
+		},
+		{
+			name:      "gemini error",
+			err:       errDown,
+			questions: []string{"halo"},
+			wantErr:   errDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeGemini{reply: tt.reply, err: tt.err}
+			classifier := NewGeminiClassifier(client)
+
+			got, err := classifier.Classify(context.Background(), []string{"Cuti Tahunan"}, tt.questions)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGeminiClassifier_Classify_Prompt(t *testing.T) {
+	client := &fakeGemini{reply: "[]"}
+	classifier := NewGeminiClassifier(client)
+
+	long := strings.Repeat("a", maxQuestionLength+50)
+	_, err := classifier.Classify(context.Background(), []string{"Cuti Tahunan", "Payroll"}, []string{"cara\n ajukan   cuti?", long})
+	require.NoError(t, err)
+
+	require.Len(t, client.texts, 3)
+	assert.Contains(t, client.texts[1], "- Cuti Tahunan\n- Payroll\n")
+	assert.Contains(t, client.texts[2], "0. cara ajukan cuti?\n")
+	assert.Contains(t, client.texts[2], "1. "+strings.Repeat("a", maxQuestionLength)+"\n")
+}